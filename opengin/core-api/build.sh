@@ -4,5 +4,5 @@
 echo "Building all packages..."
 go build -v ./...
 echo "Building core-service binary..."
-go build -v -o core-service cmd/server/service.go cmd/server/utils.go
+go build -v -o core-service ./cmd/server
 echo "Build complete!"
\ No newline at end of file
@@ -0,0 +1,148 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/logging"
+)
+
+// Number of audit records returned by default and at most
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// ReadAuditLog returns the audit records matching the filters of the request, the most recent first
+func (s *Server) ReadAuditLog(ctx context.Context, req *pb.ReadAuditLogRequest) (*pb.AuditLog, error) {
+	if s.audit == nil {
+		return nil, apperrors.FailedPreconditionf("the audit log is disabled")
+	}
+	logging.FromContext(ctx).Debug("Reading audit log", "entity_id", req.EntityId, "actor", req.Actor, "start_time", req.StartTime, "end_time", req.EndTime)
+
+	query := audit.Query{EntityID: req.EntityId, Actor: req.Actor}
+	var err error
+	if query.From, err = parseAuditTime(req.StartTime, "startTime"); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseAuditTime(req.EndTime, "endTime"); err != nil {
+		return nil, err
+	}
+	if req.PageToken != "" {
+		after, err := base64.RawURLEncoding.DecodeString(req.PageToken)
+		if err != nil || len(after) == 0 {
+			return nil, apperrors.InvalidArgumentf("invalid page token").WithField("pageToken")
+		}
+		query.After = string(after)
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultAuditPageSize
+	}
+	pageSize = min(pageSize, maxAuditPageSize)
+	// One more record tells whether there is a next page
+	query.Limit = pageSize + 1
+
+	records, err := s.audit.Find(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+	response := &pb.AuditLog{}
+	if len(records) > pageSize {
+		records = records[:pageSize]
+		response.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(records[pageSize-1].ID))
+	}
+	for _, record := range records {
+		response.Records = append(response.Records, auditRecordOf(record))
+	}
+	return response, nil
+}
+
+// parseAuditTime parses a time of the request, returning the zero time if it is empty
+func parseAuditTime(value, field string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, apperrors.InvalidArgumentf("invalid %s %q, expected an RFC 3339 time", field, value).WithField(field)
+	}
+	return parsed, nil
+}
+
+func auditRecordOf(record *audit.Record) *pb.AuditRecord {
+	result := &pb.AuditRecord{
+		Id:         record.ID,
+		Time:       record.Time.UTC().Format(time.RFC3339Nano),
+		Actor:      record.Actor,
+		AuthMethod: record.AuthMethod,
+		Method:     record.Method,
+		RequestId:  record.RequestID,
+		EntityId:   record.EntityID,
+	}
+	for _, change := range record.Changes {
+		result.Changes = append(result.Changes, &pb.AuditChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+	for _, attribute := range record.Attributes {
+		result.Attributes = append(result.Attributes, &pb.AuditAttributeWrite{Name: attribute.Name, Values: int32(attribute.Values), Deleted: attribute.Deleted})
+	}
+	return result
+}
+
+// auditSnapshot reads the audited fields of a stored entity, before a write changes them.
+// It reads nothing when auditing is disabled.
+func (s *Server) auditSnapshot(ctx context.Context, entityID string) (audit.Snapshot, error) {
+	if s.audit == nil {
+		return nil, nil
+	}
+	kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading entity %s for the audit log: %w", entityID, err)
+	}
+	metadata, err := s.mongoRepo.GetMetadata(ctx, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata of entity %s for the audit log: %w", entityID, err)
+	}
+	relationships, err := s.neo4jRepo.GetGraphRelationships(ctx, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading relationships of entity %s for the audit log: %w", entityID, err)
+	}
+	return auditSnapshotOf(&pb.Entity{
+		Id:            entityID,
+		Kind:          kind,
+		Name:          name,
+		Created:       created,
+		Terminated:    terminated,
+		Metadata:      metadata,
+		Relationships: relationships,
+	}), nil
+}
+
+// auditSnapshotOf returns the audited fields of the entity. The relationships to attribute nodes
+// are left out, the attribute writes are audited on their own.
+func auditSnapshotOf(entity *pb.Entity) audit.Snapshot {
+	relationships := make(map[string]*pb.Relationship, len(entity.Relationships))
+	for id, relationship := range entity.Relationships {
+		if relationship.Name != engine.IS_ATTRIBUTE_RELATIONSHIP {
+			relationships[id] = relationship
+		}
+	}
+	return audit.SnapshotOf(&pb.Entity{
+		Kind:          entity.Kind,
+		Name:          entity.Name,
+		Created:       entity.Created,
+		Terminated:    entity.Terminated,
+		Metadata:      entity.Metadata,
+		Relationships: relationships,
+	})
+}
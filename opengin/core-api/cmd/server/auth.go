@@ -0,0 +1,40 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/auth"
+)
+
+// authPolicy maps the roles to the COREService methods. Readers serve the dashboards,
+// writers are the ingestion jobs, which also read what they are about to change.
+// Admins operate the server and are given the other roles as well if they need them,
+// auditors only read who changed what.
+var authPolicy = auth.Policy{
+	// Orchestrators probe health without credentials, and the schema is public anyway
+	Public: []string{
+		"/grpc.health.v1.Health/",
+		"/grpc.reflection.v1.ServerReflection/",
+		"/grpc.reflection.v1alpha.ServerReflection/",
+	},
+	Roles: map[string][]auth.Role{
+		pb.COREService_ReadEntity_FullMethodName:          {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_ReadEntities_FullMethodName:        {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_WatchEntities_FullMethodName:       {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_ReadEntityHistory_FullMethodName:   {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_DiffEntity_FullMethodName:          {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_Traverse_FullMethodName:            {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_FindPaths_FullMethodName:           {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_ReadHierarchy_FullMethodName:       {auth.RoleReader, auth.RoleWriter},
+		pb.COREService_CreateEntity_FullMethodName:        {auth.RoleWriter},
+		pb.COREService_UpdateEntity_FullMethodName:        {auth.RoleWriter},
+		pb.COREService_DeleteEntity_FullMethodName:        {auth.RoleWriter},
+		pb.COREService_BatchCreateEntities_FullMethodName: {auth.RoleWriter},
+
+		pb.COREService_ListFailedWebhookDeliveries_FullMethodName: {auth.RoleAdmin},
+		pb.COREService_ReplayWebhookDeliveries_FullMethodName:     {auth.RoleAdmin},
+		pb.COREService_ReadAuditLog_FullMethodName:                {auth.RoleAdmin, auth.RoleAuditor},
+	},
+}
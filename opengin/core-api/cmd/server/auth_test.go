@@ -0,0 +1,47 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthPolicyCoversEveryMethod(t *testing.T) {
+	service := pb.COREService_ServiceDesc.ServiceName
+	var methods []string
+	for _, method := range pb.COREService_ServiceDesc.Methods {
+		methods = append(methods, "/"+service+"/"+method.MethodName)
+	}
+	for _, stream := range pb.COREService_ServiceDesc.Streams {
+		methods = append(methods, "/"+service+"/"+stream.StreamName)
+	}
+
+	for _, method := range methods {
+		assert.Contains(t, authPolicy.Roles, method, "Expected %s to be in the auth policy, or it cannot be called with auth enabled", method)
+	}
+}
+
+func TestAuthPolicyRoles(t *testing.T) {
+	reader := &auth.Identity{Subject: "dashboard", Roles: []auth.Role{auth.RoleReader}}
+	writer := &auth.Identity{Subject: "loader", Roles: []auth.Role{auth.RoleWriter}}
+	admin := &auth.Identity{Subject: "operator", Roles: []auth.Role{auth.RoleAdmin}}
+
+	assert.True(t, authPolicy.Allows(reader, pb.COREService_ReadEntities_FullMethodName))
+	assert.False(t, authPolicy.Allows(reader, pb.COREService_DeleteEntity_FullMethodName))
+	assert.True(t, authPolicy.Allows(writer, pb.COREService_DeleteEntity_FullMethodName))
+	assert.True(t, authPolicy.Allows(writer, pb.COREService_ReadEntity_FullMethodName))
+	assert.False(t, authPolicy.Allows(writer, pb.COREService_ReplayWebhookDeliveries_FullMethodName))
+	assert.True(t, authPolicy.Allows(admin, pb.COREService_ReplayWebhookDeliveries_FullMethodName))
+	assert.False(t, authPolicy.Allows(admin, pb.COREService_DeleteEntity_FullMethodName))
+
+	auditor := &auth.Identity{Subject: "ombudsman", Roles: []auth.Role{auth.RoleAuditor}}
+	assert.True(t, authPolicy.Allows(auditor, pb.COREService_ReadAuditLog_FullMethodName))
+	assert.False(t, authPolicy.Allows(auditor, pb.COREService_ReadEntity_FullMethodName))
+	assert.False(t, authPolicy.Allows(reader, pb.COREService_ReadAuditLog_FullMethodName))
+}
@@ -0,0 +1,340 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	neo4jrepository "lk/datafoundation/core-api/db/repository/neo4j"
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/saga"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// batchCreateSize is the number of streamed entities written together
+const batchCreateSize = 500
+
+// BatchCreateEntities creates the entities of a client stream and returns one result per entity.
+// Entities are written in batches: the nodes of a batch are created in one Neo4j transaction, the
+// metadata in one MongoDB insert and the relationships in another Neo4j transaction. A failing entity
+// is rolled back on its own and only affects the entities of its batch with a relationship to it.
+// A relationship can only point at an entity created earlier or in the same batch.
+func (s *Server) BatchCreateEntities(stream pb.COREService_BatchCreateEntitiesServer) error {
+	ctx := stream.Context()
+	response := &pb.BatchCreateEntitiesResponse{}
+	processor := s.processor
+
+	var batch []*pb.Entity
+	for {
+		entity, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Error receiving entity", "error", err)
+			return err
+		}
+
+		batch = append(batch, entity)
+		if len(batch) == batchCreateSize {
+			s.createEntityBatch(ctx, processor, batch, response)
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		s.createEntityBatch(ctx, processor, batch, response)
+	}
+
+	logging.FromContext(ctx).Info("Created entities", "created", response.Created, "failed", response.Failed)
+	return stream.SendAndClose(response)
+}
+
+// batchItem tracks a single entity through the steps of a batch
+type batchItem struct {
+	entity *pb.Entity
+	result *pb.BatchCreateEntityResult
+	saga   *saga.Saga
+}
+
+// createEntityBatch writes a batch of entities and appends their results to the response.
+// Relationships are created after the attributes so that an entity failing in an earlier step is
+// rolled back before other entities of the batch can point at it. An entity failing in a later step
+// takes the entities of the batch pointing at it with it.
+func (s *Server) createEntityBatch(ctx context.Context, processor *engine.EntityAttributeProcessor, entities []*pb.Entity, response *pb.BatchCreateEntitiesResponse) {
+	ctx, span := tracing.Start(ctx, "Server.createEntityBatch", attribute.Int("core.batch.size", len(entities)))
+	defer span.End()
+
+	items := make([]*batchItem, len(entities))
+	seen := make(map[string]bool)
+	for i, entity := range entities {
+		entity.Version = neo4jrepository.InitialEntityVersion
+		items[i] = &batchItem{
+			entity: entity,
+			result: &pb.BatchCreateEntityResult{Index: int32(len(response.Results) + i), Id: entity.Id, Success: true},
+			saga:   saga.New("CreateEntity " + entity.Id),
+		}
+		switch {
+		case entity.Id == "":
+			items[i].fail(ctx, apperrors.InvalidArgumentf("entity id is required").WithField("id"))
+		case seen[entity.Id]:
+			items[i].fail(ctx, apperrors.InvalidArgumentf("entity %s appears more than once in the batch", entity.Id).WithEntity(entity.Id).WithField("id"))
+		}
+		seen[entity.Id] = true
+	}
+
+	// Entity nodes
+	pending := pendingItems(items)
+	failures := s.neo4jRepo.HandleGraphEntitiesCreation(ctx, entitiesOf(pending))
+	for _, item := range pending {
+		if err := failures[item.entity.Id]; err != nil {
+			item.fail(ctx, err)
+			continue
+		}
+		entityID := item.entity.Id
+		item.saga.Record("create entity node", func(ctx context.Context) error {
+			return s.neo4jRepo.DeleteGraphEntity(ctx, entityID)
+		})
+	}
+
+	// Metadata
+	var withMetadata []*batchItem
+	for _, item := range pendingItems(items) {
+		if len(item.entity.GetMetadata()) > 0 {
+			withMetadata = append(withMetadata, item)
+		}
+	}
+	failures, err := s.mongoRepo.CreateEntities(ctx, entitiesOf(withMetadata))
+	for _, item := range withMetadata {
+		itemErr := err
+		if itemErr == nil {
+			itemErr = failures[item.entity.Id]
+		}
+		switch {
+		case itemErr == nil:
+			entityID := item.entity.Id
+			item.saga.Record("create metadata", func(ctx context.Context) error {
+				_, err := s.mongoRepo.DeleteEntity(ctx, entityID)
+				return err
+			})
+		case mongo.IsDuplicateKeyError(itemErr):
+			// Metadata left behind by an earlier attempt is replaced like CreateEntity does
+			if err := s.handleMetadata(ctx, item.saga, item.entity.Id, item.entity); err != nil {
+				item.fail(ctx, err)
+			}
+		default:
+			item.fail(ctx, itemErr)
+		}
+	}
+
+	// Revisions of the names and metadata
+	for _, item := range pendingItems(items) {
+		if err := s.recordRevisions(ctx, item.saga, item.entity); err != nil {
+			item.fail(ctx, err)
+		}
+	}
+
+	// Attributes
+	s.createBatchAttributes(ctx, processor, pendingItems(items))
+
+	// Relationships
+	var withRelationships []*batchItem
+	for _, item := range pendingItems(items) {
+		if len(item.entity.Relationships) > 0 {
+			withRelationships = append(withRelationships, item)
+		}
+	}
+	failures = s.neo4jRepo.HandleGraphRelationshipsBatchCreate(ctx, entitiesOf(withRelationships))
+	for _, item := range withRelationships {
+		if err := failures[item.entity.Id]; err != nil {
+			item.fail(ctx, err)
+			continue
+		}
+		for _, relationship := range item.entity.Relationships {
+			relationshipID := relationship.Id
+			item.saga.Record("create relationship "+relationshipID, func(ctx context.Context) error {
+				return s.neo4jRepo.DeleteRelationship(ctx, relationshipID)
+			})
+		}
+	}
+
+	// Events for the webhooks, held until the audit records of the batch were appended
+	events := make(map[string][]*pb.EntityEvent)
+	eventIDs := make(map[string][]string)
+	for _, item := range pendingItems(items) {
+		if !item.result.Success {
+			continue // Failed with an entity it points at
+		}
+		events[item.entity.Id] = createdEvents(item.entity)
+		ids, err := s.recordEvents(ctx, item.saga, events[item.entity.Id])
+		if err != nil {
+			failLinked(ctx, items, item, err)
+			continue
+		}
+		eventIDs[item.entity.Id] = ids
+	}
+
+	// Audit records, last as they cannot be undone
+	for _, item := range relatedFirst(pendingItems(items)) {
+		if !item.result.Success {
+			continue // Failed with an entity it points at
+		}
+		changes := audit.Diff(nil, auditSnapshotOf(item.entity))
+		if err := s.audit.Append(ctx, item.entity.Id, changes, audit.AttributeWrites(item.entity.Attributes)); err != nil {
+			failLinked(ctx, items, item, err)
+		}
+	}
+
+	// An entity can still fail while the entities it is linked to are audited, its events are only
+	// released once the whole batch was
+	var released []string
+	for _, item := range pendingItems(items) {
+		released = append(released, eventIDs[item.entity.Id]...)
+	}
+	s.releaseEvents(ctx, released)
+
+	for _, item := range items {
+		response.Results = append(response.Results, item.result)
+		if item.result.Success {
+			s.events.Publish(events[item.entity.Id]...)
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+}
+
+// createBatchAttributes writes the attributes of the items, with the tabular attributes of all of them
+// in one Postgres transaction. Each entity writes after a savepoint, so that a failing entity only
+// rolls back its own tables.
+func (s *Server) createBatchAttributes(ctx context.Context, processor *engine.EntityAttributeProcessor, items []*batchItem) {
+	var withAttributes []*batchItem
+	for _, item := range items {
+		if len(item.entity.Attributes) > 0 {
+			withAttributes = append(withAttributes, item)
+		}
+	}
+	if len(withAttributes) == 0 {
+		return
+	}
+
+	tx, err := s.postgresRepo.Begin(ctx)
+	if err != nil {
+		for _, item := range withAttributes {
+			item.fail(ctx, err)
+		}
+		return
+	}
+	defer tx.Rollback()
+	processor = processor.WithPostgres(tx)
+
+	var written []*batchItem
+	for _, item := range withAttributes {
+		if err := tx.Savepoint(ctx, "entity"); err != nil {
+			item.fail(ctx, fmt.Errorf("error creating savepoint of entity %s: %w", item.entity.Id, err))
+			continue
+		}
+		if err := s.handleAttributes(ctx, item.saga, processor, item.entity, true); err != nil {
+			// The tables of the entity are rolled back here, its saga removes the rest
+			if rollbackErr := tx.RollbackToSavepoint(ctx, "entity"); rollbackErr != nil {
+				err = errors.Join(err, fmt.Errorf("error rolling back tables of entity %s: %w", item.entity.Id, rollbackErr))
+			}
+			item.fail(ctx, err)
+			continue
+		}
+		if err := tx.ReleaseSavepoint(ctx, "entity"); err != nil {
+			item.fail(ctx, fmt.Errorf("error releasing savepoint of entity %s: %w", item.entity.Id, err))
+			continue
+		}
+		written = append(written, item)
+	}
+
+	if err := tx.Commit(); err != nil {
+		for _, item := range written {
+			item.fail(ctx, err)
+		}
+	}
+}
+
+// fail marks the entity as failed and rolls back what was already written for it
+func (item *batchItem) fail(ctx context.Context, cause error) {
+	err := item.saga.Abort(ctx, cause)
+	logging.FromContext(ctx).Error("Error creating entity", "entity_id", item.entity.Id, "error", err)
+	item.result.Success = false
+	item.result.Error = err.Error()
+}
+
+// failLinked fails an item whose relationships were already created. The pending items with a
+// relationship pointing at it are failed first, so that its node has no edges left when it is deleted.
+func failLinked(ctx context.Context, items []*batchItem, item *batchItem, cause error) {
+	// Marked first so that relationships pointing back at the item do not fail it again
+	item.result.Success = false
+	for _, other := range pendingItems(items) {
+		for _, relationship := range other.entity.Relationships {
+			if relationship.RelatedEntityId == item.entity.Id {
+				failLinked(ctx, items, other, apperrors.FailedPreconditionf("related entity %s of the batch failed", item.entity.Id).WithEntity(other.entity.Id).WithRelationship(relationship.Id))
+				break
+			}
+		}
+	}
+	item.fail(ctx, cause)
+}
+
+// relatedFirst orders the items so that each comes after the items of the batch it points at. An
+// item failing its audit then only fails items that have no audit record yet, except in a cycle.
+func relatedFirst(items []*batchItem) []*batchItem {
+	byID := make(map[string]*batchItem, len(items))
+	for _, item := range items {
+		byID[item.entity.Id] = item
+	}
+	ordered := make([]*batchItem, 0, len(items))
+	visited := make(map[string]bool, len(items))
+	var visit func(item *batchItem)
+	visit = func(item *batchItem) {
+		if visited[item.entity.Id] {
+			return
+		}
+		visited[item.entity.Id] = true
+		for _, relationship := range item.entity.Relationships {
+			if related, ok := byID[relationship.RelatedEntityId]; ok {
+				visit(related)
+			}
+		}
+		ordered = append(ordered, item)
+	}
+	for _, item := range items {
+		visit(item)
+	}
+	return ordered
+}
+
+// pendingItems returns the items that have not failed yet
+func pendingItems(items []*batchItem) []*batchItem {
+	var pending []*batchItem
+	for _, item := range items {
+		if item.result.Success {
+			pending = append(pending, item)
+		}
+	}
+	return pending
+}
+
+// entitiesOf returns the entities of the items
+func entitiesOf(items []*batchItem) []*pb.Entity {
+	entities := make([]*pb.Entity, len(items))
+	for i, item := range items {
+		entities[i] = item.entity
+	}
+	return entities
+}
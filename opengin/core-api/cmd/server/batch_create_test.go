@@ -0,0 +1,59 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/saga"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newBatchItem returns a pending item of an entity with relationships to the related entities
+func newBatchItem(id string, related ...string) *batchItem {
+	entity := &pb.Entity{Id: id, Relationships: make(map[string]*pb.Relationship)}
+	for _, relatedID := range related {
+		entity.Relationships[id+"-"+relatedID] = &pb.Relationship{Id: id + "-" + relatedID, RelatedEntityId: relatedID}
+	}
+	return &batchItem{
+		entity: entity,
+		result: &pb.BatchCreateEntityResult{Id: id, Success: true},
+		saga:   saga.New("CreateEntity " + id),
+	}
+}
+
+func TestFailLinked(t *testing.T) {
+	a := newBatchItem("a", "b")
+	b := newBatchItem("b", "c")
+	c := newBatchItem("c", "b")
+	d := newBatchItem("d", "existing")
+	items := []*batchItem{a, b, c, d}
+
+	failLinked(context.Background(), items, c, errors.New("audit failed"))
+
+	assert.False(t, c.result.Success)
+	assert.Contains(t, c.result.Error, "audit failed")
+	assert.False(t, b.result.Success, "Expected the entity pointing at the failed one to fail")
+	assert.Contains(t, b.result.Error, "related entity c of the batch failed")
+	assert.False(t, a.result.Success, "Expected the failure to follow the relationships")
+	assert.Contains(t, a.result.Error, "related entity b of the batch failed")
+	assert.True(t, d.result.Success, "Expected an unrelated entity not to fail")
+}
+
+func TestRelatedFirst(t *testing.T) {
+	a := newBatchItem("a", "b", "c")
+	b := newBatchItem("b", "c")
+	c := newBatchItem("c", "existing")
+
+	ordered := relatedFirst([]*batchItem{a, b, c})
+	assert.Equal(t, []*batchItem{c, b, a}, ordered, "Expected every entity after the entities it points at")
+
+	x := newBatchItem("x", "y")
+	y := newBatchItem("y", "x")
+	assert.ElementsMatch(t, []*batchItem{x, y}, relatedFirst([]*batchItem{x, y}), "Expected a cycle to keep every entity")
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	"lk/datafoundation/core-api/db/repository/postgres"
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Types of the changes of a diff
+const (
+	changeAdded   = "ADDED"
+	changeRemoved = "REMOVED"
+	changeChanged = "CHANGED"
+)
+
+// entityState is what a diff compares of an entity at one time. An entity not yet created or already
+// terminated at the time has no name, metadata, relationships or attributes.
+type entityState struct {
+	at            time.Time
+	active        bool
+	name          string
+	metadata      map[string]*anypb.Any
+	relationships map[string]*pb.Relationship
+}
+
+// DiffEntity compares the entity as it was at the two times of the request and returns what changed:
+// its name, its metadata keys, its relationships and the rows of its tabular attributes
+func (s *Server) DiffEntity(ctx context.Context, req *pb.DiffEntityRequest) (*pb.EntityDiff, error) {
+	logging.FromContext(ctx).Debug("Diffing entity", "entity_id", req.Id, "from_time", req.FromTime, "to_time", req.ToTime)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+
+	if req.Id == "" {
+		return nil, apperrors.InvalidArgumentf("entity id is required").WithField("id")
+	}
+	if req.FromTime == "" {
+		return nil, apperrors.InvalidArgumentf("fromTime is required").WithField("fromTime")
+	}
+	from, err := parseEntityTime(req.FromTime, "fromTime")
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseOptionalTime(req.ToTime, "toTime")
+	if err != nil {
+		return nil, err
+	}
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if !to.After(from) {
+		return nil, apperrors.InvalidArgumentf("toTime %s must be after fromTime %s", formatTime(to), req.FromTime).WithField("toTime")
+	}
+
+	_, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching entity info: %w", err)
+	}
+	before, err := s.entityStateAt(ctx, req.Id, name, created, terminated, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.entityStateAt(ctx, req.Id, name, created, terminated, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &pb.EntityDiff{
+		Id:            req.Id,
+		FromTime:      formatTime(from),
+		ToTime:        formatTime(to),
+		Name:          diffNames(before.name, after.name),
+		Metadata:      diffMetadata(before.metadata, after.metadata),
+		Relationships: diffRelationships(before.relationships, after.relationships),
+	}
+	if diff.Attributes, err = s.diffAttributes(ctx, req.Id, before, after); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// entityStateAt reads the name, metadata and relationships the entity had at the time
+func (s *Server) entityStateAt(ctx context.Context, entityID string, name *pb.TimeBasedValue, created, terminated string, at time.Time) (*entityState, error) {
+	state := &entityState{at: at, active: checkActive(entityID, created, terminated, at) == nil}
+	if !state.active {
+		return state, nil
+	}
+	nameAt, err := s.readNameAt(ctx, entityID, name, at, created, terminated)
+	if err != nil {
+		return nil, err
+	}
+	state.name = nameOf(&pb.Entity{Name: nameAt})
+	if state.metadata, err = s.readMetadataAt(ctx, entityID, at); err != nil {
+		return nil, fmt.Errorf("error fetching metadata of entity %s: %w", entityID, err)
+	}
+	relationships, err := s.neo4jRepo.GetFilteredRelationships(ctx, entityID, "", "", "", "", "", "", formatTime(at), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching relationships of entity %s: %w", entityID, err)
+	}
+	state.relationships = make(map[string]*pb.Relationship, len(relationships))
+	for id, relationship := range relationships {
+		if relationship.Name != engine.IS_ATTRIBUTE_RELATIONSHIP {
+			state.relationships[id] = relationship
+		}
+	}
+	return state, nil
+}
+
+// diffAttributes compares the rows of the tabular attributes held at the two times. The other storage
+// types only keep their latest value and are left out.
+func (s *Server) diffAttributes(ctx context.Context, entityID string, before, after *entityState) ([]*pb.AttributeChange, error) {
+	values, err := s.postgresRepo.ListAttributeValues(ctx, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing attribute values of entity %s: %w", entityID, err)
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if !seen[value.AttributeName] {
+			seen[value.AttributeName] = true
+			names = append(names, value.AttributeName)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []*pb.AttributeChange
+	for _, name := range names {
+		beforeValue, err := s.attributeValueAt(ctx, entityID, name, before)
+		if err != nil {
+			return nil, err
+		}
+		afterValue, err := s.attributeValueAt(ctx, entityID, name, after)
+		if err != nil {
+			return nil, err
+		}
+		change := &pb.AttributeChange{Name: name}
+		switch {
+		case beforeValue == nil && afterValue == nil:
+			continue
+		case beforeValue == nil:
+			change.Type = changeAdded
+		case afterValue == nil:
+			change.Type = changeRemoved
+		case beforeValue.TableName == afterValue.TableName:
+			continue
+		default:
+			change.Type = changeChanged
+		}
+		beforeData, err := s.attributeData(ctx, entityID, beforeValue)
+		if err != nil {
+			return nil, err
+		}
+		afterData, err := s.attributeData(ctx, entityID, afterValue)
+		if err != nil {
+			return nil, err
+		}
+		if change.AddedRows, change.RemovedRows, err = postgres.DiffTabularData(beforeData, afterData); err != nil {
+			return nil, fmt.Errorf("error comparing values of attribute %s of entity %s: %w", name, entityID, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// attributeValueAt returns the value of the attribute held in the state, or nil if none was
+func (s *Server) attributeValueAt(ctx context.Context, entityID, name string, state *entityState) (*postgres.AttributeValue, error) {
+	if !state.active {
+		return nil, nil
+	}
+	value, err := s.postgresRepo.AttributeValueAt(ctx, entityID, name, state.at)
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of attribute %s of entity %s: %w", name, entityID, err)
+	}
+	return value, nil
+}
+
+// attributeData reads the rows of the attribute value, or nil without a value
+func (s *Server) attributeData(ctx context.Context, entityID string, value *postgres.AttributeValue) (*anypb.Any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := s.postgresRepo.GetData(ctx, value.TableName, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of attribute %s of entity %s: %w", value.AttributeName, entityID, err)
+	}
+	return data, nil
+}
+
+// diffNames returns the change of the name, or nil if it did not change
+func diffNames(before, after string) *pb.NameChange {
+	if before == after {
+		return nil
+	}
+	return &pb.NameChange{Before: before, After: after}
+}
+
+// diffMetadata returns the metadata keys that differ, ordered by key
+func diffMetadata(before, after map[string]*anypb.Any) []*pb.MetadataChange {
+	var changes []*pb.MetadataChange
+	for key, value := range before {
+		afterValue, ok := after[key]
+		switch {
+		case !ok:
+			changes = append(changes, &pb.MetadataChange{Key: key, Type: changeRemoved, Before: value})
+		case !proto.Equal(value, afterValue):
+			changes = append(changes, &pb.MetadataChange{Key: key, Type: changeChanged, Before: value, After: afterValue})
+		}
+	}
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, &pb.MetadataChange{Key: key, Type: changeAdded, After: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// diffRelationships returns the relationships that differ, ordered by name, related entity and id.
+// A relationship that ended and was followed by one with the same name, direction and related entity
+// is reported as changed rather than as removed and added.
+func diffRelationships(before, after map[string]*pb.Relationship) []*pb.RelationshipChange {
+	var changes []*pb.RelationshipChange
+	var removed []*pb.Relationship
+	for id, relationship := range before {
+		afterRelationship, ok := after[id]
+		switch {
+		case !ok:
+			removed = append(removed, relationship)
+		case !proto.Equal(relationship, afterRelationship):
+			changes = append(changes, &pb.RelationshipChange{Type: changeChanged, Before: relationship, After: afterRelationship})
+		}
+	}
+	added := make(map[string][]*pb.Relationship)
+	for id, relationship := range after {
+		if _, ok := before[id]; !ok {
+			key := relationshipKey(relationship)
+			added[key] = append(added[key], relationship)
+		}
+	}
+	for key := range added {
+		sort.Slice(added[key], func(i, j int) bool { return added[key][i].Id < added[key][j].Id })
+	}
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Id < removed[j].Id })
+
+	for _, relationship := range removed {
+		key := relationshipKey(relationship)
+		if successors := added[key]; len(successors) > 0 {
+			changes = append(changes, &pb.RelationshipChange{Type: changeChanged, Before: relationship, After: successors[0]})
+			added[key] = successors[1:]
+			continue
+		}
+		changes = append(changes, &pb.RelationshipChange{Type: changeRemoved, Before: relationship})
+	}
+	for _, relationships := range added {
+		for _, relationship := range relationships {
+			changes = append(changes, &pb.RelationshipChange{Type: changeAdded, After: relationship})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changedRelationship(changes[i]), changedRelationship(changes[j])
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.RelatedEntityId != b.RelatedEntityId {
+			return a.RelatedEntityId < b.RelatedEntityId
+		}
+		return a.Id < b.Id
+	})
+	return changes
+}
+
+// relationshipKey identifies the relationships that follow one another
+func relationshipKey(relationship *pb.Relationship) string {
+	return relationship.Name + "\x00" + relationship.Direction + "\x00" + relationship.RelatedEntityId
+}
+
+// changedRelationship returns the relationship a change is ordered by, the one before it when there is one
+func changedRelationship(change *pb.RelationshipChange) *pb.Relationship {
+	if change.Before != nil {
+		return change.Before
+	}
+	return change.After
+}
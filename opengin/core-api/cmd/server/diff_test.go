@@ -0,0 +1,64 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestDiffNames(t *testing.T) {
+	assert.Nil(t, diffNames("Ministry of Health", "Ministry of Health"))
+	assert.Equal(t, &pb.NameChange{Before: "Ministry of Health", After: "Ministry of Health and Mass Media"},
+		diffNames("Ministry of Health", "Ministry of Health and Mass Media"))
+}
+
+func TestDiffMetadata(t *testing.T) {
+	before := map[string]*anypb.Any{
+		"minister": {TypeUrl: "a"},
+		"website":  {TypeUrl: "b"},
+		"budget":   {TypeUrl: "c"},
+	}
+	after := map[string]*anypb.Any{
+		"minister": {TypeUrl: "d"},
+		"website":  {TypeUrl: "b"},
+		"address":  {TypeUrl: "e"},
+	}
+
+	changes := diffMetadata(before, after)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "address", changes[0].Key)
+	assert.Equal(t, changeAdded, changes[0].Type)
+	assert.Nil(t, changes[0].Before)
+	assert.Equal(t, "budget", changes[1].Key)
+	assert.Equal(t, changeRemoved, changes[1].Type)
+	assert.Nil(t, changes[1].After)
+	assert.Equal(t, "minister", changes[2].Key)
+	assert.Equal(t, changeChanged, changes[2].Type)
+	assert.Equal(t, "a", changes[2].Before.TypeUrl)
+	assert.Equal(t, "d", changes[2].After.TypeUrl)
+}
+
+func TestDiffRelationships(t *testing.T) {
+	kept := &pb.Relationship{Id: "r1", Name: "HAS_DEPARTMENT", RelatedEntityId: "health", Direction: "OUTGOING"}
+	ended := &pb.Relationship{Id: "r2", Name: "HAS_DEPARTMENT", RelatedEntityId: "education", Direction: "OUTGOING"}
+	headed := &pb.Relationship{Id: "r3", Name: "HEADED_BY", RelatedEntityId: "minister", Direction: "OUTGOING"}
+	reappointed := &pb.Relationship{Id: "r4", Name: "HEADED_BY", RelatedEntityId: "minister", Direction: "OUTGOING"}
+	started := &pb.Relationship{Id: "r5", Name: "HAS_DEPARTMENT", RelatedEntityId: "transport", Direction: "OUTGOING"}
+
+	changes := diffRelationships(
+		map[string]*pb.Relationship{"r1": kept, "r2": ended, "r3": headed},
+		map[string]*pb.Relationship{"r1": kept, "r4": reappointed, "r5": started})
+
+	require.Len(t, changes, 3)
+	assert.Equal(t, &pb.RelationshipChange{Type: changeRemoved, Before: ended}, changes[0])
+	assert.Equal(t, &pb.RelationshipChange{Type: changeAdded, After: started}, changes[1])
+	assert.Equal(t, &pb.RelationshipChange{Type: changeChanged, Before: headed, After: reappointed}, changes[2],
+		"Expected a relationship followed by one to the same entity to be changed")
+}
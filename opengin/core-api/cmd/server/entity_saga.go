@@ -0,0 +1,295 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	postgres "lk/datafoundation/core-api/db/repository/postgres"
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/saga"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The functions in this file perform the individual writes of CreateEntity and UpdateEntity.
+// Each of them records in the saga how to undo what it wrote, so that a request failing
+// halfway leaves the databases as they were before the request.
+
+// createGraphEntity creates the entity node in Neo4j
+func (s *Server) createGraphEntity(ctx context.Context, sg *saga.Saga, entity *pb.Entity) error {
+	success, err := s.neo4jRepo.HandleGraphEntityCreation(ctx, entity)
+	if !success {
+		if err == nil {
+			err = fmt.Errorf("entity %s was not created in Neo4j", entity.Id)
+		}
+		return err
+	}
+
+	sg.Record("create entity node", func(ctx context.Context) error {
+		return s.neo4jRepo.DeleteGraphEntity(ctx, entity.Id)
+	})
+	return nil
+}
+
+// updateGraphEntity updates the name and termination of the entity node in Neo4j
+func (s *Server) updateGraphEntity(ctx context.Context, sg *saga.Saga, entity *pb.Entity) error {
+	previous, err := s.neo4jRepo.ReadGraphEntity(ctx, entity.Id)
+	if err != nil {
+		return err
+	}
+
+	success, err := s.neo4jRepo.HandleGraphEntityUpdate(ctx, entity)
+	if !success {
+		if err == nil {
+			err = fmt.Errorf("entity %s was not updated in Neo4j", entity.Id)
+		}
+		return err
+	}
+
+	sg.Record("update entity node", func(ctx context.Context) error {
+		// A nil Terminated removes the property again
+		_, err := s.neo4jRepo.UpdateGraphEntity(ctx, entity.Id, map[string]interface{}{
+			"Name":       previous["Name"],
+			"Terminated": previous["Terminated"],
+		})
+		return err
+	})
+	return nil
+}
+
+// updateVersion advances the version of the entity node in Neo4j and mirrors it in the metadata document
+// in MongoDB, creating the document if the entity has none yet. It fails with Aborted unless the entity
+// is at the expected version.
+// The version is taken in a transaction of its own before the update writes anything, and the update
+// spans several databases, so the two are not atomic: a concurrent update holding the same version
+// fails here instead, and a failing update gives the version back when its saga is rolled back.
+func (s *Server) updateVersion(ctx context.Context, sg *saga.Saga, entityID string, expected int64) (int64, error) {
+	previous, version, err := s.neo4jRepo.BumpGraphEntityVersion(ctx, entityID, expected)
+	if err != nil {
+		return 0, err
+	}
+	sg.Record("update entity version", func(ctx context.Context) error {
+		return s.neo4jRepo.RestoreGraphEntityVersion(ctx, entityID, version, previous)
+	})
+
+	created, err := s.mongoRepo.SetEntityVersion(ctx, entityID, version)
+	if err != nil {
+		return 0, fmt.Errorf("error updating version of metadata of entity %s: %w", entityID, err)
+	}
+	sg.Record("update metadata version", func(ctx context.Context) error {
+		return s.mongoRepo.RestoreEntityVersion(ctx, entityID, version, previous, created)
+	})
+	return version, nil
+}
+
+// createRelationships creates the relationships of a new entity one by one,
+// so that the ones created before a failing relationship can be removed again
+func (s *Server) createRelationships(ctx context.Context, sg *saga.Saga, entity *pb.Entity) error {
+	for _, relationship := range entity.Relationships {
+		if err := s.neo4jRepo.HandleGraphRelationshipCreate(ctx, entity.Id, relationship); err != nil {
+			return err
+		}
+
+		relationshipID := relationship.Id
+		sg.Record("create relationship "+relationshipID, func(ctx context.Context) error {
+			return s.neo4jRepo.DeleteRelationship(ctx, relationshipID)
+		})
+	}
+	return nil
+}
+
+// updateRelationships updates or creates the relationships of an existing entity one by one.
+// It returns the ids of the relationships it created.
+func (s *Server) updateRelationships(ctx context.Context, sg *saga.Saga, entity *pb.Entity) ([]string, error) {
+	var created []string
+	for _, relationship := range entity.Relationships {
+		previous, err := s.neo4jRepo.HandleGraphRelationshipUpdate(ctx, entity.Id, relationship)
+		if err != nil {
+			return nil, err
+		}
+
+		relationshipID := relationship.Id
+		if previous == nil {
+			created = append(created, relationshipID)
+			sg.Record("create relationship "+relationshipID, func(ctx context.Context) error {
+				return s.neo4jRepo.DeleteRelationship(ctx, relationshipID)
+			})
+			continue
+		}
+		sg.Record("update relationship "+relationshipID, func(ctx context.Context) error {
+			// A nil Terminated removes the property again, and the previous properties replace the new ones
+			_, err := s.neo4jRepo.UpdateRelationship(ctx, relationshipID, map[string]interface{}{
+				"Created":    previous["Created"],
+				"Terminated": previous["Terminated"],
+				"Properties": previous["Properties"],
+			})
+			return err
+		})
+		// The version replaced is kept rather than lost
+		if err := s.addRelationshipVersion(ctx, sg, entity.Id, relationshipID, previous); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+// handleMetadata creates or replaces the metadata document of the entity in MongoDB
+func (s *Server) handleMetadata(ctx context.Context, sg *saga.Saga, entityID string, entity *pb.Entity) error {
+	if len(entity.GetMetadata()) == 0 {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "Server.handleMetadata", tracing.EntityID(entityID))
+	defer span.End()
+
+	existing, err := s.mongoRepo.ReadEntity(ctx, entityID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	if err := s.mongoRepo.HandleMetadata(ctx, entityID, entity); err != nil {
+		return err
+	}
+
+	if existing == nil {
+		sg.Record("create metadata", func(ctx context.Context) error {
+			_, err := s.mongoRepo.DeleteEntity(ctx, entityID)
+			return err
+		})
+		return nil
+	}
+	sg.Record("update metadata", func(ctx context.Context) error {
+		_, err := s.mongoRepo.UpdateEntity(ctx, entityID, bson.M{"metadata": existing.Metadata})
+		return err
+	})
+	return nil
+}
+
+// handleAttributes stores the attributes of the entity. The attribute processor keeps going after
+// a failing attribute, so instead of undoing single writes the rollback removes every attribute node
+// and attribute table that did not exist before. For a new entity nothing existed before,
+// which saves looking it up.
+func (s *Server) handleAttributes(ctx context.Context, sg *saga.Saga, processor *engine.EntityAttributeProcessor, entity *pb.Entity, isNew bool) error {
+	if len(entity.Attributes) == 0 {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "Server.handleAttributes", tracing.EntityID(entity.Id))
+	defer span.End()
+
+	graphManager := s.graphManager
+	previousAttributeIDs := []string{}
+	previousTables := &postgres.AttributeTableSnapshot{EntityID: entity.Id}
+	if !isNew {
+		var err error
+		previousAttributeIDs, err = graphManager.ListAttributeIDs(ctx, entity.Id)
+		if err != nil {
+			return fmt.Errorf("error listing attributes of entity %s: %w", entity.Id, err)
+		}
+		previousTables, err = s.postgresRepo.SnapshotEntityAttributeTables(ctx, entity.Id)
+		if err != nil {
+			return fmt.Errorf("error listing attribute tables of entity %s: %w", entity.Id, err)
+		}
+	}
+
+	// Recorded up front because a failing attribute does not stop the others from being written
+	sg.Record("store attributes", func(ctx context.Context) error {
+		if _, err := s.postgresRepo.RestoreEntityAttributeTables(ctx, previousTables); err != nil {
+			return err
+		}
+		attributeIDs, err := graphManager.ListAttributeIDs(ctx, entity.Id)
+		if err != nil {
+			return err
+		}
+		var added []string
+		for _, attributeID := range attributeIDs {
+			if !slices.Contains(previousAttributeIDs, attributeID) {
+				added = append(added, attributeID)
+			}
+		}
+		return graphManager.DeleteAttributeNodes(ctx, entity.Id, added)
+	})
+
+	// Note that in the perspective of the attribute this is always a creation operation
+	// The entity is already there but here the attribute is set later.
+	// There is no alignment of update operation with the attribute.
+	// TODO: https://github.com/LDFLK/nexoan/issues/286
+	attributeResults := processor.ProcessEntityAttributes(ctx, entity, "create", nil)
+
+	// Check if any attributes failed
+	var failedNames []string
+	for attrName, result := range attributeResults {
+		if !result.Success || result.Error != nil {
+			logging.FromContext(ctx).Error("Error handling attribute", "attribute", attrName, "error", result.Error)
+			failedNames = append(failedNames, attrName)
+		} else {
+			logging.FromContext(ctx).Debug("Handled attribute for entity", "attribute", attrName, "entity_id", entity.Id)
+		}
+	}
+	if len(failedNames) == 0 {
+		return nil
+	}
+
+	// The error takes the code of the first failing attribute, so that a single invalid
+	// attribute is reported as an invalid argument
+	slices.Sort(failedNames)
+	failed := make([]string, len(failedNames))
+	for i, attrName := range failedNames {
+		failed[i] = fmt.Sprintf("%s: %v", attrName, attributeResults[attrName].Error)
+	}
+	return &apperrors.Error{
+		Code:      apperrors.CodeOf(attributeResults[failedNames[0]].Error),
+		Message:   fmt.Sprintf("some attributes failed to process: %s", strings.Join(failed, "; ")),
+		EntityID:  entity.Id,
+		Attribute: failedNames[0],
+	}
+}
+
+// recordEvents holds the events of the write in the outbox, for the webhooks, and returns their ids.
+// They are only handed to the dispatcher by releaseEvents once the write can no longer be rolled back.
+func (s *Server) recordEvents(ctx context.Context, sg *saga.Saga, events []*pb.EntityEvent) ([]string, error) {
+	eventIDs, err := s.outbox.Hold(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	sg.Record("record events", func(ctx context.Context) error {
+		return s.outbox.Discard(ctx, eventIDs)
+	})
+	return eventIDs, nil
+}
+
+// releaseEvents hands the held events of finished writes to the dispatcher. The writes are done, so
+// a failure is logged rather than making the caller retry them.
+func (s *Server) releaseEvents(ctx context.Context, eventIDs []string) {
+	if err := s.outbox.Release(context.WithoutCancel(ctx), eventIDs); err != nil {
+		logging.FromContext(ctx).Error("Error releasing events, the webhooks will miss them", "event_ids", eventIDs, "error", err)
+	}
+}
+
+// finishWrite records the events and the audit record of a write of the entity. The audit record
+// cannot be undone, so the events are held until it was appended: a write rolled back because its
+// audit failed sends no webhook.
+func (s *Server) finishWrite(ctx context.Context, sg *saga.Saga, entityID string, events []*pb.EntityEvent, changes []audit.Change, attributes []audit.AttributeWrite) error {
+	eventIDs, err := s.recordEvents(ctx, sg, events)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error recording events of entity", "entity_id", entityID, "error", err)
+		return err
+	}
+	if err := s.audit.Append(ctx, entityID, changes, attributes); err != nil {
+		logging.FromContext(ctx).Error("Error auditing entity", "entity_id", entityID, "error", err)
+		return err
+	}
+	s.releaseEvents(ctx, eventIDs)
+	return nil
+}
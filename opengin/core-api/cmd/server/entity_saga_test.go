@@ -0,0 +1,115 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/outbox"
+	"lk/datafoundation/core-api/pkg/saga"
+	"lk/datafoundation/core-api/pkg/watch"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOutboxStore keeps the entries of the outbox in memory
+type memoryOutboxStore struct {
+	entries []*outbox.Entry
+}
+
+func (s *memoryOutboxStore) Add(ctx context.Context, entries []*outbox.Entry) error {
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func (s *memoryOutboxStore) Release(ctx context.Context, eventIDs []string) error {
+	for _, entry := range s.entries {
+		if entry.Status == outbox.StatusHeld && slices.Contains(eventIDs, entry.EventID) {
+			entry.Status = outbox.StatusPending
+		}
+	}
+	return nil
+}
+
+func (s *memoryOutboxStore) Discard(ctx context.Context, eventIDs []string) error {
+	s.entries = slices.DeleteFunc(s.entries, func(entry *outbox.Entry) bool {
+		return entry.Status != outbox.StatusDelivered && slices.Contains(eventIDs, entry.EventID)
+	})
+	return nil
+}
+
+func (s *memoryOutboxStore) Claim(ctx context.Context, now time.Time, lease time.Duration) (*outbox.Entry, error) {
+	return nil, nil
+}
+
+func (s *memoryOutboxStore) Save(ctx context.Context, entry *outbox.Entry) error {
+	return nil
+}
+
+func (s *memoryOutboxStore) ListFailed(ctx context.Context, endpoint string, limit int) ([]*outbox.Entry, error) {
+	return nil, nil
+}
+
+func (s *memoryOutboxStore) Replay(ctx context.Context, ids []string, endpoint string) (int64, error) {
+	return 0, nil
+}
+
+// statuses returns the status of every entry
+func (s *memoryOutboxStore) statuses() []string {
+	var statuses []string
+	for _, entry := range s.entries {
+		statuses = append(statuses, entry.Status)
+	}
+	return statuses
+}
+
+// auditStore appends records in memory, or fails with err
+type auditStore struct {
+	records []*audit.Record
+	err     error
+}
+
+func (s *auditStore) Append(ctx context.Context, record *audit.Record) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *auditStore) Find(ctx context.Context, query audit.Query) ([]*audit.Record, error) {
+	return s.records, nil
+}
+
+func TestFinishWrite(t *testing.T) {
+	ctx := context.Background()
+	entity := &pb.Entity{Id: "entity", Kind: &pb.Kind{Major: "Person"}, Version: 1}
+	changes := audit.Diff(nil, auditSnapshotOf(entity))
+
+	store := &memoryOutboxStore{}
+	audits := &auditStore{}
+	s := &Server{outbox: outbox.New(store, []*outbox.Endpoint{{Name: "hook"}}), audit: audit.New(audits)}
+
+	err := s.finishWrite(ctx, saga.New("CreateEntity entity"), entity.Id, createdEvents(entity), changes, nil)
+	assert.NoError(t, err)
+	assert.Len(t, audits.records, 1)
+	assert.Equal(t, []string{outbox.StatusPending}, store.statuses(), "Expected the events to be released once audited")
+
+	// A failing audit rolls the write back before any event could be delivered
+	store = &memoryOutboxStore{}
+	s = &Server{outbox: outbox.New(store, []*outbox.Endpoint{{Name: "hook"}}), audit: audit.New(&auditStore{err: errors.New("audit store down")})}
+	sg := saga.New("UpdateEntity entity")
+	err = s.finishWrite(ctx, sg, entity.Id, []*pb.EntityEvent{watch.EntityEvent(watch.EntityUpdated, entity)}, changes, nil)
+	assert.Error(t, err)
+	assert.Equal(t, []string{outbox.StatusHeld}, store.statuses(), "Expected no pending event before the rollback")
+
+	_ = sg.Abort(ctx, err)
+	assert.Empty(t, store.entries, "Expected the held events to be discarded with the write")
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"github.com/lib/pq"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// classifyStoreError returns the code of a database driver error that the repositories passed
+// on without turning it into an apperrors.Error. It is used by the error interceptors.
+func classifyStoreError(err error) apperrors.Code {
+	// Neo4j
+	var connectivityErr *neo4j.ConnectivityError
+	if errors.As(err, &connectivityErr) {
+		return apperrors.Unavailable
+	}
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) {
+		switch {
+		case strings.HasPrefix(neo4jErr.Code, "Neo.TransientError."):
+			return apperrors.Unavailable
+		case neo4jErr.Code == "Neo.ClientError.Schema.ConstraintValidationFailed":
+			return apperrors.AlreadyExists
+		}
+	}
+
+	// MongoDB
+	var selectionErr topology.ServerSelectionError
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return apperrors.NotFound
+	case mongo.IsDuplicateKeyError(err):
+		return apperrors.AlreadyExists
+	case mongo.IsNetworkError(err) || mongo.IsTimeout(err) || errors.As(err, &selectionErr):
+		return apperrors.Unavailable
+	}
+
+	// PostgreSQL
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code.Class() == "08", pqErr.Code == "57P01", pqErr.Code == "57P02", pqErr.Code == "57P03":
+			// Connection exceptions and server shutdown
+			return apperrors.Unavailable
+		case pqErr.Code == "23505":
+			return apperrors.AlreadyExists
+		}
+	}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return apperrors.NotFound
+	case errors.Is(err, sql.ErrConnDone):
+		return apperrors.Unavailable
+	}
+
+	return apperrors.Unknown
+}
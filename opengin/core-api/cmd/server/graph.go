@@ -0,0 +1,128 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	neo4jrepository "lk/datafoundation/core-api/db/repository/neo4j"
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Traverse returns the entities and relationships reachable from an entity within the requested depths,
+// following only the requested relationships and visiting only the requested kinds
+func (s *Server) Traverse(ctx context.Context, req *pb.TraverseRequest) (*pb.Graph, error) {
+	logging.FromContext(ctx).Debug("Traversing from entity", "entity_id", req.Id, "relationships", req.RelationshipNames, "direction", req.Direction, "min_depth", req.MinDepth, "max_depth", req.MaxDepth, "active_at", req.ActiveAt)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+
+	activeAt, err := activeAtParam(req.ActiveAt)
+	if err != nil {
+		return nil, err
+	}
+	traversal := &neo4jrepository.TraversalRequest{
+		StartID:           req.Id,
+		RelationshipNames: req.RelationshipNames,
+		Direction:         req.Direction,
+		MinDepth:          int(req.MinDepth),
+		MaxDepth:          int(req.MaxDepth),
+		ActiveAt:          activeAt,
+		Kinds:             req.Kinds,
+	}
+	if traversal.MinDepth == 0 {
+		traversal.MinDepth = 1
+	}
+	if traversal.MaxDepth == 0 {
+		traversal.MaxDepth = traversal.MinDepth
+	}
+	if len(req.RelationshipNames) == 0 {
+		// The attribute nodes are not entities
+		traversal.ExcludedRelationshipNames = []string{engine.IS_ATTRIBUTE_RELATIONSHIP}
+	}
+	return s.neo4jRepo.Traverse(ctx, traversal)
+}
+
+// Lengths and counts of the paths FindPaths returns by default
+const (
+	defaultPathLength = 6
+	defaultPathCount  = 10
+)
+
+// FindPaths returns the shortest paths between two entities, through the relationships active at the requested time
+func (s *Server) FindPaths(ctx context.Context, req *pb.FindPathsRequest) (*pb.PathList, error) {
+	logging.FromContext(ctx).Debug("Finding paths between entities", "from_id", req.FromId, "to_id", req.ToId, "relationships", req.RelationshipNames, "active_at", req.ActiveAt, "max_length", req.MaxLength, "all", req.All)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.FromId))
+
+	activeAt, err := activeAtParam(req.ActiveAt)
+	if err != nil {
+		return nil, err
+	}
+	pathRequest := &neo4jrepository.PathRequest{
+		FromID:            req.FromId,
+		ToID:              req.ToId,
+		RelationshipNames: req.RelationshipNames,
+		ActiveAt:          activeAt,
+		MaxLength:         int(req.MaxLength),
+		All:               req.All,
+		MaxPaths:          int(req.MaxPaths),
+	}
+	if pathRequest.MaxLength == 0 {
+		pathRequest.MaxLength = defaultPathLength
+	}
+	if pathRequest.MaxPaths == 0 {
+		pathRequest.MaxPaths = defaultPathCount
+	}
+	if len(req.RelationshipNames) == 0 {
+		pathRequest.ExcludedRelationshipNames = []string{engine.IS_ATTRIBUTE_RELATIONSHIP}
+	}
+	paths, err := s.neo4jRepo.FindPaths(ctx, pathRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PathList{Paths: paths}, nil
+}
+
+// Levels below the root ReadHierarchy returns by default
+const defaultHierarchyDepth = 3
+
+// ReadHierarchy returns the tree of entities below the root through the parent-child relationships of the request
+func (s *Server) ReadHierarchy(ctx context.Context, req *pb.ReadHierarchyRequest) (*pb.Hierarchy, error) {
+	logging.FromContext(ctx).Debug("Reading hierarchy of entity", "entity_id", req.Id, "relationships", req.RelationshipNames, "direction", req.Direction, "active_at", req.ActiveAt, "max_depth", req.MaxDepth)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+
+	activeAt, err := activeAtParam(req.ActiveAt)
+	if err != nil {
+		return nil, err
+	}
+	hierarchy := &neo4jrepository.HierarchyRequest{
+		RootID:            req.Id,
+		RelationshipNames: req.RelationshipNames,
+		Direction:         req.Direction,
+		ActiveAt:          activeAt,
+		MaxDepth:          int(req.MaxDepth),
+		CountChildren:     req.IncludeChildCount,
+	}
+	if hierarchy.Direction == "" {
+		hierarchy.Direction = neo4jrepository.DirectionOutgoing
+	}
+	if hierarchy.MaxDepth == 0 {
+		hierarchy.MaxDepth = defaultHierarchyDepth
+	}
+	return s.neo4jRepo.ReadHierarchy(ctx, hierarchy)
+}
+
+// activeAtParam validates the activeAt of a graph request and returns it in the form Neo4j parses,
+// or an empty string if it is not set
+func activeAtParam(value string) (string, error) {
+	activeAt, err := parseOptionalTime(value, "activeAt")
+	if err != nil || activeAt.IsZero() {
+		return "", err
+	}
+	return formatTime(activeAt), nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	engine "lk/datafoundation/core-api/engine"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// window keeps the values that held at some time from its start (inclusive) until its end (exclusive).
+// A zero start or end leaves that side open.
+type window struct {
+	start, end time.Time
+}
+
+// contains tells whether a value holding from startTime until endTime held at some time within the window.
+// An empty startTime holds since ever and an empty endTime until now.
+func (w window) contains(startTime, endTime string) bool {
+	if !w.end.IsZero() && startTime != "" {
+		if start, err := parseEntityTime(startTime, "startTime"); err == nil && !start.Before(w.end) {
+			return false
+		}
+	}
+	if !w.start.IsZero() && endTime != "" {
+		if end, err := parseEntityTime(endTime, "endTime"); err == nil && !end.After(w.start) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadEntityHistory returns every name, metadata revision, relationship and tabular attribute value
+// the entity had, each ordered by start time and kept to those holding within the requested window
+func (s *Server) ReadEntityHistory(ctx context.Context, req *pb.ReadEntityHistoryRequest) (*pb.EntityHistory, error) {
+	logging.FromContext(ctx).Debug("Reading history of entity", "entity_id", req.Id, "start_time", req.StartTime, "end_time", req.EndTime)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+
+	if req.Id == "" {
+		return nil, apperrors.InvalidArgumentf("entity id is required").WithField("id")
+	}
+	var w window
+	var err error
+	if w.start, err = parseOptionalTime(req.StartTime, "startTime"); err != nil {
+		return nil, err
+	}
+	if w.end, err = parseOptionalTime(req.EndTime, "endTime"); err != nil {
+		return nil, err
+	}
+	if !w.start.IsZero() && !w.end.IsZero() && !w.end.After(w.start) {
+		return nil, apperrors.InvalidArgumentf("endTime %s must be after startTime %s", req.EndTime, req.StartTime).WithField("endTime")
+	}
+
+	kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching entity info: %w", err)
+	}
+	history := &pb.EntityHistory{
+		Id:         req.Id,
+		Kind:       kind,
+		Created:    created,
+		Terminated: terminated,
+		Attributes: make(map[string]*pb.TimeBasedValueList),
+	}
+
+	if history.Names, err = s.nameHistory(ctx, req.Id, name, created, terminated, w); err != nil {
+		return nil, err
+	}
+	if history.Metadata, err = s.metadataHistory(ctx, req.Id, created, terminated, w); err != nil {
+		return nil, err
+	}
+	if history.Relationships, err = s.relationshipHistory(ctx, req.Id, w); err != nil {
+		return nil, err
+	}
+	if err := s.attributeHistory(ctx, history, w); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// nameHistory returns the names of the entity, or the name given if its name was never revised
+func (s *Server) nameHistory(ctx context.Context, entityID string, name *pb.TimeBasedValue, created, terminated string, w window) ([]*pb.TimeBasedValue, error) {
+	revisions, err := s.mongoRepo.ReadRevisions(ctx, []string{entityID}, mongorepository.NameRevision)
+	if err != nil {
+		return nil, fmt.Errorf("error reading names of entity %s: %w", entityID, err)
+	}
+	var names []*pb.TimeBasedValue
+	if len(revisions[entityID]) == 0 {
+		if name != nil && w.contains(name.StartTime, name.EndTime) {
+			names = append(names, name)
+		}
+		return names, nil
+	}
+	for i, revision := range revisions[entityID] {
+		startTime, endTime := revisionInterval(revisions[entityID], i, created, terminated)
+		if !w.contains(startTime, endTime) {
+			continue
+		}
+		value, err := anypb.New(wrapperspb.String(revision.Name))
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, &pb.TimeBasedValue{StartTime: startTime, EndTime: endTime, Value: value})
+	}
+	return names, nil
+}
+
+// metadataHistory returns the metadata revisions of the entity, or its metadata if it was never revised
+func (s *Server) metadataHistory(ctx context.Context, entityID, created, terminated string, w window) ([]*pb.MetadataRevision, error) {
+	revisions, err := s.mongoRepo.ReadRevisions(ctx, []string{entityID}, mongorepository.MetadataRevision)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata revisions of entity %s: %w", entityID, err)
+	}
+	var history []*pb.MetadataRevision
+	if len(revisions[entityID]) == 0 {
+		metadata, err := s.mongoRepo.GetMetadata(ctx, entityID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching metadata of entity %s: %w", entityID, err)
+		}
+		if len(metadata) > 0 && w.contains(created, terminated) {
+			history = append(history, &pb.MetadataRevision{StartTime: created, EndTime: terminated, Metadata: metadata})
+		}
+		return history, nil
+	}
+	for i, revision := range revisions[entityID] {
+		startTime, endTime := revisionInterval(revisions[entityID], i, created, terminated)
+		if w.contains(startTime, endTime) {
+			history = append(history, &pb.MetadataRevision{StartTime: startTime, EndTime: endTime, Metadata: revision.Metadata})
+		}
+	}
+	return history, nil
+}
+
+// relationshipHistory returns the relationships of the entity in both directions, leaving out its attributes
+func (s *Server) relationshipHistory(ctx context.Context, entityID string, w window) ([]*pb.Relationship, error) {
+	relationships, err := s.neo4jRepo.GetFilteredRelationships(ctx, entityID, "", "", "", "", "", "", "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching relationships of entity %s: %w", entityID, err)
+	}
+	var history []*pb.Relationship
+	for _, relationship := range relationships {
+		if relationship.Name != engine.IS_ATTRIBUTE_RELATIONSHIP && w.contains(relationship.StartTime, relationship.EndTime) {
+			history = append(history, relationship)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].StartTime != history[j].StartTime {
+			return startsBefore(history[i].StartTime, history[j].StartTime)
+		}
+		return history[i].Id < history[j].Id
+	})
+	return history, nil
+}
+
+// attributeHistory adds every value of the tabular attributes of the entity to the history.
+// The other storage types do not keep their values yet.
+func (s *Server) attributeHistory(ctx context.Context, history *pb.EntityHistory, w window) error {
+	values, err := s.postgresRepo.ListAttributeValues(ctx, history.Id)
+	if err != nil {
+		return fmt.Errorf("error listing attribute values of entity %s: %w", history.Id, err)
+	}
+	for _, value := range values {
+		if !w.contains(value.StartTime, value.EndTime) {
+			continue
+		}
+		data, err := s.postgresRepo.GetData(ctx, value.TableName, map[string]interface{}{})
+		if err != nil {
+			return fmt.Errorf("error reading value of attribute %s of entity %s: %w", value.AttributeName, history.Id, err)
+		}
+		list, ok := history.Attributes[value.AttributeName]
+		if !ok {
+			list = &pb.TimeBasedValueList{}
+			history.Attributes[value.AttributeName] = list
+		}
+		list.Values = append(list.Values, &pb.TimeBasedValue{StartTime: value.StartTime, EndTime: value.EndTime, Value: data})
+	}
+	return nil
+}
+
+// startsBefore orders start times, an empty one first
+func startsBefore(a, b string) bool {
+	if a == "" || b == "" {
+		return a == "" && b != ""
+	}
+	timeA, errA := parseEntityTime(a, "startTime")
+	timeB, errB := parseEntityTime(b, "startTime")
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return timeA.Before(timeB)
+}
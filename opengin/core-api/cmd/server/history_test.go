@@ -0,0 +1,30 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowContains(t *testing.T) {
+	open := window{}
+	assert.True(t, open.contains("", ""))
+	assert.True(t, open.contains("2020-01-01T00:00:00Z", "2021-01-01T00:00:00Z"))
+
+	w := window{start: date(t, "2022-01-01"), end: date(t, "2023-01-01")}
+	assert.True(t, w.contains("2021-06-01T00:00:00Z", "2022-06-01T00:00:00Z"), "Expected a value overlapping the start")
+	assert.True(t, w.contains("2022-06-01T00:00:00Z", ""), "Expected a value still holding")
+	assert.True(t, w.contains("", "2022-01-02T00:00:00Z"), "Expected a value holding since ever")
+	assert.False(t, w.contains("2020-01-01T00:00:00Z", "2022-01-01T00:00:00Z"), "Expected a value ending at the start to be left out")
+	assert.False(t, w.contains("2023-01-01T00:00:00Z", ""), "Expected a value starting at the end to be left out")
+}
+
+func TestStartsBefore(t *testing.T) {
+	assert.True(t, startsBefore("", "2020-01-01T00:00:00Z"))
+	assert.False(t, startsBefore("2020-01-01T00:00:00Z", ""))
+	assert.False(t, startsBefore("", ""))
+	assert.True(t, startsBefore("2020-01-01T00:00Z", "2020-01-01T00:00:01Z"), "Expected times to be compared, not strings")
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/metrics"
+)
+
+// metricsShutdownTimeout is how long a scrape in progress may take when the server stops
+const metricsShutdownTimeout = 5 * time.Second
+
+// serveMetrics serves /metrics on the listener until the context is cancelled
+func serveMetrics(ctx context.Context, listener net.Listener) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("Serving metrics", "address", listener.Addr().String(), "path", "/metrics")
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Failed to serve metrics", "error", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	"lk/datafoundation/core-api/pkg/saga"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// addRelationshipVersion keeps the version of the relationship an update replaced, as returned by
+// HandleGraphRelationshipUpdate
+func (s *Server) addRelationshipVersion(ctx context.Context, sg *saga.Saga, entityID, relationshipID string, previous map[string]interface{}) error {
+	version := &mongorepository.RelationshipVersion{RelationshipID: relationshipID, EntityID: entityID}
+	version.StartTime, _ = previous["Created"].(string)
+	version.EndTime, _ = previous["Terminated"].(string)
+	version.Properties, _ = previous["Properties"].(map[string]*anypb.Any)
+	if err := s.mongoRepo.AddRelationshipVersion(ctx, version); err != nil {
+		return fmt.Errorf("error recording version of relationship %s of entity %s: %w", relationshipID, entityID, err)
+	}
+	versionID := version.ID
+	sg.Record("add version of relationship "+relationshipID, func(ctx context.Context) error {
+		return s.mongoRepo.DeleteRelationshipVersion(ctx, versionID)
+	})
+	return nil
+}
+
+// readRelationshipVersions adds the versions updates replaced to the relationships
+func (s *Server) readRelationshipVersions(ctx context.Context, relationships map[string]*pb.Relationship) error {
+	if len(relationships) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(relationships))
+	for id := range relationships {
+		ids = append(ids, id)
+	}
+	versions, err := s.mongoRepo.ReadRelationshipVersions(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("error reading versions of relationships: %w", err)
+	}
+	for id, relationship := range relationships {
+		relationship.Versions = relationshipVersions(versions[id])
+	}
+	return nil
+}
+
+// relationshipVersions converts the stored versions of a relationship, oldest first. Each version was
+// recorded when the one before it was superseded.
+func relationshipVersions(versions []*mongorepository.RelationshipVersion) []*pb.RelationshipVersion {
+	var result []*pb.RelationshipVersion
+	recordedAt := ""
+	for _, version := range versions {
+		result = append(result, &pb.RelationshipVersion{
+			StartTime:    version.StartTime,
+			EndTime:      version.EndTime,
+			Properties:   version.Properties,
+			RecordedAt:   recordedAt,
+			SupersededAt: formatTime(version.Superseded),
+		})
+		recordedAt = formatTime(version.Superseded)
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationshipVersions(t *testing.T) {
+	assert.Empty(t, relationshipVersions(nil), "Expected no versions of a relationship never updated")
+
+	versions := relationshipVersions([]*mongorepository.RelationshipVersion{
+		{StartTime: "2020-01-01T00:00:00Z", Superseded: date(t, "2022-01-01")},
+		{StartTime: "2020-01-01T00:00:00Z", EndTime: "2023-01-01T00:00:00Z", Superseded: date(t, "2023-06-01")},
+	})
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "", versions[0].RecordedAt, "Expected the version created with the relationship to have no recorded time")
+	assert.Equal(t, "2022-01-01T00:00:00Z", versions[0].SupersededAt)
+	assert.Equal(t, "2022-01-01T00:00:00Z", versions[1].RecordedAt, "Expected a version to be recorded when the one before it was superseded")
+	assert.Equal(t, "2023-01-01T00:00:00Z", versions[1].EndTime)
+	assert.Equal(t, "2023-06-01T00:00:00Z", versions[1].SupersededAt)
+}
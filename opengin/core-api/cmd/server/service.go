@@ -5,20 +5,40 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"syscall"
 
-	"lk/datafoundation/core-api/db/config"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
 
 	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
 	neo4jrepository "lk/datafoundation/core-api/db/repository/neo4j"
 	postgres "lk/datafoundation/core-api/db/repository/postgres"
 	engine "lk/datafoundation/core-api/engine"
-
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/auth"
+	"lk/datafoundation/core-api/pkg/config"
+	"lk/datafoundation/core-api/pkg/healthcheck"
+	"lk/datafoundation/core-api/pkg/idempotency"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/metrics"
+	"lk/datafoundation/core-api/pkg/outbox"
+	"lk/datafoundation/core-api/pkg/saga"
+	"lk/datafoundation/core-api/pkg/tracing"
+	"lk/datafoundation/core-api/pkg/watch"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -30,67 +50,86 @@ type Server struct {
 	mongoRepo    *mongorepository.MongoRepository
 	neo4jRepo    *neo4jrepository.Neo4jRepository
 	postgresRepo *postgres.PostgresRepository
+	processor    *engine.EntityAttributeProcessor
+	graphManager *engine.GraphMetadataManager
+	events       *watch.Hub
+	outbox       *outbox.Outbox // nil when webhooks are disabled
+	audit        *audit.Log     // nil when auditing is disabled
 }
 
-// CreateEntity handles entity creation with relationships, metadata and attributes
+// CreateEntity handles entity creation with relationships, metadata and attributes.
+// If any step fails, the steps that already completed are rolled back.
 func (s *Server) CreateEntity(ctx context.Context, req *pb.Entity) (*pb.Entity, error) {
-	log.Printf("Creating Entity: %s", req.Id)
+	logging.FromContext(ctx).Debug("Creating Entity", "entity_id", req.Id)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+	req.Version = neo4jrepository.InitialEntityVersion
+
+	sg := saga.New("CreateEntity " + req.Id)
 
 	// Validate required fields for Neo4j entity creation
-	success, err := s.neo4jRepo.HandleGraphEntityCreation(ctx, req)
-	if !success {
-		log.Printf("[server.CreateEntity] Error saving entity in Neo4j: %v", err)
+	err := s.createGraphEntity(ctx, sg, req)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error saving entity in Neo4j", "error", err)
 		return nil, err
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved entity in Neo4j for entity: %s", req.Id)
+		logging.FromContext(ctx).Debug("Saved entity in Neo4j", "entity_id", req.Id)
 	}
 
 	// Handle relationships
-	err = s.neo4jRepo.HandleGraphRelationshipsCreate(ctx, req)
+	err = s.createRelationships(ctx, sg, req)
 	if err != nil {
-		log.Printf("[server.CreateEntity] Error saving relationships in Neo4j: %v", err)
-		return nil, err
+		logging.FromContext(ctx).Error("Error saving relationships in Neo4j", "error", err)
+		return nil, sg.Abort(ctx, err)
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved relationships in Neo4j for entity: %s", req.Id)
+		logging.FromContext(ctx).Debug("Saved relationships in Neo4j", "entity_id", req.Id)
 	}
 
-	// The HandleMetadata function will only process it if it has metadata
+	// The handleMetadata function will only process it if it has metadata
 	// If metadata is not provided, a document will not be created in MongoDB
 	// FIXME: https://github.com/LDFLK/nexoan/issues/120
-	err = s.mongoRepo.HandleMetadata(ctx, req.Id, req)
+	err = s.handleMetadata(ctx, sg, req.Id, req)
 	if err != nil {
-		log.Printf("[server.CreateEntity] Error saving metadata in MongoDB: %v", err)
-		return nil, err
+		logging.FromContext(ctx).Error("Error saving metadata in MongoDB", "error", err)
+		return nil, sg.Abort(ctx, err)
 	} else {
-		log.Printf("[server.CreateEntity] Successfully saved metadata in MongoDB for entity: %s", req.Id)
+		logging.FromContext(ctx).Debug("Saved metadata in MongoDB", "entity_id", req.Id)
 	}
 
-	// Handle attributes
-	processor := engine.NewEntityAttributeProcessor()
-	attributeResults := processor.ProcessEntityAttributes(ctx, req, "create", nil)
+	// Keep the name and metadata for the reads at a point in time
+	err = s.recordRevisions(ctx, sg, req)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error recording revisions of entity", "entity_id", req.Id, "error", err)
+		return nil, sg.Abort(ctx, err)
+	}
 
-	// Check if any attributes failed
-	hasErrors := false
-	for attrName, result := range attributeResults {
-		if !result.Success || result.Error != nil {
-			log.Printf("[server.CreateEntity] Error handling attribute %s: %v", attrName, result.Error)
-			hasErrors = true
-		} else {
-			log.Printf("[server.CreateEntity] Successfully handled attribute %s for entity: %s", attrName, req.Id)
-		}
+	// Handle attributes
+	err = s.handleAttributes(ctx, sg, s.processor, req, true)
+	if err != nil {
+		logging.FromContext(ctx).Error("Some attributes failed to process", "error", err)
+		return nil, sg.Abort(ctx, err)
 	}
 
-	if hasErrors {
-		log.Printf("[server.CreateEntity] Some attributes failed to process")
-		return nil, fmt.Errorf("some attributes failed to process")
+	// The events for the webhooks and the audit record come last, so that none is kept for an entity that was rolled back
+	events := createdEvents(req)
+	err = s.finishWrite(ctx, sg, req.Id, events, audit.Diff(nil, auditSnapshotOf(req)), audit.AttributeWrites(req.Attributes))
+	if err != nil {
+		return nil, sg.Abort(ctx, err)
 	}
 
+	s.events.Publish(events...)
 	return req, nil
 }
 
-// ReadEntity retrieves an entity
+// ReadEntity retrieves an entity. With activeAt the entity is returned as it was at that time,
+// failing with NotFound if it did not exist then.
 func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb.Entity, error) {
-	log.Printf("Reading Entity: %s with output fields: %v", req.Entity.Id, req.Output)
+	logging.FromContext(ctx).Debug("Reading Entity with output fields", "entity_id", req.Entity.Id, "output", req.Output, "active_at", req.ActiveAt)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Entity.Id))
+
+	activeAt, err := parseOptionalTime(req.ActiveAt, "activeAt")
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize a complete response entity with empty fields
 	response := &pb.Entity{
@@ -105,36 +144,52 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 	}
 
 	// Always fetch basic entity info from Neo4j
-	kind, name, created, terminated, err := s.neo4jRepo.GetGraphEntity(ctx, req.Entity.Id)
+	kind, name, created, terminated, version, err := s.neo4jRepo.GetVersionedGraphEntity(ctx, req.Entity.Id)
 	if err != nil {
-		log.Printf("Error fetching entity info: %v", err)
-		return nil, fmt.Errorf("error fetching entity info: %v", err)
+		logging.FromContext(ctx).Error("Error fetching entity info", "error", err)
+		return nil, fmt.Errorf("error fetching entity info: %w", err)
 	} else {
 		response.Kind = kind
 		response.Name = name
 		response.Created = created
 		response.Terminated = terminated
+		response.Version = version
+	}
+
+	if !activeAt.IsZero() {
+		if err := checkActive(req.Entity.Id, created, terminated, activeAt); err != nil {
+			return nil, err
+		}
+		response.Name, err = s.readNameAt(ctx, req.Entity.Id, name, activeAt, created, terminated)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error reading name of entity at time", "entity_id", req.Entity.Id, "active_at", req.ActiveAt, "error", err)
+			return nil, err
+		}
 	}
 
 	// If no output fields specified, return the entity with basic info
 	if len(req.Output) == 0 {
-		log.Printf("Returning entity from ReadEntity: %+v", response)
+		logging.FromContext(ctx).Debug("Returning entity", logging.Payload("response", response))
 		return response, nil
 	}
 
 	// Process each requested output field
 	for _, field := range req.Output {
-		log.Printf("[DEBUG] Entering switch statement for entity ID: %s", req.Entity.Id)
 		switch field {
 		case "metadata":
-			log.Printf("[DEBUG] Processing metadata field for entity ID: %s", req.Entity.Id)
+			logging.FromContext(ctx).Debug("Reading metadata of entity", "entity_id", req.Entity.Id)
 			// Get metadata from MongoDB
-			metadata, err := s.mongoRepo.GetMetadata(ctx, req.Entity.Id)
+			var metadata map[string]*anypb.Any
+			if activeAt.IsZero() {
+				metadata, err = s.mongoRepo.GetMetadata(ctx, req.Entity.Id)
+			} else {
+				metadata, err = s.readMetadataAt(ctx, req.Entity.Id, activeAt)
+			}
 			if err != nil {
-				log.Printf("Error fetching metadata: %v", err)
-				return nil, fmt.Errorf("error fetching metadata: %v", err)
+				logging.FromContext(ctx).Error("Error fetching metadata", "error", err)
+				return nil, fmt.Errorf("error fetching metadata: %w", err)
 			} else {
-				log.Printf("[DEBUG] Retrieved metadata: %+v", metadata)
+				logging.FromContext(ctx).Debug("Retrieved metadata", logging.Payload("metadata", metadata))
 				response.Metadata = metadata
 			}
 
@@ -143,10 +198,10 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 			if req.Entity != nil {
 				if len(req.Entity.Relationships) == 0 {
 					// No filters provided, fetch all relationships for the entity
-					filteredRels, err := s.neo4jRepo.GetFilteredRelationships(ctx, req.Entity.Id, "", "", "", "", "", "", req.ActiveAt)
+					filteredRels, err := s.neo4jRepo.GetFilteredRelationships(ctx, req.Entity.Id, "", "", "", "", "", "", req.ActiveAt, nil)
 					if err != nil {
-						log.Printf("Error fetching related entity IDs for entity %s: %v", req.Entity.Id, err)
-						return nil, fmt.Errorf("error fetching related entity IDs: %v", err)
+						logging.FromContext(ctx).Error("Error fetching related entity IDs for entity", "entity_id", req.Entity.Id, "error", err)
+						return nil, fmt.Errorf("error fetching related entity IDs: %w", err)
 					} else {
 						for id, relationship := range filteredRels {
 							response.Relationships[id] = relationship
@@ -155,11 +210,11 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 				} else {
 					// Call GetFilteredRelationships for each relationship
 					for _, rel := range req.Entity.Relationships {
-						log.Printf("Fetching related entity IDs for entity %s with relationship %s and start time %s", req.Entity.Id, rel.Name, rel.StartTime)
-						filteredRels, err := s.neo4jRepo.GetFilteredRelationships(ctx, req.Entity.Id, rel.Id, rel.Name, rel.RelatedEntityId, rel.StartTime, rel.EndTime, rel.Direction, req.ActiveAt)
+						logging.FromContext(ctx).Debug("Fetching related entity IDs", "entity_id", req.Entity.Id, "name", rel.Name, "start_time", rel.StartTime)
+						filteredRels, err := s.neo4jRepo.GetFilteredRelationships(ctx, req.Entity.Id, rel.Id, rel.Name, rel.RelatedEntityId, rel.StartTime, rel.EndTime, rel.Direction, req.ActiveAt, rel.Properties)
 						if err != nil {
-							log.Printf("Error fetching related entity IDs for entity %s: %v", req.Entity.Id, err)
-							return nil, fmt.Errorf("error fetching related entity IDs: %v", err)
+							logging.FromContext(ctx).Error("Error fetching related entity IDs for entity", "entity_id", req.Entity.Id, "error", err)
+							return nil, fmt.Errorf("error fetching related entity IDs: %w", err)
 						}
 
 						// Add the relationships to the response
@@ -169,44 +224,45 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 					}
 				}
 			} else {
-				return nil, fmt.Errorf("entity is required to fetch relationships")
+				return nil, apperrors.InvalidArgumentf("entity is required to fetch relationships").WithField("entity")
 			}
 
 		case "attributes":
-			log.Printf("Processing attributes for entity: %s", req.Entity.Id)
+			logging.FromContext(ctx).Debug("Processing attributes for entity", "entity_id", req.Entity.Id)
 
 			// For now, create a minimal entity with test attributes to demonstrate the conversion
 
-			log.Printf("[server.ReadEntity] Processing attributes for entity: %s, attributes: %+v", req.Entity.Id, req.Entity.Attributes)
+			logging.FromContext(ctx).Debug("Processing attributes for entity", "entity_id", req.Entity.Id, logging.Payload("attributes", req.Entity.Attributes))
 
 			// Use the EntityAttributeProcessor to read and process attributes
-			processor := engine.NewEntityAttributeProcessor()
+			processor := s.processor
 
 			// Extract fields from the request attributes based on storage type
 			fields := extractFieldsFromAttributes(req.Entity.Attributes)
-			log.Printf("Extracted fields from attributes: %v", fields)
+			logging.FromContext(ctx).Debug("Extracted fields from attributes", "fields", fields)
 
 			readOptions := engine.NewReadOptions(make(map[string]interface{}), fields...)
+			readOptions.ReadOptions.ActiveAt = activeAt
 
 			// Process the entity with attributes to get the results map
 			attributeResults := processor.ProcessEntityAttributes(ctx, req.Entity, "read", readOptions)
 
-			log.Printf("[server.ReadEntity] Successfully processed attributes for entity: %s, results: %+v", req.Entity.Id, attributeResults)
+			logging.FromContext(ctx).Debug("Processed attributes for entity", "entity_id", req.Entity.Id, "attributes", len(attributeResults))
 
 			// Convert the results map back to TimeBasedValueList and attach to response.Attributes
 			for attrName, result := range attributeResults {
-				log.Printf("[server.ReadEntity] Successfully processed attribute %s for entity: %s, result: %+v", attrName, req.Entity.Id, result)
+				logging.FromContext(ctx).Debug("Processed attribute for entity", "attribute", attrName, "entity_id", req.Entity.Id, logging.Payload("result", result))
 				if result.Success && result.Data != nil {
 					// Convert the result data back to TimeBasedValue format
 					if timeBasedValue, ok := result.Data.(*pb.TimeBasedValue); ok {
 						// If the data is already in TimeBasedValue format, use it directly
-						log.Printf("[server.ReadEntity] Successfully processed attribute %s for entity: %s", attrName, req.Entity.Id)
+						logging.FromContext(ctx).Debug("Processed attribute for entity", "attribute", attrName, "entity_id", req.Entity.Id)
 						response.Attributes[attrName] = &pb.TimeBasedValueList{
 							Values: []*pb.TimeBasedValue{timeBasedValue},
 						}
 					} else {
 						// Convert other data types to TimeBasedValue format
-						log.Printf("[server.ReadEntity] Successfully processed attribute %s for entity: %s", attrName, req.Entity.Id)
+						logging.FromContext(ctx).Debug("Processed attribute for entity", "attribute", attrName, "entity_id", req.Entity.Id)
 						response.Attributes[attrName] = &pb.TimeBasedValueList{
 							Values: []*pb.TimeBasedValue{
 								{
@@ -223,68 +279,85 @@ func (s *Server) ReadEntity(ctx context.Context, req *pb.ReadEntityRequest) (*pb
 				}
 			}
 
+		case "relationshipVersions":
+			// Added to the relationships read once every output was processed
+
 		default:
-			log.Printf("Unknown output field requested: %s", field)
-			return nil, fmt.Errorf("unknown output field requested: %s", field)
+			logging.FromContext(ctx).Debug("Unknown output field requested", "field", field)
+			return nil, apperrors.InvalidArgumentf("unknown output field requested: %s", field).WithField("output")
+		}
+	}
+	if slices.Contains(req.Output, "relationshipVersions") {
+		if err := s.readRelationshipVersions(ctx, response.Relationships); err != nil {
+			logging.FromContext(ctx).Error("Error reading relationship versions", "entity_id", req.Entity.Id, "error", err)
+			return nil, err
 		}
 	}
 	return response, nil
 }
 
-// UpdateEntity modifies existing metadata
+// UpdateEntity modifies existing metadata.
+// If any step fails, the steps that already completed are rolled back.
 func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest) (*pb.Entity, error) {
 	// Extract ID from request parameter and entity data
 	updateEntityID := req.Id
 	updateEntity := req.Entity
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(updateEntityID))
 
 	// Ensure the entity ID matches the URL parameter - since the id is already passed in the url param, the user does not need to pass it again in the payload
 	if updateEntity.Id == "" || updateEntity.Id != updateEntityID {
 		updateEntity.Id = updateEntityID
 	}
 
-	// Pass the ID and metadata to HandleMetadata- if no metadata was provided this will rerturn nil
-	err := s.mongoRepo.HandleMetadata(ctx, updateEntityID, updateEntity)
+	sg := saga.New("UpdateEntity " + updateEntityID)
+
+	// Take the next version first, so that a caller holding an outdated version changes nothing
+	version, err := s.updateVersion(ctx, sg, updateEntityID, req.ExpectedVersion)
 	if err != nil {
-		log.Printf("[server.UpdateEntity] Error updating metadata for entity %s: %v", updateEntityID, err)
-		return nil, fmt.Errorf("error updating metadata for entity %s: %v", updateEntityID, err)
+		logging.FromContext(ctx).Warn("Error updating version of entity", "entity_id", updateEntityID, "expected_version", req.ExpectedVersion, "error", err)
+		return nil, err
 	}
+	updateEntity.Version = version
 
-	// Handle Graph Entity update if entity has required fields
-	success, err := s.neo4jRepo.HandleGraphEntityUpdate(ctx, updateEntity)
-	if !success {
-		log.Printf("[server.UpdateEntity] Error updating graph entity for %s: %v", updateEntityID, err)
-		return nil, fmt.Errorf("error updating graph entity for entity %s: %v", updateEntityID, err)
+	before, err := s.auditSnapshot(ctx, updateEntityID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error reading entity before update", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, err)
 	}
 
-	// Handle Relationships update
-	err = s.neo4jRepo.HandleGraphRelationshipsUpdate(ctx, updateEntity)
+	// Keep the name and metadata for the reads at a point in time, before they are replaced
+	err = s.reviseEntity(ctx, sg, updateEntity)
 	if err != nil {
-		log.Printf("[server.UpdateEntity] Error updating relationships for entity %s: %v", updateEntityID, err)
-		return nil, fmt.Errorf("error updating relationships for entity %s: %v", updateEntityID, err)
+		logging.FromContext(ctx).Error("Error recording revisions of entity", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, fmt.Errorf("error recording revisions of entity %s: %w", updateEntityID, err))
 	}
 
-	// Handle attributes
-	processor := engine.NewEntityAttributeProcessor()
-	// Note that in the perspective of the attribute this is a creation operation
-	// The entity is already there but here the attribute is set later.
-	// There is no alignment of update operation with the attribute.
-	// TODO: https://github.com/LDFLK/nexoan/issues/286
-	attributeResults := processor.ProcessEntityAttributes(ctx, req.Entity, "create", nil)
+	// Pass the ID and metadata to handleMetadata- if no metadata was provided this will rerturn nil
+	err = s.handleMetadata(ctx, sg, updateEntityID, updateEntity)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating metadata for entity", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, fmt.Errorf("error updating metadata for entity %s: %w", updateEntityID, err))
+	}
 
-	// Check if any attributes failed
-	hasErrors := false
-	for attrName, result := range attributeResults {
-		if !result.Success || result.Error != nil {
-			log.Printf("[server.CreateEntity] Error handling attribute %s: %v", attrName, result.Error)
-			hasErrors = true
-		} else {
-			log.Printf("[server.CreateEntity] Successfully handled attribute %s for entity: %s", attrName, req.Id)
-		}
+	// Handle Graph Entity update if entity has required fields
+	err = s.updateGraphEntity(ctx, sg, updateEntity)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating graph entity", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, fmt.Errorf("error updating graph entity for entity %s: %w", updateEntityID, err))
 	}
 
-	if hasErrors {
-		log.Printf("[server.CreateEntity] Some attributes failed to process")
-		return nil, fmt.Errorf("some attributes failed to process")
+	// Handle Relationships update
+	createdRelationships, err := s.updateRelationships(ctx, sg, updateEntity)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating relationships for entity", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, fmt.Errorf("error updating relationships for entity %s: %w", updateEntityID, err))
+	}
+
+	// Handle attributes
+	err = s.handleAttributes(ctx, sg, s.processor, updateEntity, false)
+	if err != nil {
+		logging.FromContext(ctx).Error("Some attributes failed to process for entity", "entity_id", updateEntityID, "error", err)
+		return nil, sg.Abort(ctx, err)
 	}
 
 	// Prepare the Update Response
@@ -299,7 +372,7 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest)
 	metadata, _ := s.mongoRepo.GetMetadata(ctx, updateEntityID)
 
 	// Return updated entity with all available information
-	return &pb.Entity{
+	response := &pb.Entity{
 		Id:            updateEntity.Id,
 		Kind:          kind,
 		Name:          name,
@@ -308,64 +381,280 @@ func (s *Server) UpdateEntity(ctx context.Context, req *pb.UpdateEntityRequest)
 		Metadata:      metadata,
 		Attributes:    make(map[string]*pb.TimeBasedValueList), // Empty attributes
 		Relationships: relationships,
-	}, nil
+		Version:       version,
+	}
+
+	events := []*pb.EntityEvent{watch.EntityEvent(watch.EntityUpdated, response)}
+	for id, relationship := range updateEntity.Relationships {
+		eventType := watch.RelationshipUpdated
+		if slices.Contains(createdRelationships, id) {
+			eventType = watch.RelationshipCreated
+		}
+		// The request only carries the fields that changed
+		if stored, ok := relationships[id]; ok {
+			relationship = stored
+		}
+		events = append(events, watch.RelationshipEvent(eventType, response, outgoing(relationship)))
+	}
+	err = s.finishWrite(ctx, sg, updateEntityID, events, audit.Diff(before, auditSnapshotOf(response)), audit.AttributeWrites(updateEntity.Attributes))
+	if err != nil {
+		return nil, sg.Abort(ctx, err)
+	}
+	s.events.Publish(events...)
+
+	return response, nil
 }
 
-// DeleteEntity removes metadata
-func (s *Server) DeleteEntity(ctx context.Context, req *pb.EntityId) (*pb.Empty, error) {
-	log.Printf("[server.DeleteEntity] Deleting Entity metadata: %s", req.Id)
+// DeleteEntity removes an entity and everything stored for it across the databases.
+// Outgoing relationships, attributes and metadata belong to the entity and are always removed.
+// Incoming relationships belong to other entities, so they are only removed when cascade is set.
+// With dryRun the deletion plan is returned without removing anything.
+func (s *Server) DeleteEntity(ctx context.Context, req *pb.DeleteEntityRequest) (*pb.DeleteEntityResponse, error) {
+	logging.FromContext(ctx).Debug("Deleting Entity", "entity_id", req.Id, "cascade", req.Cascade, "dry_run", req.DryRun)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.EntityID(req.Id))
+
+	if req.Id == "" {
+		return nil, apperrors.InvalidArgumentf("entity id is required").WithField("id")
+	}
+
+	response := &pb.DeleteEntityResponse{
+		Id:     req.Id,
+		DryRun: req.DryRun,
+	}
 
-	// Check if entity exists before deleting
+	// Check if entity contains metadata
 	_, err := s.mongoRepo.ReadEntity(ctx, req.Id)
+	response.Metadata = err == nil
+
+	// Check if entity exists in the graph
+	graphEntity, err := s.neo4jRepo.ReadGraphEntity(ctx, req.Id)
 	if err != nil {
-		// NOTE: Not returning an error here because we want to delete the
-		// entity even if it does not contain metadata
-		log.Printf("[server.DeleteEntity] Entity %s does not contain metadata: %v", req.Id, err)
-	} else {
-		log.Printf("[server.DeleteEntity] Entity %s metadata exists.", req.Id)
-		_, err = s.mongoRepo.DeleteEntity(ctx, req.Id)
-		if err != nil {
-			// Log error
-			log.Printf("[server.DeleteEntity] Error deleting metadata for entity %s: %v", req.Id, err)
-			return nil, fmt.Errorf("error deleting metadata for entity %s: %v", req.Id, err)
-		} else {
-			log.Printf("[server.DeleteEntity] Entity %s metadata deleted.", req.Id)
+		if !apperrors.Is(err, apperrors.NotFound) {
+			logging.FromContext(ctx).Error("Error reading entity", "entity_id", req.Id, "error", err)
+			return nil, fmt.Errorf("error reading entity %s: %w", req.Id, err)
+		}
+		// NOTE: Not returning an error here so that deleting an entity twice succeeds,
+		// only metadata left behind by a partial create is removed
+		logging.FromContext(ctx).Debug("Entity does not exist in the graph", "entity_id", req.Id)
+		if err := s.deleteEntityMetadata(ctx, response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	relationships, err := s.planEntityDeletion(ctx, req, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DryRun {
+		logging.FromContext(ctx).Debug("Dry run for entity", "entity_id", req.Id, "relationship_ids", response.RelationshipIds, "attributes", response.Attributes, "attribute_tables", response.AttributeTables, "metadata", response.Metadata)
+		return response, nil
+	}
+
+	deleted := &pb.Entity{Id: req.Id, Kind: &pb.Kind{}}
+	deleted.Kind.Major, _ = graphEntity["MajorKind"].(string)
+	deleted.Kind.Minor, _ = graphEntity["MinorKind"].(string)
+	events := []*pb.EntityEvent{}
+	for _, relationshipID := range response.RelationshipIds {
+		events = append(events, watch.RelationshipEvent(watch.RelationshipDeleted, deleted, relationships[relationshipID]))
+	}
+	events = append(events, watch.EntityEvent(watch.EntityDeleted, deleted))
+
+	before, err := s.auditSnapshot(ctx, req.Id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error reading entity before deletion", "entity_id", req.Id, "error", err)
+		return nil, err
+	}
+
+	// A deletion cannot be rolled back, so its events are held for the webhooks until it finished
+	eventIDs, err := s.outbox.Hold(ctx, events...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error recording events of entity", "entity_id", req.Id, "error", err)
+		return nil, err
+	}
+	finished := false
+	defer func() {
+		if finished {
+			return
+		}
+		if err := s.outbox.Discard(context.WithoutCancel(ctx), eventIDs); err != nil {
+			logging.FromContext(ctx).Error("Error discarding events of entity", "entity_id", req.Id, "error", err)
+		}
+	}()
+
+	// Tabular attribute values are dropped before the attribute nodes that point to them
+	_, err = s.postgresRepo.DeleteEntityAttributeTables(ctx, req.Id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error deleting attribute tables for entity", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error deleting attribute tables for entity %s: %w", req.Id, err)
+	}
+
+	for _, attributeName := range response.Attributes {
+		if err := s.graphManager.DeleteAttribute(ctx, req.Id, attributeName); err != nil {
+			logging.FromContext(ctx).Error("Error deleting attribute for entity", "attribute", attributeName, "entity_id", req.Id, "error", err)
+			return nil, fmt.Errorf("error deleting attribute %s for entity %s: %w", attributeName, req.Id, err)
+		}
+	}
+
+	for _, relationshipID := range response.RelationshipIds {
+		if err := s.neo4jRepo.DeleteRelationship(ctx, relationshipID); err != nil {
+			logging.FromContext(ctx).Error("Error deleting relationship for entity", "relationship_id", relationshipID, "entity_id", req.Id, "error", err)
+			return nil, fmt.Errorf("error deleting relationship %s for entity %s: %w", relationshipID, req.Id, err)
+		}
+	}
+
+	if err := s.deleteEntityMetadata(ctx, response); err != nil {
+		return nil, err
+	}
+	if err := s.mongoRepo.DeleteRevisions(ctx, req.Id); err != nil {
+		logging.FromContext(ctx).Error("Error deleting revisions for entity", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error deleting revisions for entity %s: %w", req.Id, err)
+	}
+	if len(response.RelationshipIds) > 0 {
+		if err := s.mongoRepo.DeleteRelationshipVersions(ctx, response.RelationshipIds); err != nil {
+			logging.FromContext(ctx).Error("Error deleting relationship versions for entity", "entity_id", req.Id, "error", err)
+			return nil, fmt.Errorf("error deleting relationship versions for entity %s: %w", req.Id, err)
 		}
 	}
-	// TODO: Implement Relationship Deletion in Neo4j
-	// TODO: Implement Entity Deletion in Neo4j
-	// TODO: Implement Attribute Deletion in Neo4j
-	return &pb.Empty{}, nil
+
+	// The node goes last, Neo4j refuses to delete a node that still has relationships
+	if err := s.neo4jRepo.DeleteGraphEntity(ctx, req.Id); err != nil {
+		logging.FromContext(ctx).Error("Error deleting entity from the graph", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error deleting entity %s from the graph: %w", req.Id, err)
+	}
+
+	logging.FromContext(ctx).Info("Entity deleted", "entity_id", req.Id)
+	finished = true
+	attributes := make([]audit.AttributeWrite, len(response.Attributes))
+	for i, attributeName := range response.Attributes {
+		attributes[i] = audit.AttributeWrite{Name: attributeName, Deleted: true}
+	}
+	if err := s.audit.Append(context.WithoutCancel(ctx), req.Id, audit.Diff(before, nil), attributes); err != nil {
+		// Like the events, the record is logged rather than failing a deletion that is done
+		logging.FromContext(ctx).Error("Error auditing deletion of entity", "entity_id", req.Id, logging.Payload("changes", audit.Diff(before, nil)), "error", err)
+	}
+	if err := s.outbox.Release(context.WithoutCancel(ctx), eventIDs); err != nil {
+		// The entity is gone, failing now would only make the caller retry a finished deletion
+		logging.FromContext(ctx).Error("Error releasing events of entity, the webhooks will miss them", "entity_id", req.Id, "event_ids", eventIDs, "error", err)
+	}
+	s.events.Publish(events...)
+	return response, nil
 }
 
-// ReadEntities retrieves a list of entities filtered by base attributes
+// planEntityDeletion collects the relationships, attributes and attribute tables that have to be
+// removed together with the entity. It fails when the entity has incoming relationships and cascade is not set.
+// The relationships to remove are also returned by id, with their direction seen from the entity.
+func (s *Server) planEntityDeletion(ctx context.Context, req *pb.DeleteEntityRequest, response *pb.DeleteEntityResponse) (map[string]*pb.Relationship, error) {
+	relationships, err := s.neo4jRepo.ReadRelationships(ctx, req.Id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error reading relationships for entity", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error reading relationships for entity %s: %w", req.Id, err)
+	}
+
+	var incoming []string
+	removed := make(map[string]*pb.Relationship)
+	seen := make(map[string]bool)
+	for _, rel := range relationships {
+		relationshipID, _ := rel["relationshipID"].(string)
+		// A relationship from the entity to itself is returned in both directions
+		if seen[relationshipID] {
+			continue
+		}
+		seen[relationshipID] = true
+
+		// Attribute relationships are removed together with their attribute nodes
+		if rel["type"] == engine.IS_ATTRIBUTE_RELATIONSHIP && rel["direction"] == engine.IS_ATTRIBUTE_RELATIONSHIP_DIRECTION {
+			continue
+		}
+		if rel["direction"] == "INCOMING" && rel["relatedID"] != req.Id {
+			incoming = append(incoming, relationshipID)
+			if !req.Cascade {
+				continue
+			}
+		}
+		response.RelationshipIds = append(response.RelationshipIds, relationshipID)
+		removed[relationshipID] = &pb.Relationship{Id: relationshipID}
+		removed[relationshipID].Name, _ = rel["type"].(string)
+		removed[relationshipID].RelatedEntityId, _ = rel["relatedID"].(string)
+		removed[relationshipID].Direction, _ = rel["direction"].(string)
+	}
+
+	if len(incoming) > 0 && !req.Cascade {
+		logging.FromContext(ctx).Debug("Entity has incoming relationships", "entity_id", req.Id, "incoming", incoming)
+		return nil, apperrors.FailedPreconditionf("entity %s is referenced by relationships %v, set cascade to delete them", req.Id, incoming).WithEntity(req.Id).WithField("cascade")
+	}
+
+	attributes, err := s.graphManager.ListAttributes(ctx, req.Id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error listing attributes for entity", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error listing attributes for entity %s: %w", req.Id, err)
+	}
+	// A time based attribute has one node per value but is deleted by name
+	attributeNames := make(map[string]bool)
+	for _, attribute := range attributes {
+		if !attributeNames[attribute.AttributeName] {
+			attributeNames[attribute.AttributeName] = true
+			response.Attributes = append(response.Attributes, attribute.AttributeName)
+		}
+	}
+	sort.Strings(response.Attributes)
+
+	response.AttributeTables, err = s.postgresRepo.ListEntityAttributeTables(ctx, req.Id)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error listing attribute tables for entity", "entity_id", req.Id, "error", err)
+		return nil, fmt.Errorf("error listing attribute tables for entity %s: %w", req.Id, err)
+	}
+
+	return removed, nil
+}
+
+// deleteEntityMetadata removes the metadata document of the entity unless this is a dry run
+func (s *Server) deleteEntityMetadata(ctx context.Context, response *pb.DeleteEntityResponse) error {
+	if !response.Metadata || response.DryRun {
+		return nil
+	}
+	if _, err := s.mongoRepo.DeleteEntity(ctx, response.Id); err != nil {
+		logging.FromContext(ctx).Error("Error deleting metadata for entity", "entity_id", response.Id, "error", err)
+		return fmt.Errorf("error deleting metadata for entity %s: %w", response.Id, err)
+	}
+	logging.FromContext(ctx).Debug("Entity metadata deleted", "entity_id", response.Id)
+	return nil
+}
+
+// ReadEntities retrieves a list of entities filtered by base attributes.
+// With activeAt only the entities that existed at that time are returned, with the name they had then.
 func (s *Server) ReadEntities(ctx context.Context, req *pb.ReadEntityRequest) (*pb.EntityList, error) {
 	if req.Entity == nil {
-		return nil, fmt.Errorf("entity is required for filtering entities")
+		return nil, apperrors.InvalidArgumentf("entity is required for filtering entities").WithField("entity")
+	}
+	activeAt, err := parseOptionalTime(req.ActiveAt, "activeAt")
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if we have either an ID or Kind.Major
 	if req.Entity.Id == "" && (req.Entity.Kind == nil || req.Entity.Kind.Major == "") {
-		return nil, fmt.Errorf("either Entity.Id or Entity.Kind.Major is required for filtering entities")
+		return nil, apperrors.InvalidArgumentf("either Entity.Id or Entity.Kind.Major is required for filtering entities").WithField("entity.kind.major")
 	}
 
 	// If we have an ID, add it to the filters
 	if req.Entity.Id != "" {
-		log.Printf("Filtering entities by ID: %s", req.Entity.Id)
+		logging.FromContext(ctx).Debug("Filtering entities by ID", "entity_id", req.Entity.Id)
 	} else {
-		log.Printf("Filtering entities by Kind.Major: %s", req.Entity.Kind.Major)
+		logging.FromContext(ctx).Debug("Filtering entities by Kind.Major", "major", req.Entity.Kind.Major)
 	}
 
 	// Use HandleGraphEntityFilter to get filtered entities
-	filteredEntities, err := s.neo4jRepo.HandleGraphEntityFilter(ctx, req)
+	page, err := s.neo4jRepo.HandleGraphEntityFilter(ctx, req)
 	if err != nil {
-		log.Printf("Error filtering entities: %v", err)
+		logging.FromContext(ctx).Error("Error filtering entities", "error", err)
 		return nil, err
 	}
 
 	// Convert filtered entities to pb.Entity format
 	var entities []*pb.Entity
-	for _, entity := range filteredEntities {
+	for _, entity := range page.Entities {
 		pbEntity := &pb.Entity{
 			Id: entity["id"].(string),
 			Kind: &pb.Kind{
@@ -387,12 +676,34 @@ func (s *Server) ReadEntities(ctx context.Context, req *pb.ReadEntityRequest) (*
 			pbEntity.Terminated = terminated
 			pbEntity.Name.EndTime = terminated
 		}
+		pbEntity.Version, _ = entity["version"].(int64)
 
 		entities = append(entities, pbEntity)
 	}
 
+	if !activeAt.IsZero() && len(entities) > 0 {
+		ids := make([]string, len(entities))
+		for i, entity := range entities {
+			ids[i] = entity.Id
+		}
+		revisions, err := s.mongoRepo.ReadRevisions(ctx, ids, mongorepository.NameRevision)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error reading names of entities", "error", err)
+			return nil, fmt.Errorf("error reading names of entities: %w", err)
+		}
+		for _, entity := range entities {
+			if name, startTime, endTime, ok := nameAt(revisions[entity.Id], activeAt, entity.Created, entity.Terminated); ok {
+				entity.Name.Value.Value = []byte(name)
+				entity.Name.StartTime = startTime
+				entity.Name.EndTime = endTime
+			}
+		}
+	}
+
 	return &pb.EntityList{
-		Entities: entities,
+		Entities:      entities,
+		NextPageToken: neo4jrepository.EncodeEntityCursor(page.Next),
+		TotalCount:    page.TotalCount,
 	}, nil
 }
 
@@ -416,7 +727,7 @@ func extractFieldsFromAttributes(attributes map[string]*pb.TimeBasedValueList) [
 		// Determine storage type and extract fields accordingly
 		storageType, err := determineStorageTypeFromValue(value.Value)
 		if err != nil {
-			log.Printf("Warning: could not determine storage type for attribute %s: %v", attrName, err)
+			slog.Warn("Could not determine storage type for attribute", "attribute", attrName, "error", err)
 			continue
 		}
 
@@ -426,16 +737,16 @@ func extractFieldsFromAttributes(attributes map[string]*pb.TimeBasedValueList) [
 			if columns, err := extractColumnsFromTabularAttribute(value.Value); err == nil {
 				fields = append(fields, columns...)
 			} else {
-				log.Printf("Warning: could not extract columns from tabular attribute %s: %v", attrName, err)
+				slog.Warn("Could not extract columns from tabular attribute", "attribute", attrName, "error", err)
 			}
 		case "graph":
 			// TODO: Handle graph data fields
-			log.Printf("Graph data fields extraction not implemented yet for attribute %s", attrName)
+			slog.Debug("Graph data fields extraction not implemented yet for attribute", "attribute", attrName)
 		case "map":
 			// TODO: Handle document/map data fields
-			log.Printf("Document data fields extraction not implemented yet for attribute %s", attrName)
+			slog.Debug("Document data fields extraction not implemented yet for attribute", "attribute", attrName)
 		default:
-			log.Printf("Unknown storage type %s for attribute %s", storageType, attrName)
+			slog.Debug("Unknown storage type for attribute", "storage_type", storageType, "attribute", attrName)
 		}
 	}
 
@@ -447,7 +758,7 @@ func determineStorageTypeFromValue(anyValue *anypb.Any) (string, error) {
 	// Unpack the Any value to get the underlying message
 	message, err := anyValue.UnmarshalNew()
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal Any value: %v", err)
+		return "", fmt.Errorf("failed to unmarshal Any value: %w", err)
 	}
 
 	// Check if it's a struct
@@ -480,7 +791,7 @@ func extractColumnsFromTabularAttribute(anyValue *anypb.Any) ([]string, error) {
 	// Unpack the Any value to get the underlying message
 	message, err := anyValue.UnmarshalNew()
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal Any value: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal Any value: %w", err)
 	}
 
 	// Check if it's a struct
@@ -509,79 +820,207 @@ func extractColumnsFromTabularAttribute(anyValue *anypb.Any) ([]string, error) {
 	return columns, nil
 }
 
+// fatal logs the error and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 // Start the gRPC server
 func main() {
-	// Initialize MongoDB config
-	mongoConfig := &config.MongoConfig{
-		URI:        os.Getenv("MONGO_URI"),
-		DBName:     os.Getenv("MONGO_DB_NAME"),
-		Collection: os.Getenv("MONGO_COLLECTION"),
-	}
-
-	// Initialize Neo4j config
-	neo4jConfig := &config.Neo4jConfig{
-		URI:      os.Getenv("NEO4J_URI"),
-		Username: os.Getenv("NEO4J_USER"),
-		Password: os.Getenv("NEO4J_PASSWORD"),
-	}
+	// The config file is optional, every setting can also be given as an environment variable
+	configPath := flag.String("config", os.Getenv("CORE_CONFIG_FILE"), "path to the YAML config file")
+	flag.Parse()
 
-	// Initialize PostgreSQL config
-	postgresConfig := &postgres.Config{
-		Host:     os.Getenv("POSTGRES_HOST"),
-		Port:     os.Getenv("POSTGRES_PORT"),
-		User:     os.Getenv("POSTGRES_USER"),
-		Password: os.Getenv("POSTGRES_PASSWORD"),
-		DBName:   os.Getenv("POSTGRES_DB"),
-		SSLMode:  os.Getenv("POSTGRES_SSL_MODE"),
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fatal("Failed to load configuration", err)
 	}
-
-	// Get host and port from environment variables with defaults
-	host := os.Getenv("CORE_SERVICE_HOST")
-	if host == "" {
-		host = "0.0.0.0"
+	if _, err := logging.Setup(cfg.Logging, os.Stderr); err != nil {
+		fatal("Failed to set up logging", err)
 	}
-	port := os.Getenv("CORE_SERVICE_PORT")
-	if port == "" {
-		port = "50051"
+	// Trace before connecting, so that the first queries are traced too
+	ctx := context.Background()
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
 	}
 
 	// Create MongoDB repository
-	ctx := context.Background()
-	mongoRepo := mongorepository.NewMongoRepository(ctx, mongoConfig)
+	mongoRepo := mongorepository.NewMongoRepository(ctx, &cfg.Mongo)
+	if err := mongoRepo.CreateRevisionIndexes(ctx); err != nil {
+		fatal("Failed to set up entity revisions", err)
+	}
+	if err := mongoRepo.CreateRelationshipVersionIndexes(ctx); err != nil {
+		fatal("Failed to set up relationship versions", err)
+	}
 
 	// Create Neo4j repository
-	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, neo4jConfig)
+	neo4jRepo, err := neo4jrepository.NewNeo4jRepository(ctx, &cfg.Neo4j)
 	if err != nil {
-		log.Fatalf("[service.main] Failed to create Neo4j repository: %v", err)
+		fatal("Failed to create Neo4j repository", err)
 	}
-	defer neo4jRepo.Close(ctx)
 
 	// Create PostgreSQL repository
-	postgresRepo, err := postgres.NewPostgresRepository(*postgresConfig)
+	postgresRepo, err := postgres.NewPostgresRepository(cfg.Postgres)
 	if err != nil {
-		log.Fatalf("[service.main] Failed to create PostgreSQL repository: %v", err)
+		fatal("Failed to create PostgreSQL repository", err)
+	}
+	// The attribute value intervals are read before any attribute is written
+	if err := postgresRepo.InitializeTables(ctx); err != nil {
+		fatal("Failed to set up PostgreSQL tables", err)
 	}
-	defer postgresRepo.Close()
 
-	listener, err := net.Listen("tcp", host+":"+port)
+	listener, err := net.Listen("tcp", cfg.Address())
 	if err != nil {
-		log.Fatalf("[service.main] Failed to listen: %v", err)
+		fatal("Failed to listen", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	// Every call is logged with its request id, and metrics see every call, including the rejected ones,
+	// with its final status code. Callers are then authenticated and authorized before anything else runs.
+	unaryInterceptors := []grpc.UnaryServerInterceptor{logging.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{logging.StreamServerInterceptor()}
+	if cfg.Features.Metrics {
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, metrics.StreamServerInterceptor())
+	}
+	if cfg.Auth.Enabled {
+		authenticator, err := auth.NewAuthenticator(cfg.Auth)
+		if err != nil {
+			fatal("Failed to set up authentication", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(authenticator, authPolicy))
+		streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(authenticator, authPolicy))
+	} else {
+		slog.Warn("Authentication is disabled, every caller can read and write")
+	}
+
+	// Errors are turned into status codes with details the clients can act on.
+	// Stop waits for the handlers, so that cancelled writes are rolled back before the repositories close.
+	unaryInterceptors = append(unaryInterceptors, apperrors.UnaryServerInterceptor(classifyStoreError))
+	streamInterceptors = append(streamInterceptors, apperrors.StreamServerInterceptor(classifyStoreError))
+	// Retried writes with an idempotency key are answered with the response of the first call
+	if cfg.Features.Idempotency {
+		idempotencyStore, err := mongoRepo.IdempotencyStore(ctx)
+		if err != nil {
+			fatal("Failed to set up idempotency keys", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, idempotency.UnaryServerInterceptor(idempotencyStore, cfg.Idempotency.Window,
+			pb.COREService_CreateEntity_FullMethodName, pb.COREService_UpdateEntity_FullMethodName))
+	}
+	// Every call joins the caller's trace, except the health checks that would flood it
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.WaitForHandlers(true),
+	)
+	// The engine writes the attributes through the repositories of the server
+	repositories := &engine.Repositories{Neo4j: neo4jRepo, Mongo: mongoRepo, Postgres: postgresRepo}
 	server := &Server{
 		mongoRepo:    mongoRepo,
 		neo4jRepo:    neo4jRepo,
 		postgresRepo: postgresRepo,
+		processor:    engine.NewEntityAttributeProcessor(repositories),
+		graphManager: engine.NewGraphMetadataManager(repositories),
+		events:       watch.NewHub(cfg.Watch.BufferSize),
 	}
 
 	pb.RegisterCOREServiceServer(grpcServer, server)
 
+	// Register the health service, backed by periodic checks of the three stores
+	background := newWorkers()
+	checker := healthcheck.New(cfg.Health.Interval, cfg.Health.Timeout, pb.COREService_ServiceDesc.ServiceName)
+	if cfg.Features.HealthChecks {
+		checker.Add("neo4j", true, neo4jRepo.Ping)
+		checker.Add("mongodb", true, mongoRepo.Ping)
+		checker.Add("postgres", true, postgresRepo.DB().PingContext)
+		checker.Check(ctx)
+		background.Go("health checker", checker.Run)
+		healthpb.RegisterHealthServer(grpcServer, checker.Server())
+	}
+
+	// Serve the Prometheus metrics, including the connection pools of the three stores
+	if cfg.Features.Metrics {
+		metrics.RegisterPool(metrics.Neo4j, neo4jRepo.PoolStats)
+		metrics.RegisterPool(metrics.MongoDB, mongoRepo.PoolStats)
+		metrics.RegisterPool(metrics.Postgres, postgresRepo.PoolStats)
+		metricsListener, err := net.Listen("tcp", cfg.Metrics.Address)
+		if err != nil {
+			fatal("Failed to listen for metrics", err)
+		}
+		background.Go("metrics server", func(ctx context.Context) {
+			serveMetrics(ctx, metricsListener)
+		})
+	}
+
+	// Append every write to the audit log
+	if cfg.Features.Audit {
+		auditStore, err := mongoRepo.AuditStore(ctx)
+		if err != nil {
+			fatal("Failed to set up the audit log", err)
+		}
+		server.audit = audit.New(auditStore)
+	} else {
+		slog.Warn("The audit log is disabled, writes are not recorded")
+	}
+
+	// Deliver the events recorded in the outbox to the webhooks
+	if cfg.Features.Webhooks {
+		endpoints, err := outbox.LoadEndpoints(cfg.Webhooks.EndpointsFile)
+		if err != nil {
+			fatal("Failed to load webhook endpoints", err)
+		}
+		outboxStore, err := mongoRepo.OutboxStore(ctx, cfg.Webhooks.Retention)
+		if err != nil {
+			fatal("Failed to set up the outbox", err)
+		}
+		server.outbox = outbox.New(outboxStore, endpoints)
+		background.Go("webhook dispatcher", outbox.NewDispatcher(outboxStore, endpoints, cfg.Webhooks).Run)
+		slog.Info("Delivering changes to webhooks", "endpoints", len(endpoints))
+	}
+
 	// Register reflection service
-	reflection.Register(grpcServer)
+	if cfg.Features.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("CORE Service is running", "address", cfg.Address())
+		serveErr <- grpcServer.Serve(listener)
+	}()
+
+	// Wait for SIGINT or SIGTERM
+	signalCtx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case <-signalCtx.Done():
+		slog.Info("Shutting down")
+	case err := <-serveErr:
+		fatal("Failed to serve", err)
+	}
 
-	log.Printf("[service.main] CORE Service is running on %s:%s...", host, port)
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("[service.main] Failed to serve: %v", err)
+	// Report NOT_SERVING first so that clients stop sending requests, then end the watches, which
+	// would otherwise never finish, and drain the running calls
+	shutdownTimeout := cfg.Server.ShutdownTimeout
+	checker.Shutdown()
+	server.events.Close()
+	gracefulStop(grpcServer, shutdownTimeout)
+	background.Stop(shutdownTimeout)
+
+	// Close the repositories in reverse order of creation
+	closeCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancel()
+	if err := postgresRepo.Close(); err != nil {
+		slog.Error("Error closing PostgreSQL repository", "error", err)
+	}
+	neo4jRepo.Close(closeCtx)
+	if err := mongoRepo.Close(closeCtx); err != nil {
+		slog.Error("Error closing MongoDB repository", "error", err)
+	}
+	if err := shutdownTracing(closeCtx); err != nil {
+		slog.Error("Error flushing traces", "error", err)
 	}
+	slog.Info("CORE Service stopped")
 }
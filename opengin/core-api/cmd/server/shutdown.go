@@ -0,0 +1,71 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// workers runs the background goroutines of the server, so that shutdown can stop them
+// and wait for them before the repositories they use are closed
+type workers struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newWorkers() *workers {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workers{ctx: ctx, cancel: cancel}
+}
+
+// Go starts a worker. The worker must return once its context is cancelled.
+func (w *workers) Go(name string, run func(ctx context.Context)) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		run(w.ctx)
+		slog.Info("Worker stopped", "worker", name)
+	}()
+}
+
+// Stop cancels the workers and waits until they have returned or the timeout expired
+func (w *workers) Stop(timeout time.Duration) {
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		slog.Warn("Workers did not stop in time", "timeout", timeout)
+	}
+}
+
+// gracefulStop stops accepting new RPCs and waits for the running ones to finish.
+// RPCs still running after the timeout are cancelled, which rolls back their partial writes.
+func gracefulStop(grpcServer *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("All RPCs finished")
+	case <-time.After(timeout):
+		slog.Warn("RPCs still running, cancelling them", "timeout", timeout)
+		grpcServer.Stop()
+		<-done
+	}
+}
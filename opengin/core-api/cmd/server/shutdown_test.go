@@ -0,0 +1,70 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWorkersStop(t *testing.T) {
+	w := newWorkers()
+	stopped := make(chan struct{})
+	w.Go("test worker", func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+
+	w.Stop(time.Second)
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Expected the worker to have stopped")
+	}
+}
+
+// blockingHealthServer answers Watch by blocking until the RPC is cancelled
+type blockingHealthServer struct {
+	*health.Server
+	started chan struct{}
+}
+
+func (s *blockingHealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	close(s.started)
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestGracefulStopCancelsRPCsAfterTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	grpcServer := grpc.NewServer(grpc.WaitForHandlers(true))
+	healthServer := &blockingHealthServer{Server: health.NewServer(), started: make(chan struct{})}
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go grpcServer.Serve(listener)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+	<-healthServer.started
+
+	start := time.Now()
+	gracefulStop(grpcServer, 100*time.Millisecond)
+	assert.Less(t, time.Since(start), 5*time.Second, "Expected the running RPC to be cancelled after the timeout")
+
+	_, err = stream.Recv()
+	assert.Error(t, err, "Expected the cancelled RPC to fail")
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/saga"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The reads with activeAt return an entity as it was at that time. Neo4j and MongoDB only hold the
+// latest name and metadata of an entity, so every write of them is also kept as a revision. The name
+// holds from the start time of its TimeBasedValue, the metadata from the write that stored it. The
+// first revision holds since the creation of the entity.
+
+// Layouts of the times of entities. Neo4j leaves out the seconds of a time when they are zero.
+var entityTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00", time.DateOnly}
+
+// parseEntityTime parses a time of a request or of a stored entity, an RFC 3339 time or a date
+func parseEntityTime(value, field string) (time.Time, error) {
+	for _, layout := range entityTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, apperrors.InvalidArgumentf("invalid %s %q, expected an RFC 3339 time", field, value).WithField(field)
+}
+
+// parseOptionalTime parses a time of a request, returning the zero time if it is empty
+func parseOptionalTime(value, field string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseEntityTime(value, field)
+}
+
+// checkActive fails with NotFound unless the entity was created and not yet terminated at the time
+func checkActive(entityID, created, terminated string, at time.Time) error {
+	if createdAt, err := parseEntityTime(created, "created"); err == nil && at.Before(createdAt) {
+		return apperrors.NotFoundf("entity %s was not created yet at %s, it was created at %s", entityID, formatTime(at), created).WithEntity(entityID).WithField("activeAt")
+	}
+	if terminated == "" {
+		return nil
+	}
+	if terminatedAt, err := parseEntityTime(terminated, "terminated"); err == nil && !at.Before(terminatedAt) {
+		return apperrors.NotFoundf("entity %s was already terminated at %s, it was terminated at %s", entityID, formatTime(at), terminated).WithEntity(entityID).WithField("activeAt")
+	}
+	return nil
+}
+
+// revisionAt returns the index of the revision that held at the time, the last one started by then or
+// else the first one, or -1 without revisions. The revisions are ordered by start time.
+func revisionAt(revisions []*mongorepository.Revision, at time.Time) int {
+	if len(revisions) == 0 {
+		return -1
+	}
+	index := 0
+	for i, revision := range revisions {
+		if revision.StartTime.After(at) {
+			break
+		}
+		index = i
+	}
+	return index
+}
+
+// nameAt returns the name of the entity that held at the time and the interval it held for,
+// or false without revisions
+func nameAt(revisions []*mongorepository.Revision, at time.Time, created, terminated string) (name, startTime, endTime string, ok bool) {
+	index := revisionAt(revisions, at)
+	if index < 0 {
+		return "", "", "", false
+	}
+	startTime, endTime = revisionInterval(revisions, index, created, terminated)
+	return revisions[index].Name, startTime, endTime, true
+}
+
+// revisionInterval returns the interval the revision held for, from its start time, or the creation
+// of the entity for the first one, until the start time of the next one or the termination of the entity
+func revisionInterval(revisions []*mongorepository.Revision, index int, created, terminated string) (startTime, endTime string) {
+	startTime, endTime = created, terminated
+	if index > 0 {
+		startTime = formatTime(revisions[index].StartTime)
+	}
+	if index+1 < len(revisions) {
+		endTime = formatTime(revisions[index+1].StartTime)
+	}
+	return startTime, endTime
+}
+
+// metadataAt returns the metadata of the entity that held at the time, or nil without revisions
+func metadataAt(revisions []*mongorepository.Revision, at time.Time) map[string]*anypb.Any {
+	index := revisionAt(revisions, at)
+	if index < 0 {
+		return nil
+	}
+	if revisions[index].Metadata == nil {
+		return make(map[string]*anypb.Any)
+	}
+	return revisions[index].Metadata
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// readNameAt returns the name the entity had at the time, or the name given if its name was never revised
+func (s *Server) readNameAt(ctx context.Context, entityID string, name *pb.TimeBasedValue, at time.Time, created, terminated string) (*pb.TimeBasedValue, error) {
+	revisions, err := s.mongoRepo.ReadRevisions(ctx, []string{entityID}, mongorepository.NameRevision)
+	if err != nil {
+		return nil, fmt.Errorf("error reading names of entity %s: %w", entityID, err)
+	}
+	revised, startTime, endTime, ok := nameAt(revisions[entityID], at, created, terminated)
+	if !ok {
+		return name, nil
+	}
+	value, err := anypb.New(wrapperspb.String(revised))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TimeBasedValue{StartTime: startTime, EndTime: endTime, Value: value}, nil
+}
+
+// readMetadataAt returns the metadata the entity had at the time
+func (s *Server) readMetadataAt(ctx context.Context, entityID string, at time.Time) (map[string]*anypb.Any, error) {
+	revisions, err := s.mongoRepo.ReadRevisions(ctx, []string{entityID}, mongorepository.MetadataRevision)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata revisions of entity %s: %w", entityID, err)
+	}
+	if metadata := metadataAt(revisions[entityID], at); metadata != nil {
+		return metadata, nil
+	}
+	// Metadata written before revisions were kept
+	return s.mongoRepo.GetMetadata(ctx, entityID)
+}
+
+// recordRevisions keeps the name and metadata of a new entity as their first revisions
+func (s *Server) recordRevisions(ctx context.Context, sg *saga.Saga, entity *pb.Entity) error {
+	created, err := parseEntityTime(entity.Created, "created")
+	if err != nil {
+		return err
+	}
+	if name := nameOf(entity); name != "" {
+		start := created
+		if entity.Name.StartTime != "" {
+			if start, err = parseEntityTime(entity.Name.StartTime, "name.startTime"); err != nil {
+				return err
+			}
+		}
+		err := s.addRevision(ctx, sg, &mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.NameRevision, StartTime: start, Name: name})
+		if err != nil {
+			return err
+		}
+	}
+	if len(entity.Metadata) > 0 {
+		return s.addRevision(ctx, sg, &mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.MetadataRevision, StartTime: created, Metadata: entity.Metadata})
+	}
+	return nil
+}
+
+// reviseEntity keeps the name and metadata an update gives the entity. The name holds from the start
+// time of its value or else from now, the metadata from now. The values replaced are kept first if the
+// entity has no revisions of them yet, as they were written before revisions were kept.
+func (s *Server) reviseEntity(ctx context.Context, sg *saga.Saga, entity *pb.Entity) error {
+	name := nameOf(entity)
+	if name == "" && len(entity.Metadata) == 0 {
+		return nil
+	}
+	previous, err := s.neo4jRepo.ReadGraphEntity(ctx, entity.Id)
+	if err != nil {
+		return err
+	}
+	created, _ := previous["Created"].(string)
+	now := time.Now().UTC()
+
+	if name != "" {
+		start := now
+		if entity.Name.StartTime != "" {
+			if start, err = parseEntityTime(entity.Name.StartTime, "name.startTime"); err != nil {
+				return err
+			}
+		}
+		replaced, _ := previous["Name"].(string)
+		err := s.revise(ctx, sg, created,
+			&mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.NameRevision, StartTime: start, Name: name},
+			&mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.NameRevision, Name: replaced})
+		if err != nil {
+			return err
+		}
+	}
+	if len(entity.Metadata) > 0 {
+		replaced, err := s.mongoRepo.GetMetadata(ctx, entity.Id)
+		if err != nil {
+			return err
+		}
+		return s.revise(ctx, sg, created,
+			&mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.MetadataRevision, StartTime: now, Metadata: entity.Metadata},
+			&mongorepository.Revision{EntityID: entity.Id, Field: mongorepository.MetadataRevision, Metadata: replaced})
+	}
+	return nil
+}
+
+// revise adds the revision of an update, preceded by the replaced value since the creation of the
+// entity if the field has no revisions yet
+func (s *Server) revise(ctx context.Context, sg *saga.Saga, created string, revision, replaced *mongorepository.Revision) error {
+	revised, err := s.mongoRepo.HasRevisions(ctx, revision.EntityID, revision.Field)
+	if err != nil {
+		return err
+	}
+	if !revised && (replaced.Name != "" || len(replaced.Metadata) > 0) {
+		if replaced.StartTime, err = parseEntityTime(created, "created"); err != nil {
+			return err
+		}
+		if err := s.addRevision(ctx, sg, replaced); err != nil {
+			return err
+		}
+	}
+	return s.addRevision(ctx, sg, revision)
+}
+
+// addRevision stores the revision
+func (s *Server) addRevision(ctx context.Context, sg *saga.Saga, revision *mongorepository.Revision) error {
+	if err := s.mongoRepo.AddRevision(ctx, revision); err != nil {
+		return fmt.Errorf("error recording %s revision of entity %s: %w", revision.Field, revision.EntityID, err)
+	}
+	revisionID := revision.ID
+	sg.Record("add "+revision.Field+" revision", func(ctx context.Context) error {
+		return s.mongoRepo.DeleteRevision(ctx, revisionID)
+	})
+	return nil
+}
+
+// nameOf returns the name of the entity, or an empty string if it has none
+func nameOf(entity *pb.Entity) string {
+	if entity.GetName().GetValue() == nil {
+		return ""
+	}
+	var name wrapperspb.StringValue
+	if err := entity.Name.Value.UnmarshalTo(&name); err != nil {
+		return ""
+	}
+	return name.Value
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func date(t *testing.T, value string) time.Time {
+	parsed, err := parseEntityTime(value, "date")
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestParseEntityTime(t *testing.T) {
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), date(t, "2022-01-01"))
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), date(t, "2022-01-01T00:00Z"), "Expected the times Neo4j returns to parse")
+	assert.Equal(t, time.Date(2022, 1, 1, 12, 30, 15, 0, time.UTC), date(t, "2022-01-01T12:30:15Z"))
+
+	activeAt, err := parseOptionalTime("", "activeAt")
+	assert.NoError(t, err)
+	assert.True(t, activeAt.IsZero())
+	_, err = parseOptionalTime("yesterday", "activeAt")
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument))
+}
+
+func TestCheckActive(t *testing.T) {
+	assert.NoError(t, checkActive("ministry", "2020-01-01T00:00:00Z", "", date(t, "2020-01-01")))
+	assert.NoError(t, checkActive("ministry", "2020-01-01T00:00:00Z", "2024-01-01T00:00:00Z", date(t, "2023-12-31")))
+
+	err := checkActive("ministry", "2020-01-01T00:00:00Z", "", date(t, "2019-12-31"))
+	assert.True(t, apperrors.Is(err, apperrors.NotFound), "Expected NotFound before the creation")
+	err = checkActive("ministry", "2020-01-01T00:00:00Z", "2024-01-01T00:00:00Z", date(t, "2024-01-01"))
+	assert.True(t, apperrors.Is(err, apperrors.NotFound), "Expected NotFound from the termination on")
+}
+
+func TestNameAt(t *testing.T) {
+	revisions := []*mongorepository.Revision{
+		{StartTime: date(t, "2020-06-01"), Name: "Ministry of Health"},
+		{StartTime: date(t, "2022-01-01"), Name: "Ministry of Health and Indigenous Medicine"},
+		{StartTime: date(t, "2024-01-01"), Name: "Ministry of Health and Mass Media"},
+	}
+	created, terminated := "2020-01-01T00:00:00Z", "2025-01-01T00:00:00Z"
+
+	name, startTime, endTime, ok := nameAt(revisions, date(t, "2020-02-01"), created, terminated)
+	require.True(t, ok)
+	assert.Equal(t, "Ministry of Health", name, "Expected the first name to hold since the creation")
+	assert.Equal(t, created, startTime)
+	assert.Equal(t, "2022-01-01T00:00:00Z", endTime)
+
+	name, startTime, endTime, _ = nameAt(revisions, date(t, "2023-05-01"), created, terminated)
+	assert.Equal(t, "Ministry of Health and Indigenous Medicine", name)
+	assert.Equal(t, "2022-01-01T00:00:00Z", startTime)
+	assert.Equal(t, "2024-01-01T00:00:00Z", endTime)
+
+	name, _, endTime, _ = nameAt(revisions, date(t, "2024-01-01"), created, terminated)
+	assert.Equal(t, "Ministry of Health and Mass Media", name)
+	assert.Equal(t, terminated, endTime)
+
+	_, _, _, ok = nameAt(nil, date(t, "2024-01-01"), created, terminated)
+	assert.False(t, ok, "Expected no name without revisions")
+}
+
+func TestMetadataAt(t *testing.T) {
+	first := map[string]*anypb.Any{"minister": {TypeUrl: "a"}}
+	second := map[string]*anypb.Any{"minister": {TypeUrl: "b"}}
+	revisions := []*mongorepository.Revision{
+		{StartTime: date(t, "2020-01-01"), Metadata: first},
+		{StartTime: date(t, "2023-01-01"), Metadata: second},
+	}
+
+	assert.Equal(t, first, metadataAt(revisions, date(t, "2022-12-31")))
+	assert.Equal(t, second, metadataAt(revisions, date(t, "2023-01-01")))
+	assert.Nil(t, metadataAt(nil, date(t, "2023-01-01")))
+}
@@ -4,52 +4,36 @@
 package main
 
 import (
-	"fmt"
+	"context"
+
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
-	"log"
+	"lk/datafoundation/core-api/pkg/logging"
 )
 
-func debugMetadata(req *pb.Entity) {
-
-	log.Printf("Reading Entity metadata: %s", req.Id)
-	fmt.Println("DEBUG - Received request:")
-	fmt.Printf("DEBUG - Entity ID: %s\n", req.Id)
-	fmt.Printf("DEBUG - Kind: %+v\n", req.Kind)
-	fmt.Printf("DEBUG - Created: %s\n", req.Created)
-	fmt.Printf("DEBUG - Terminated: %s\n", req.Terminated)
-	fmt.Printf("DEBUG - Name: %+v\n", req.Name)
-
-	// Print all metadata entries
-	fmt.Println("DEBUG - Metadata entries count:", len(req.Metadata))
+// debugMetadata logs the fields and metadata keys of an entity
+func debugMetadata(ctx context.Context, req *pb.Entity) {
+	logger := logging.FromContext(ctx)
+	logger.Debug("Received entity", "entity_id", req.Id, "kind", req.Kind, "created", req.Created, "terminated", req.Terminated, logging.Payload("name", req.Name))
 	for key, value := range req.Metadata {
-		fmt.Printf("DEBUG - Metadata key: %s\n", key)
 		if value != nil {
-			fmt.Printf("DEBUG - Metadata value typeUrl: %s\n", value.TypeUrl)
-			fmt.Printf("DEBUG - Metadata value data length: %d\n", len(value.Value))
+			logger.Debug("Metadata entry", "key", key, "type_url", value.TypeUrl, "bytes", len(value.Value))
 		} else {
-			fmt.Println("DEBUG - Metadata value is nil")
+			logger.Debug("Metadata entry is nil", "key", key)
 		}
 	}
-
 }
 
-func debugUtils(req *pb.Entity) {
-
-	// Print attributes if present
-	fmt.Println("DEBUG - Attributes count:", len(req.Attributes))
+// debugUtils logs the attribute and relationship keys of an entity
+func debugUtils(ctx context.Context, req *pb.Entity) {
+	logger := logging.FromContext(ctx)
 	for key, valueList := range req.Attributes {
-		fmt.Printf("DEBUG - Attribute key: %s\n", key)
 		if valueList != nil {
-			fmt.Printf("DEBUG - Attribute values count: %d\n", len(valueList.Values))
+			logger.Debug("Attribute", "attribute", key, "values", len(valueList.Values))
 		}
 	}
-
-	// Print relationships if present
-	fmt.Println("DEBUG - Relationships count:", len(req.Relationships))
 	for key, rel := range req.Relationships {
-		fmt.Printf("DEBUG - Relationship key: %s\n", key)
 		if rel != nil {
-			fmt.Printf("DEBUG - Related entity ID: %s\n", rel.RelatedEntityId)
+			logger.Debug("Relationship", "relationship_id", key, "related_entity_id", rel.RelatedEntityId)
 		}
 	}
 }
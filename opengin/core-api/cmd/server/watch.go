@@ -0,0 +1,59 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/watch"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchEntities streams the changes of entities matching the filters of the request, starting after
+// afterSequence or with the next change. The stream ends when the client cancels it, when it asked to resume
+// from events that are no longer kept, or when the server shuts down.
+func (s *Server) WatchEntities(req *pb.WatchEntitiesRequest, stream pb.COREService_WatchEntitiesServer) error {
+	ctx := stream.Context()
+	filter := watch.NewFilter(req)
+
+	after := req.AfterSequence
+	if after == 0 {
+		after = s.events.Latest()
+	}
+	logging.FromContext(ctx).Debug("Watching entities", "after_sequence", after, "kinds", req.Kinds, "entity_ids", req.EntityIds, "relationship_names", req.RelationshipNames)
+
+	for {
+		events, err := s.events.Next(ctx, after)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			after = event.Sequence
+			if !filter.Match(event) {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// createdEvents returns the events of a created entity and its relationships
+func createdEvents(entity *pb.Entity) []*pb.EntityEvent {
+	events := []*pb.EntityEvent{watch.EntityEvent(watch.EntityCreated, entity)}
+	for _, relationship := range entity.Relationships {
+		events = append(events, watch.RelationshipEvent(watch.RelationshipCreated, entity, outgoing(relationship)))
+	}
+	return events
+}
+
+// outgoing returns a copy of a relationship of the request with its direction set
+func outgoing(relationship *pb.Relationship) *pb.Relationship {
+	relationship = proto.Clone(relationship).(*pb.Relationship)
+	relationship.Direction = "OUTGOING"
+	return relationship
+}
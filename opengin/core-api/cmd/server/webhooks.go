@@ -0,0 +1,70 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Number of dead-lettered deliveries listed by default and at most
+const (
+	defaultDeliveryLimit = 100
+	maxDeliveryLimit     = 1000
+)
+
+// ListFailedWebhookDeliveries lists the dead-lettered webhook deliveries, the most recent first
+func (s *Server) ListFailedWebhookDeliveries(ctx context.Context, req *pb.ListFailedWebhookDeliveriesRequest) (*pb.WebhookDeliveryList, error) {
+	if s.outbox == nil {
+		return nil, apperrors.FailedPreconditionf("webhooks are disabled")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	limit = min(limit, maxDeliveryLimit)
+
+	entries, err := s.outbox.ListFailed(ctx, req.Endpoint, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing failed webhook deliveries: %w", err)
+	}
+	response := &pb.WebhookDeliveryList{Deliveries: make([]*pb.WebhookDelivery, len(entries))}
+	for i, entry := range entries {
+		event := &pb.EntityEvent{}
+		if err := protojson.Unmarshal(entry.Event, event); err != nil {
+			return nil, fmt.Errorf("error reading event of webhook delivery %s: %w", entry.ID, err)
+		}
+		response.Deliveries[i] = &pb.WebhookDelivery{
+			Id:        entry.ID,
+			EventId:   entry.EventID,
+			Endpoint:  entry.Endpoint,
+			Event:     event,
+			Attempts:  int32(entry.Attempts),
+			LastError: entry.LastError,
+			CreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		}
+	}
+	return response, nil
+}
+
+// ReplayWebhookDeliveries hands dead-lettered webhook deliveries to the dispatcher again, with all their attempts
+func (s *Server) ReplayWebhookDeliveries(ctx context.Context, req *pb.ReplayWebhookDeliveriesRequest) (*pb.ReplayWebhookDeliveriesResponse, error) {
+	if s.outbox == nil {
+		return nil, apperrors.FailedPreconditionf("webhooks are disabled")
+	}
+	replayed, err := s.outbox.Replay(ctx, req.Ids, req.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error replaying webhook deliveries: %w", err)
+	}
+	logging.FromContext(ctx).Info("Replaying webhook deliveries", "ids", req.Ids, "endpoint", req.Endpoint, "replayed", replayed)
+	return &pb.ReplayWebhookDeliveriesResponse{Replayed: replayed}, nil
+}
@@ -8,7 +8,7 @@ import (
 	"fmt"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
 	"lk/datafoundation/core-api/pkg/storageinference"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -77,7 +77,7 @@ func ExtractAttributeMetadataFields(entity *pb.Entity) (storageTypeStr, storageP
 	updatedStr = ExtractStringFromAny(metadataMap["updated"])
 	schemaStr := ExtractStringFromAny(metadataMap["schema"])
 
-	log.Printf("[Commons.ExtractAttributeMetadataFields] storageTypeStr: %s, storagePath: %s, updatedStr: %s, schemaStr: %s", storageTypeStr, storagePath, updatedStr, schemaStr)
+	slog.Debug("Extracted attribute metadata fields", "storage_type", storageTypeStr, "storage_path", storagePath, "updated", updatedStr)
 
 	// Convert schema JSON string to map
 	schemaMap, err := ConvertJSONStringToMap(schemaStr)
@@ -152,7 +152,7 @@ func ParseTimestamp(timestampStr string, context string) time.Time {
 
 	parsed, err := time.Parse(time.RFC3339, timestampStr)
 	if err != nil {
-		log.Printf("[Commons.ParseTimestamp] Warning: Failed to parse timestamp '%s' for %s, using zero value", timestampStr, context)
+		slog.Warn("Failed to parse timestamp, using zero value", "timestamp", timestampStr, "field", context)
 		return time.Time{} // Return zero value for invalid timestamps
 	}
 
@@ -3,23 +3,38 @@
 
 package config
 
+import "time"
+
+// Zero values of the pool sizes and timeouts keep the defaults of the repositories
+
 type MongoConfig struct {
-	URI        string `env:"MONGO_URI"`
-	DBName     string `env:"MONGO_DB_NAME"`
-	Collection string `env:"MONGO_COLLECTION"`
+	URI                    string        `yaml:"uri" env:"MONGO_URI"`
+	DBName                 string        `yaml:"dbName" env:"MONGO_DB_NAME"`
+	Collection             string        `yaml:"collection" env:"MONGO_COLLECTION"`
+	MaxPoolSize            uint64        `yaml:"maxPoolSize" env:"MONGO_MAX_POOL_SIZE"`
+	MinPoolSize            uint64        `yaml:"minPoolSize" env:"MONGO_MIN_POOL_SIZE"`
+	ConnectTimeout         time.Duration `yaml:"connectTimeout" env:"MONGO_CONNECT_TIMEOUT"`
+	ServerSelectionTimeout time.Duration `yaml:"serverSelectionTimeout" env:"MONGO_SERVER_SELECTION_TIMEOUT"`
 }
 
 type Neo4jConfig struct {
-	URI      string `env:"NEO4J_URI"`
-	Username string `env:"NEO4J_USER"`
-	Password string `env:"NEO4J_PASSWORD"`
+	URI                          string        `yaml:"uri" env:"NEO4J_URI"`
+	Username                     string        `yaml:"username" env:"NEO4J_USER"`
+	Password                     string        `yaml:"password" env:"NEO4J_PASSWORD"`
+	MaxConnectionPoolSize        int           `yaml:"maxConnectionPoolSize" env:"NEO4J_MAX_CONNECTION_POOL_SIZE"`
+	ConnectionAcquisitionTimeout time.Duration `yaml:"connectionAcquisitionTimeout" env:"NEO4J_CONNECTION_ACQUISITION_TIMEOUT"`
+	ConnectTimeout               time.Duration `yaml:"connectTimeout" env:"NEO4J_CONNECT_TIMEOUT"`
 }
 
 type PostgresConfig struct {
-	Host     string `env:"POSTGRES_HOST"`
-	Port     string `env:"POSTGRES_PORT"`
-	User     string `env:"POSTGRES_USER"`
-	Password string `env:"POSTGRES_PASSWORD"`
-	DBName   string `env:"POSTGRES_DB"`
-	SSLMode  string `env:"POSTGRES_SSL_MODE"`
+	Host            string        `yaml:"host" env:"POSTGRES_HOST"`
+	Port            string        `yaml:"port" env:"POSTGRES_PORT"`
+	User            string        `yaml:"user" env:"POSTGRES_USER"`
+	Password        string        `yaml:"password" env:"POSTGRES_PASSWORD"`
+	DBName          string        `yaml:"dbName" env:"POSTGRES_DB"`
+	SSLMode         string        `yaml:"sslMode" env:"POSTGRES_SSL_MODE"`
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"POSTGRES_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"POSTGRES_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"POSTGRES_CONN_MAX_LIFETIME"`
+	ConnectTimeout  time.Duration `yaml:"connectTimeout" env:"POSTGRES_CONNECT_TIMEOUT"`
 }
@@ -0,0 +1,109 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/audit"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditCollection is the collection of the audit log, next to the entities
+const AuditCollection = "audit_log"
+
+// AuditStore keeps the audit log in MongoDB. It only inserts and finds records; deny the other
+// operations on the collection to the database user of the server to make the log tamper-proof.
+type AuditStore struct {
+	collection *mongo.Collection
+}
+
+// auditDocument is the document of an audit.Record
+type auditDocument struct {
+	ID         string                 `bson:"_id"`
+	Time       time.Time              `bson:"time"`
+	Actor      string                 `bson:"actor,omitempty"`
+	AuthMethod string                 `bson:"authMethod,omitempty"`
+	Method     string                 `bson:"method"`
+	RequestID  string                 `bson:"requestId,omitempty"`
+	EntityID   string                 `bson:"entityId"`
+	Changes    []audit.Change         `bson:"changes,omitempty"`
+	Attributes []audit.AttributeWrite `bson:"attributes,omitempty"`
+}
+
+// AuditStore returns the store of the audit log, creating the indexes of its queries
+func (repo *MongoRepository) AuditStore(ctx context.Context) (*AuditStore, error) {
+	collection := repo.client.Database(repo.config.DBName).Collection(AuditCollection)
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "entityId", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "actor", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "time", Value: -1}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating indexes of %s: %w", AuditCollection, err)
+	}
+	return &AuditStore{collection: collection}, nil
+}
+
+// Append inserts the record
+func (s *AuditStore) Append(ctx context.Context, record *audit.Record) error {
+	ctx, span := tracing.Start(ctx, "AuditStore.Append", tracing.EntityID(record.EntityID))
+	defer span.End()
+
+	_, err := s.collection.InsertOne(ctx, auditDocument(*record))
+	return err
+}
+
+// Find returns the records matching the query, the most recent first. Record ids are ordered
+// by time, so a page continues with the ids below the last one of the previous page.
+func (s *AuditStore) Find(ctx context.Context, query audit.Query) ([]*audit.Record, error) {
+	ctx, span := tracing.Start(ctx, "AuditStore.Find", tracing.EntityID(query.EntityID))
+	defer span.End()
+
+	filter := bson.M{}
+	if query.EntityID != "" {
+		filter["entityId"] = query.EntityID
+	}
+	if query.Actor != "" {
+		filter["actor"] = query.Actor
+	}
+	if !query.From.IsZero() || !query.To.IsZero() {
+		between := bson.M{}
+		if !query.From.IsZero() {
+			between["$gte"] = query.From
+		}
+		if !query.To.IsZero() {
+			between["$lt"] = query.To
+		}
+		filter["time"] = between
+	}
+	if query.After != "" {
+		filter["_id"] = bson.M{"$lt": query.After}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	if query.Limit > 0 {
+		opts.SetLimit(int64(query.Limit))
+	}
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []auditDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	records := make([]*audit.Record, len(docs))
+	for i := range docs {
+		record := audit.Record(docs[i])
+		records[i] = &record
+	}
+	return records, nil
+}
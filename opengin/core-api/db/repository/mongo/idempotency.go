@@ -0,0 +1,123 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/idempotency"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IdempotencyCollection is the collection of the idempotency records, next to the entities
+const IdempotencyCollection = "idempotency_keys"
+
+// IdempotencyStore keeps the idempotency records in MongoDB. MongoDB deletes them once they expire.
+type IdempotencyStore struct {
+	collection *mongo.Collection
+}
+
+// idempotencyDocument is the document of an idempotency.Record
+type idempotencyDocument struct {
+	Key         string    `bson:"_id"`
+	Fingerprint string    `bson:"fingerprint"`
+	Response    []byte    `bson:"response,omitempty"`
+	Done        bool      `bson:"done"`
+	LockedUntil time.Time `bson:"lockedUntil"`
+	ExpiresAt   time.Time `bson:"expiresAt"`
+}
+
+// IdempotencyStore returns the store of the idempotency records, creating the index that expires them
+func (repo *MongoRepository) IdempotencyStore(ctx context.Context) (*IdempotencyStore, error) {
+	collection := repo.client.Database(repo.config.DBName).Collection(IdempotencyCollection)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating expiry index of %s: %w", IdempotencyCollection, err)
+	}
+	return &IdempotencyStore{collection: collection}, nil
+}
+
+// Begin inserts the record, or returns the record holding its key
+func (s *IdempotencyStore) Begin(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyStore.Begin")
+	defer span.End()
+
+	doc := idempotencyDocument{
+		Key:         record.Key,
+		Fingerprint: record.Fingerprint,
+		LockedUntil: record.LockedUntil,
+		ExpiresAt:   record.ExpiresAt,
+	}
+	_, err := s.collection.InsertOne(ctx, doc)
+	if err == nil {
+		return nil, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, err
+	}
+
+	// Take the key over if its record expired but was not deleted yet,
+	// or if the same call held it and stopped before finishing
+	now := time.Now()
+	filter := bson.M{
+		"_id": record.Key,
+		"$or": bson.A{
+			bson.M{"expiresAt": bson.M{"$lte": now}},
+			bson.M{"done": false, "fingerprint": record.Fingerprint, "lockedUntil": bson.M{"$lte": now}},
+		},
+	}
+	result, err := s.collection.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 1 {
+		return nil, nil
+	}
+
+	var existing idempotencyDocument
+	err = s.collection.FindOne(ctx, bson.M{"_id": record.Key}).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Deleted since the insert, the retry of the caller will find the key free
+		return nil, fmt.Errorf("idempotency key %s was released concurrently", record.Key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &idempotency.Record{
+		Key:         existing.Key,
+		Fingerprint: existing.Fingerprint,
+		Response:    existing.Response,
+		Done:        existing.Done,
+		LockedUntil: existing.LockedUntil,
+		ExpiresAt:   existing.ExpiresAt,
+	}, nil
+}
+
+// Complete stores the response of the call holding the key
+func (s *IdempotencyStore) Complete(ctx context.Context, key string, response []byte) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyStore.Complete")
+	defer span.End()
+
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{"done": true, "response": response}})
+	return err
+}
+
+// Abandon deletes the record of a call that failed
+func (s *IdempotencyStore) Abandon(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyStore.Abandon")
+	defer span.End()
+
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": key, "done": false})
+	return err
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/metrics"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultMaxPoolSize is the pool size of the driver when none is configured
+const defaultMaxPoolSize = 100
+
+// poolCounters follows the connection pool through the pool events of the driver
+type poolCounters struct {
+	open  atomic.Int64
+	inUse atomic.Int64
+}
+
+// commandMonitor records every command sent to MongoDB in the metrics and as a span,
+// named after the command, e.g. "find"
+func commandMonitor() *event.CommandMonitor {
+	var spans sync.Map // Request id to the span of the command
+	finish := func(requestID int64, name string, duration time.Duration, err error) {
+		metrics.ObserveStore(metrics.MongoDB, name, time.Now().Add(-duration), err)
+		if span, ok := spans.LoadAndDelete(requestID); ok {
+			tracing.End(span.(trace.Span), err)
+		}
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			_, span := tracing.StartClient(ctx, metrics.MongoDB, e.CommandName)
+			spans.Store(e.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, e.CommandName, e.Duration, nil)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.CommandName, e.Duration, errors.New(e.Failure))
+		},
+	}
+}
+
+// poolMonitor keeps the counters up to date
+func (c *poolCounters) poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				c.open.Add(1)
+			case event.ConnectionClosed:
+				c.open.Add(-1)
+			case event.GetSucceeded:
+				c.inUse.Add(1)
+			case event.ConnectionReturned:
+				c.inUse.Add(-1)
+			}
+		},
+	}
+}
+
+// PoolStats reports the connection pool of the client
+func (repo *MongoRepository) PoolStats() metrics.PoolStats {
+	maxConnections := defaultMaxPoolSize
+	if repo.config != nil && repo.config.MaxPoolSize > 0 {
+		maxConnections = int(repo.config.MaxPoolSize)
+	}
+	inUse := int(repo.pool.inUse.Load())
+	return metrics.PoolStats{InUse: inUse, Idle: max(int(repo.pool.open.Load())-inUse, 0), Max: maxConnections}
+}
@@ -5,9 +5,10 @@ package mongorepository
 
 import (
 	"context"
-	"log"
 
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
 
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -17,6 +18,9 @@ import (
 
 // Add this function to handle metadata operations
 func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string, entity *pb.Entity) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.HandleMetadata", tracing.EntityID(entityId))
+	defer span.End()
+
 	// Skip operations if no metadata is provided
 	if entity == nil || entity.GetMetadata() == nil || len(entity.GetMetadata()) == 0 {
 		return nil
@@ -39,6 +43,7 @@ func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string
 			Name:          entity.Name,
 			Attributes:    entity.Attributes,
 			Relationships: entity.Relationships,
+			Version:       entity.Version,
 		}
 		_, err = repo.CreateEntity(ctx, newEntity)
 	} else {
@@ -53,11 +58,14 @@ func (repo *MongoRepository) HandleMetadata(ctx context.Context, entityId string
 
 // Improved GetMetadata function that handles conversion internally
 func (repo *MongoRepository) GetMetadata(ctx context.Context, entityId string) (map[string]*anypb.Any, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.GetMetadata", tracing.EntityID(entityId))
+	defer span.End()
+
 	// Use the existing ReadEntity method for consistency
 	entity, err := repo.ReadEntity(ctx, entityId)
 	if err != nil {
 		// Log error and return empty metadata map
-		log.Printf("Error retrieving metadata for entity %s: %v", entityId, err)
+		logging.FromContext(ctx).Error("Error retrieving metadata for entity", "entity_id", entityId, "error", err)
 		metadata := make(map[string]*anypb.Any)
 		return metadata, nil
 	}
@@ -5,20 +5,25 @@ package mongorepository
 
 import (
 	"context"
+	"errors"
 	"lk/datafoundation/core-api/db/config"
 	"log"
 
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 type MongoRepository struct {
 	client *mongo.Client
 	config *config.MongoConfig
+	pool   *poolCounters
 }
 
 // A custom wrapper struct for Entity to use MongoDB's _id field
@@ -31,15 +36,20 @@ type entityDocument struct {
 	Name          *pb.TimeBasedValue                `bson:"name,omitempty"`
 	Attributes    map[string]*pb.TimeBasedValueList `bson:"attributes,omitempty"`
 	Relationships map[string]*pb.Relationship       `bson:"relationships,omitempty"`
+	Version       int64                             `bson:"version,omitempty"` // Mirrors the version of the entity node in Neo4j
 }
 
 // Convert protobuf Entity to MongoDB document
 func toDocument(entity *pb.Entity) interface{} {
-	return bson.M{
+	doc := bson.M{
 		"_id":      entity.Id,
 		"metadata": entity.Metadata,
 		// Map other entity fields as needed
 	}
+	if entity.Version > 0 {
+		doc["version"] = entity.Version
+	}
+	return doc
 }
 
 // Convert MongoDB document to protobuf Entity
@@ -53,6 +63,7 @@ func fromDocument(data *entityDocument) *pb.Entity {
 		Name:          data.Name,
 		Attributes:    data.Attributes,
 		Relationships: data.Relationships,
+		Version:       data.Version,
 	}
 }
 
@@ -60,6 +71,21 @@ func fromDocument(data *entityDocument) *pb.Entity {
 // TODO: Handle errors better
 func NewMongoRepository(ctx context.Context, config *config.MongoConfig) *MongoRepository {
 	clientOptions := options.Client().ApplyURI(config.URI)
+	if config.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(config.MaxPoolSize)
+	}
+	if config.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(config.MinPoolSize)
+	}
+	if config.ConnectTimeout > 0 {
+		clientOptions.SetConnectTimeout(config.ConnectTimeout)
+	}
+	if config.ServerSelectionTimeout > 0 {
+		clientOptions.SetServerSelectionTimeout(config.ServerSelectionTimeout)
+	}
+	pool := &poolCounters{}
+	clientOptions.SetMonitor(commandMonitor())
+	clientOptions.SetPoolMonitor(pool.poolMonitor())
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		log.Fatal(err)
@@ -67,9 +93,24 @@ func NewMongoRepository(ctx context.Context, config *config.MongoConfig) *MongoR
 	return &MongoRepository{
 		client: client,
 		config: config,
+		pool:   pool,
 	}
 }
 
+// Close disconnects the MongoDB client, waiting for operations in progress
+func (repo *MongoRepository) Close(ctx context.Context) error {
+	if err := repo.client.Disconnect(ctx); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("MongoDB connection closed")
+	return nil
+}
+
+// Ping checks that the MongoDB primary can be reached
+func (repo *MongoRepository) Ping(ctx context.Context) error {
+	return repo.client.Ping(ctx, readpref.Primary())
+}
+
 func (repo *MongoRepository) collection() *mongo.Collection {
 	return repo.client.Database(repo.config.DBName).Collection(repo.config.Collection)
 }
@@ -77,14 +118,54 @@ func (repo *MongoRepository) collection() *mongo.Collection {
 // CreateEntity inserts a new entity in MongoDB
 // FIXME: https://github.com/LDFLK/nexoan/issues/118
 func (repo *MongoRepository) CreateEntity(ctx context.Context, entity *pb.Entity) (*mongo.InsertOneResult, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.CreateEntity", tracing.EntityID(entity.GetId()))
+	defer span.End()
+
 	// Use the entity.Id as MongoDB's _id field
 	doc := toDocument(entity)
 	result, err := repo.collection().InsertOne(ctx, doc)
 	return result, err
 }
 
+// CreateEntities inserts many entities in MongoDB with a single unordered insert,
+// so one failing document does not stop the others. It returns the error of every entity
+// that was not inserted, a duplicate id can be detected with mongo.IsDuplicateKeyError.
+func (repo *MongoRepository) CreateEntities(ctx context.Context, entities []*pb.Entity) (map[string]error, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.CreateEntities")
+	defer span.End()
+
+	failures := make(map[string]error)
+	if len(entities) == 0 {
+		return failures, nil
+	}
+
+	docs := make([]interface{}, len(entities))
+	for i, entity := range entities {
+		docs[i] = toDocument(entity)
+	}
+
+	_, err := repo.collection().InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return failures, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return nil, err
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Index >= 0 && writeErr.Index < len(entities) {
+			failures[entities[writeErr.Index].Id] = mongo.WriteException{WriteErrors: mongo.WriteErrors{writeErr.WriteError}}
+		}
+	}
+	return failures, nil
+}
+
 // ReadEntity fetches an entity by ID from MongoDB
 func (repo *MongoRepository) ReadEntity(ctx context.Context, id string) (*pb.Entity, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.ReadEntity", tracing.EntityID(id))
+	defer span.End()
+
 	var doc entityDocument
 	err := repo.collection().FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
 	if err != nil {
@@ -95,13 +176,48 @@ func (repo *MongoRepository) ReadEntity(ctx context.Context, id string) (*pb.Ent
 
 // UpdateEntity updates an entity's attributes in MongoDB
 func (repo *MongoRepository) UpdateEntity(ctx context.Context, id string, updates bson.M) (*mongo.UpdateResult, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.UpdateEntity", tracing.EntityID(id))
+	defer span.End()
+
 	update := bson.M{"$set": updates}
 	result, err := repo.collection().UpdateOne(ctx, bson.M{"_id": id}, update)
 	return result, err
 }
 
+// SetEntityVersion sets the version on the metadata document of an entity, creating the document if the
+// entity has none yet. It reports whether it created the document.
+func (repo *MongoRepository) SetEntityVersion(ctx context.Context, id string, version int64) (bool, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.SetEntityVersion", tracing.EntityID(id))
+	defer span.End()
+
+	result, err := repo.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"version": version}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedID != nil, nil
+}
+
+// RestoreEntityVersion sets the version of the metadata document of an entity back to previous, unless
+// another update advanced it beyond version since. With created the document SetEntityVersion created
+// is removed instead.
+func (repo *MongoRepository) RestoreEntityVersion(ctx context.Context, id string, version, previous int64, created bool) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.RestoreEntityVersion", tracing.EntityID(id))
+	defer span.End()
+
+	filter := bson.M{"_id": id, "version": version}
+	if created {
+		_, err := repo.collection().DeleteOne(ctx, filter)
+		return err
+	}
+	_, err := repo.collection().UpdateOne(ctx, filter, bson.M{"$set": bson.M{"version": previous}})
+	return err
+}
+
 // DeleteEntity removes an entity from MongoDB
 func (repo *MongoRepository) DeleteEntity(ctx context.Context, id string) (*mongo.DeleteResult, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.DeleteEntity", tracing.EntityID(id))
+	defer span.End()
+
 	result, err := repo.collection().DeleteOne(ctx, bson.M{"_id": id})
 	return result, err
 }
@@ -220,6 +220,39 @@ func TestDeleteEntity(t *testing.T) {
 // 3. Validates that metadata values maintain their type information through storage/retrieval
 // 4. Ensures that type-specific unwrapping (UnmarshalTo) works correctly after retrieval
 // 5. Verifies the integrity of data values after a complete create-read cycle
+func TestSetEntityVersion(t *testing.T) {
+	entityID := "test-entity-version"
+	defer testRepo.DeleteEntity(testCtx, entityID)
+
+	// An entity without a metadata document gets one
+	created, err := testRepo.SetEntityVersion(testCtx, entityID, 2)
+	assert.NoError(t, err)
+	assert.True(t, created, "Expected the document to be created")
+	entity, err := testRepo.ReadEntity(testCtx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), entity.Version)
+
+	created, err = testRepo.SetEntityVersion(testCtx, entityID, 3)
+	assert.NoError(t, err)
+	assert.False(t, created, "Expected the existing document to be updated")
+
+	// A version advanced by another update is not restored
+	assert.NoError(t, testRepo.RestoreEntityVersion(testCtx, entityID, 4, 3, false))
+	entity, err = testRepo.ReadEntity(testCtx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), entity.Version)
+
+	assert.NoError(t, testRepo.RestoreEntityVersion(testCtx, entityID, 3, 2, false))
+	entity, err = testRepo.ReadEntity(testCtx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), entity.Version)
+
+	// Restoring the version that created the document removes it
+	assert.NoError(t, testRepo.RestoreEntityVersion(testCtx, entityID, 2, 1, true))
+	_, err = testRepo.ReadEntity(testCtx, entityID)
+	assert.Error(t, err, "Expected the created document to be removed")
+}
+
 func TestMetadataHandling(t *testing.T) {
 	// Log database and collection information
 	log.Printf("Test using database: %s, collection: %s", testRepo.GetDBName(), testRepo.GetCollectionName())
@@ -0,0 +1,200 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/outbox"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxCollection is the collection of the webhook deliveries, next to the entities
+const OutboxCollection = "outbox"
+
+// OutboxStore keeps the outbox in MongoDB. MongoDB deletes the delivered entries once they expire.
+type OutboxStore struct {
+	collection *mongo.Collection
+	retention  time.Duration
+}
+
+// outboxDocument is the document of an outbox.Entry
+type outboxDocument struct {
+	ID          string    `bson:"_id"`
+	EventID     string    `bson:"eventId"`
+	Endpoint    string    `bson:"endpoint"`
+	EventType   string    `bson:"eventType"`
+	Event       []byte    `bson:"event"`
+	Status      string    `bson:"status"`
+	Attempts    int       `bson:"attempts"`
+	NextAttempt time.Time `bson:"nextAttempt"`
+	LastError   string    `bson:"lastError,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt"`
+	DeliveredAt time.Time `bson:"deliveredAt,omitempty"`
+	ExpiresAt   time.Time `bson:"expiresAt,omitempty"` // Set once delivered
+}
+
+// OutboxStore returns the store of the outbox, creating the indexes that find due entries
+// and expire the entries delivered longer than retention ago
+func (repo *MongoRepository) OutboxStore(ctx context.Context, retention time.Duration) (*OutboxStore, error) {
+	collection := repo.client.Database(repo.config.DBName).Collection(OutboxCollection)
+	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttempt", Value: 1}}},
+		{Keys: bson.D{{Key: "eventId", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating indexes of %s: %w", OutboxCollection, err)
+	}
+	return &OutboxStore{collection: collection, retention: retention}, nil
+}
+
+// Add inserts the entries
+func (s *OutboxStore) Add(ctx context.Context, entries []*outbox.Entry) error {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Add")
+	defer span.End()
+
+	docs := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		docs[i] = outboxDocument{
+			ID:          entry.ID,
+			EventID:     entry.EventID,
+			Endpoint:    entry.Endpoint,
+			EventType:   entry.EventType,
+			Event:       entry.Event,
+			Status:      entry.Status,
+			Attempts:    entry.Attempts,
+			NextAttempt: entry.NextAttempt,
+			CreatedAt:   entry.CreatedAt,
+		}
+	}
+	_, err := s.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// Release makes the held entries of the events pending, due right away
+func (s *OutboxStore) Release(ctx context.Context, eventIDs []string) error {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Release")
+	defer span.End()
+
+	_, err := s.collection.UpdateMany(ctx,
+		bson.M{"eventId": bson.M{"$in": eventIDs}, "status": outbox.StatusHeld},
+		bson.M{"$set": bson.M{"status": outbox.StatusPending, "nextAttempt": time.Now().UTC()}})
+	return err
+}
+
+// Discard deletes the entries of the events that were not delivered yet
+func (s *OutboxStore) Discard(ctx context.Context, eventIDs []string) error {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Discard")
+	defer span.End()
+
+	_, err := s.collection.DeleteMany(ctx, bson.M{"eventId": bson.M{"$in": eventIDs}, "status": bson.M{"$ne": outbox.StatusDelivered}})
+	return err
+}
+
+// Claim postpones the pending entry that has been due the longest by the lease and returns it
+func (s *OutboxStore) Claim(ctx context.Context, now time.Time, lease time.Duration) (*outbox.Entry, error) {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Claim")
+	defer span.End()
+
+	var doc outboxDocument
+	err := s.collection.FindOneAndUpdate(ctx,
+		bson.M{"status": outbox.StatusPending, "nextAttempt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"nextAttempt": now.Add(lease)}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "nextAttempt", Value: 1}}),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.entry(), nil
+}
+
+// Save stores the outcome of an attempt
+func (s *OutboxStore) Save(ctx context.Context, entry *outbox.Entry) error {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Save")
+	defer span.End()
+
+	set := bson.M{
+		"status":      entry.Status,
+		"attempts":    entry.Attempts,
+		"nextAttempt": entry.NextAttempt,
+		"lastError":   entry.LastError,
+	}
+	if entry.Status == outbox.StatusDelivered {
+		set["deliveredAt"] = entry.DeliveredAt
+		set["expiresAt"] = entry.DeliveredAt.Add(s.retention)
+	}
+	_, err := s.collection.UpdateOne(ctx, bson.M{"_id": entry.ID}, bson.M{"$set": set})
+	return err
+}
+
+// ListFailed returns the dead-lettered entries, the most recent first
+func (s *OutboxStore) ListFailed(ctx context.Context, endpoint string, limit int) ([]*outbox.Entry, error) {
+	ctx, span := tracing.Start(ctx, "OutboxStore.ListFailed")
+	defer span.End()
+
+	filter := bson.M{"status": outbox.StatusFailed}
+	if endpoint != "" {
+		filter["endpoint"] = endpoint
+	}
+	cursor, err := s.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var docs []outboxDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	entries := make([]*outbox.Entry, len(docs))
+	for i := range docs {
+		entries[i] = docs[i].entry()
+	}
+	return entries, nil
+}
+
+// Replay makes dead-lettered entries pending again with their attempts reset
+func (s *OutboxStore) Replay(ctx context.Context, ids []string, endpoint string) (int64, error) {
+	ctx, span := tracing.Start(ctx, "OutboxStore.Replay")
+	defer span.End()
+
+	filter := bson.M{"status": outbox.StatusFailed}
+	if len(ids) > 0 {
+		filter["_id"] = bson.M{"$in": ids}
+	}
+	if endpoint != "" {
+		filter["endpoint"] = endpoint
+	}
+	result, err := s.collection.UpdateMany(ctx, filter,
+		bson.M{"$set": bson.M{"status": outbox.StatusPending, "attempts": 0, "nextAttempt": time.Now().UTC()}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (doc *outboxDocument) entry() *outbox.Entry {
+	return &outbox.Entry{
+		ID:          doc.ID,
+		EventID:     doc.EventID,
+		Endpoint:    doc.Endpoint,
+		EventType:   doc.EventType,
+		Event:       doc.Event,
+		Status:      doc.Status,
+		Attempts:    doc.Attempts,
+		NextAttempt: doc.NextAttempt,
+		LastError:   doc.LastError,
+		CreatedAt:   doc.CreatedAt,
+		DeliveredAt: doc.DeliveredAt,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RelationshipVersionsCollection is the collection of the versions of relationships an update replaced
+const RelationshipVersionsCollection = "relationship_versions"
+
+// RelationshipVersion is what a relationship held before an update replaced it. The version was
+// recorded when the previous version of the relationship was superseded, or with the relationship
+// if it is its first.
+type RelationshipVersion struct {
+	ID             string                `bson:"_id"`
+	RelationshipID string                `bson:"relationshipId"`
+	EntityID       string                `bson:"entityId"` // The entity whose update replaced the version
+	StartTime      string                `bson:"startTime"`
+	EndTime        string                `bson:"endTime,omitempty"`
+	Properties     map[string]*anypb.Any `bson:"properties,omitempty"`
+	Superseded     time.Time             `bson:"superseded"`
+}
+
+func (repo *MongoRepository) relationshipVersions() *mongo.Collection {
+	return repo.client.Database(repo.config.DBName).Collection(RelationshipVersionsCollection)
+}
+
+// CreateRelationshipVersionIndexes creates the index of the relationship version reads
+func (repo *MongoRepository) CreateRelationshipVersionIndexes(ctx context.Context) error {
+	_, err := repo.relationshipVersions().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "relationshipId", Value: 1}, {Key: "superseded", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating index of %s: %w", RelationshipVersionsCollection, err)
+	}
+	return nil
+}
+
+// AddRelationshipVersion stores a replaced version, giving it an id and the time it was superseded
+func (repo *MongoRepository) AddRelationshipVersion(ctx context.Context, version *RelationshipVersion) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.AddRelationshipVersion", tracing.EntityID(version.EntityID), tracing.RelationshipID(version.RelationshipID))
+	defer span.End()
+
+	version.ID = uuid.Must(uuid.NewV7()).String()
+	version.Superseded = time.Now().UTC()
+	_, err := repo.relationshipVersions().InsertOne(ctx, version)
+	return err
+}
+
+// ReadRelationshipVersions returns the replaced versions of the relationships by relationship id, oldest
+// first. Relationships that were never updated are left out.
+func (repo *MongoRepository) ReadRelationshipVersions(ctx context.Context, relationshipIDs []string) (map[string][]*RelationshipVersion, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.ReadRelationshipVersions")
+	defer span.End()
+
+	opts := options.Find().SetSort(bson.D{{Key: "superseded", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := repo.relationshipVersions().Find(ctx, bson.M{"relationshipId": bson.M{"$in": relationshipIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var versions []*RelationshipVersion
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	byRelationship := make(map[string][]*RelationshipVersion)
+	for _, version := range versions {
+		byRelationship[version.RelationshipID] = append(byRelationship[version.RelationshipID], version)
+	}
+	return byRelationship, nil
+}
+
+// DeleteRelationshipVersion removes a version
+func (repo *MongoRepository) DeleteRelationshipVersion(ctx context.Context, id string) error {
+	_, err := repo.relationshipVersions().DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// DeleteRelationshipVersions removes every version of the relationships
+func (repo *MongoRepository) DeleteRelationshipVersions(ctx context.Context, relationshipIDs []string) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.DeleteRelationshipVersions")
+	defer span.End()
+
+	_, err := repo.relationshipVersions().DeleteMany(ctx, bson.M{"relationshipId": bson.M{"$in": relationshipIDs}})
+	return err
+}
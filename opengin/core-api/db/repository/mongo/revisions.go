@@ -0,0 +1,111 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package mongorepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RevisionsCollection is the collection of the names and metadata entities had over time, next to the entities
+const RevisionsCollection = "entity_revisions"
+
+// Fields of an entity that revisions are kept of
+const (
+	NameRevision     = "name"
+	MetadataRevision = "metadata"
+)
+
+// Revision is a value the name or the metadata of an entity took. It holds from its start time
+// until the start time of the next revision of the same field.
+type Revision struct {
+	ID        string                `bson:"_id"`
+	EntityID  string                `bson:"entityId"`
+	Field     string                `bson:"field"` // NameRevision or MetadataRevision
+	StartTime time.Time             `bson:"startTime"`
+	Name      string                `bson:"name,omitempty"`
+	Metadata  map[string]*anypb.Any `bson:"metadata,omitempty"`
+	Recorded  time.Time             `bson:"recorded"` // When the revision was written, orders revisions with the same start time
+}
+
+func (repo *MongoRepository) revisions() *mongo.Collection {
+	return repo.client.Database(repo.config.DBName).Collection(RevisionsCollection)
+}
+
+// CreateRevisionIndexes creates the index of the revision reads
+func (repo *MongoRepository) CreateRevisionIndexes(ctx context.Context) error {
+	_, err := repo.revisions().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "entityId", Value: 1}, {Key: "field", Value: 1}, {Key: "startTime", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating index of %s: %w", RevisionsCollection, err)
+	}
+	return nil
+}
+
+// AddRevision stores a revision, giving it an id and the time it was recorded
+func (repo *MongoRepository) AddRevision(ctx context.Context, revision *Revision) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.AddRevision", tracing.EntityID(revision.EntityID))
+	defer span.End()
+
+	revision.ID = uuid.Must(uuid.NewV7()).String()
+	revision.Recorded = time.Now().UTC()
+	_, err := repo.revisions().InsertOne(ctx, revision)
+	return err
+}
+
+// HasRevisions tells whether revisions of the field of the entity were stored
+func (repo *MongoRepository) HasRevisions(ctx context.Context, entityID, field string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.HasRevisions", tracing.EntityID(entityID))
+	defer span.End()
+
+	count, err := repo.revisions().CountDocuments(ctx, bson.M{"entityId": entityID, "field": field}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// ReadRevisions returns the revisions of the field of the entities by entity id, ordered by start time.
+// Entities without revisions are left out.
+func (repo *MongoRepository) ReadRevisions(ctx context.Context, entityIDs []string, field string) (map[string][]*Revision, error) {
+	ctx, span := tracing.Start(ctx, "MongoRepository.ReadRevisions")
+	defer span.End()
+
+	opts := options.Find().SetSort(bson.D{{Key: "startTime", Value: 1}, {Key: "recorded", Value: 1}})
+	cursor, err := repo.revisions().Find(ctx, bson.M{"entityId": bson.M{"$in": entityIDs}, "field": field}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []*Revision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	byEntity := make(map[string][]*Revision)
+	for _, revision := range revisions {
+		byEntity[revision.EntityID] = append(byEntity[revision.EntityID], revision)
+	}
+	return byEntity, nil
+}
+
+// DeleteRevision removes a revision
+func (repo *MongoRepository) DeleteRevision(ctx context.Context, id string) error {
+	_, err := repo.revisions().DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// DeleteRevisions removes every revision of the entity
+func (repo *MongoRepository) DeleteRevisions(ctx context.Context, entityID string) error {
+	ctx, span := tracing.Start(ctx, "MongoRepository.DeleteRevisions", tracing.EntityID(entityID))
+	defer span.End()
+
+	_, err := repo.revisions().DeleteMany(ctx, bson.M{"entityId": entityID})
+	return err
+}
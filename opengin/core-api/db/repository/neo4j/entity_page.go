@@ -0,0 +1,173 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+)
+
+// Number of entities FilterEntitiesPage returns by default and at most, larger page sizes are reduced to MaxPageSize
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// Sort keys supported by FilterEntitiesPage
+const (
+	OrderByID         = ""
+	OrderByName       = "name"
+	OrderByCreated    = "created"
+	OrderByTerminated = "terminated"
+)
+
+// EntityPageRequest describes which page of the filtered entities to read.
+// Pages use keyset pagination: a page starts right after the cursor of the previous page,
+// so deep pages are as cheap as the first one and concurrent inserts do not shift them.
+type EntityPageRequest struct {
+	PageSize          int           // 0 returns DefaultPageSize entities
+	OrderBy           string        // One of the OrderBy constants, entities with the same key are ordered by id
+	Descending        bool          // Entities without a termination come last in both directions
+	After             *EntityCursor // Cursor of the previous page, nil for the first page
+	IncludeTotalCount bool
+}
+
+// EntityPage is a page of filtered entities
+type EntityPage struct {
+	Entities   []map[string]interface{}
+	Next       *EntityCursor // nil on the last page
+	TotalCount int64         // Only set when IncludeTotalCount was requested
+}
+
+// EntityCursor is the position of the last entity of a page
+type EntityCursor struct {
+	OrderBy    string `json:"o,omitempty"`
+	Descending bool   `json:"d,omitempty"`
+	Value      string `json:"v,omitempty"` // Sort key of the entity
+	Null       bool   `json:"n,omitempty"` // The sort key is not set, only possible for terminated
+	ID         string `json:"i"`
+}
+
+// ParseOrderBy parses an order like "created desc" into a sort key and direction
+func ParseOrderBy(orderBy string) (string, bool, error) {
+	fields := strings.Fields(strings.ToLower(orderBy))
+	if len(fields) == 0 {
+		return OrderByID, false, nil
+	}
+	if len(fields) > 2 {
+		return "", false, apperrors.InvalidArgumentf("invalid orderBy %q, expected a single sort key", orderBy).WithField("orderBy")
+	}
+
+	key := fields[0]
+	switch key {
+	case "id":
+		key = OrderByID
+	case OrderByName, OrderByCreated, OrderByTerminated:
+	default:
+		return "", false, apperrors.InvalidArgumentf("invalid orderBy %q, supported sort keys are name, created and terminated", orderBy).WithField("orderBy")
+	}
+
+	descending := false
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "asc":
+		case "desc":
+			descending = true
+		default:
+			return "", false, apperrors.InvalidArgumentf("invalid orderBy %q, direction must be asc or desc", orderBy).WithField("orderBy")
+		}
+	}
+
+	return key, descending, nil
+}
+
+// EncodeEntityCursor turns a cursor into an opaque page token
+func EncodeEntityCursor(cursor *EntityCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeEntityCursor reads a page token created by EncodeEntityCursor.
+// An empty token is the first page and returns a nil cursor.
+func DecodeEntityCursor(token string) (*EntityCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, apperrors.InvalidArgumentf("invalid page token").WithField("pageToken")
+	}
+	var cursor EntityCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, apperrors.InvalidArgumentf("invalid page token").WithField("pageToken")
+	}
+	return &cursor, nil
+}
+
+// orderClause returns the ORDER BY clause and the keyset predicate that skips everything up to the cursor
+func (p *EntityPageRequest) orderClause(params map[string]interface{}) (string, string) {
+	direction, compare := "ASC", ">"
+	if p.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	var orderBy, after string
+	switch p.OrderBy {
+	case OrderByName:
+		orderBy = `ORDER BY e.Name ` + direction + `, e.Id ` + direction
+		after = `(e.Name ` + compare + ` $afterValue OR (e.Name = $afterValue AND e.Id ` + compare + ` $afterId))`
+	case OrderByCreated:
+		orderBy = `ORDER BY e.Created ` + direction + `, e.Id ` + direction
+		after = `(e.Created ` + compare + ` datetime($afterValue) OR (e.Created = datetime($afterValue) AND e.Id ` + compare + ` $afterId))`
+	case OrderByTerminated:
+		// Sorting on the null check first keeps entities that are still active at the end
+		orderBy = `ORDER BY e.Terminated IS NULL, e.Terminated ` + direction + `, e.Id ` + direction
+		if p.After != nil && p.After.Null {
+			after = `(e.Terminated IS NULL AND e.Id ` + compare + ` $afterId)`
+		} else {
+			after = `(e.Terminated IS NULL OR e.Terminated ` + compare + ` datetime($afterValue) OR (e.Terminated = datetime($afterValue) AND e.Id ` + compare + ` $afterId))`
+		}
+	default:
+		orderBy = `ORDER BY e.Id ` + direction
+		after = `e.Id ` + compare + ` $afterId`
+	}
+
+	if p.After == nil {
+		return orderBy, ""
+	}
+	params["afterId"] = p.After.ID
+	if p.OrderBy != OrderByID && !p.After.Null {
+		params["afterValue"] = p.After.Value
+	}
+	return orderBy, after
+}
+
+// cursorOf returns the cursor pointing at an entity returned by FilterEntitiesPage
+func (p *EntityPageRequest) cursorOf(entity map[string]interface{}) *EntityCursor {
+	cursor := &EntityCursor{OrderBy: p.OrderBy, Descending: p.Descending, ID: fmt.Sprintf("%v", entity["id"])}
+	var value interface{}
+	switch p.OrderBy {
+	case OrderByName:
+		value = entity["name"]
+	case OrderByCreated:
+		value = entity["created"]
+	case OrderByTerminated:
+		value = entity["terminated"]
+	default:
+		return cursor
+	}
+	if value == nil {
+		cursor.Null = true
+	} else {
+		cursor.Value = fmt.Sprintf("%v", value)
+	}
+	return cursor
+}
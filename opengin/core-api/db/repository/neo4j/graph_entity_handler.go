@@ -6,9 +6,13 @@ package neo4jrepository
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"maps"
 
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api" // Replace with your actual protobuf package
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
 
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -16,11 +20,21 @@ import (
 
 // GetEntityDetailsFromNeo4j retrieves entity information from Neo4j database
 func (repo *Neo4jRepository) GetGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, error) {
+	kind, name, created, terminated, _, err := repo.GetVersionedGraphEntity(ctx, entityId)
+	return kind, name, created, terminated, err
+}
+
+// GetVersionedGraphEntity is GetGraphEntity that also returns the version of the entity
+func (repo *Neo4jRepository) GetVersionedGraphEntity(ctx context.Context, entityId string) (*pb.Kind, *pb.TimeBasedValue, string, string, int64, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.GetGraphEntity", tracing.EntityID(entityId))
+	defer span.End()
+
 	// Try to get additional entity information from Neo4j
 	var kind *pb.Kind
 	var name *pb.TimeBasedValue
 	var created string
 	var terminated string
+	var version int64
 
 	// Attempt to read from Neo4j
 	entityMap, err := repo.ReadGraphEntity(ctx, entityId)
@@ -64,22 +78,27 @@ func (repo *Neo4jRepository) GetGraphEntity(ctx context.Context, entityId string
 		if termValue, ok := entityMap["Terminated"]; ok {
 			terminated = termValue.(string)
 		}
+
+		version, _ = entityMap["Version"].(int64)
 	} else {
-		log.Printf("[neo4j_handler.GetGraphEntity] Error reading entity %s: %v", entityId, err)
-		return nil, nil, "", "", fmt.Errorf("[neo4j_handler.GetGraphEntity] error reading entity: %v", err)
+		logging.FromContext(ctx).Error("Error reading entity", "entity_id", entityId, "error", err)
+		return nil, nil, "", "", 0, fmt.Errorf("[neo4j_handler.GetGraphEntity] error reading entity: %w", err)
 	}
 
-	return kind, name, created, terminated, err
+	return kind, name, created, terminated, version, err
 }
 
 // GetGraphRelationships retrieves relationships for an entity from Neo4j
 func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId string) (map[string]*pb.Relationship, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.GetGraphRelationships", tracing.EntityID(entityId))
+	defer span.End()
+
 	relationships := make(map[string]*pb.Relationship)
 	// Retrieve relationships from Neo4j
 	relData, err := repo.ReadRelationships(ctx, entityId)
 	if err != nil {
-		log.Printf("[neo4j_handler.GetGraphRelationships] Error reading relationships for entity %s: %v", entityId, err)
-		return relationships, fmt.Errorf("[neo4j_handler.GetGraphRelationships] error reading relationships: %v", err)
+		logging.FromContext(ctx).Error("Error reading relationships for entity", "entity_id", entityId, "error", err)
+		return relationships, fmt.Errorf("[neo4j_handler.GetGraphRelationships] error reading relationships: %w", err)
 	}
 
 	// Process each relationship
@@ -109,6 +128,9 @@ func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId
 		if terminated, ok := rel["Terminated"].(string); ok && terminated != "" {
 			relationship.EndTime = terminated
 		}
+		if properties, ok := rel["Properties"].(map[string]*anypb.Any); ok && len(properties) > 0 {
+			relationship.Properties = properties
+		}
 
 		// Store in map with unique key
 		relationships[relID] = relationship
@@ -117,11 +139,15 @@ func (repo *Neo4jRepository) GetGraphRelationships(ctx context.Context, entityId
 	return relationships, nil
 }
 
-// GetRelationshipsByName retrieves relationships for an entity by various filters
-func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entityId string, relationshipId string, relationship string, relatedEntityId string, startTime string, endTime string, direction string, activeAt string) (map[string]*pb.Relationship, error) {
+// GetRelationshipsByName retrieves relationships for an entity by various filters.
+// A relationship matches the properties filter when it has every property with the same value.
+func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entityId string, relationshipId string, relationship string, relatedEntityId string, startTime string, endTime string, direction string, activeAt string, properties map[string]*anypb.Any) (map[string]*pb.Relationship, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.GetFilteredRelationships", tracing.EntityID(entityId))
+	defer span.End()
+
 	// Validate input parameters
 	if entityId == "" {
-		return nil, fmt.Errorf("entityId cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entityId cannot be empty").WithField("id")
 	}
 
 	// Build filters map for ReadFilteredRelationships
@@ -144,11 +170,18 @@ func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entit
 	if direction != "" {
 		filters["direction"] = direction
 	}
+	if len(properties) > 0 {
+		values, err := graphProperties(properties)
+		if err != nil {
+			return nil, err
+		}
+		filters["properties"] = values
+	}
 
 	relationshipData, err := repo.ReadFilteredRelationships(ctx, entityId, filters, activeAt)
 
 	if err != nil {
-		log.Printf("[GetEntityIdsByRelationship] Error fetching related relationships for entity %s with filters %v: %v", entityId, filters, err)
+		logging.FromContext(ctx).Error("Error fetching related relationships for entity with filters", "entity_id", entityId, logging.Payload("filters", filters), "error", err)
 		return nil, err
 	}
 
@@ -164,8 +197,8 @@ func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entit
 
 		// Ensure required fields are present
 		if !relIDOk || !relatedEntityIdOk || !startTimeOk || !nameOk || !directionOk {
-			log.Printf("[GetEntityIdsByRelationship] Missing required fields in relationship: %v", rel)
-			return nil, fmt.Errorf("relationship missing required fields: %v", rel)
+			logging.FromContext(ctx).Debug("Missing required fields in relationship", logging.Payload("relationship", rel))
+			return nil, apperrors.InvalidArgumentf("relationship missing required fields: %v", rel).WithEntity(entityId)
 		}
 
 		// Create a pb.Relationship object
@@ -177,6 +210,9 @@ func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entit
 			Name:            name,
 			Direction:       direction,
 		}
+		if properties, ok := rel["properties"].(map[string]*anypb.Any); ok && len(properties) > 0 {
+			relationships[relID].Properties = properties
+		}
 	}
 
 	return relationships, nil
@@ -186,19 +222,19 @@ func (repo *Neo4jRepository) GetFilteredRelationships(ctx context.Context, entit
 func validateGraphEntityCreation(entity *pb.Entity) bool {
 	// Check if Kind is present and has a Major value
 	if entity.Kind == nil || entity.Kind.GetMajor() == "" || entity.Kind.GetMinor() == "" {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Kind.Major", entity.Id)
+		slog.Debug("Skipping Neo4j entity creation for: Missing or empty Kind.Major", "entity_id", entity.Id)
 		return false
 	}
 
 	// Check if Name is present and has a Value
 	if entity.Name == nil || entity.Name.GetValue() == nil {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing or empty Name.Value", entity.Id)
+		slog.Debug("Skipping Neo4j entity creation for: Missing or empty Name.Value", "entity_id", entity.Id)
 		return false
 	}
 
 	// Check if Created date is present
 	if entity.Created == "" {
-		log.Printf("[neo4j_handler.validateGraphEntityCreation] Skipping Neo4j entity creation for %s: Missing Created date", entity.Id)
+		slog.Debug("Skipping Neo4j entity creation for: Missing Created date", "entity_id", entity.Id)
 		return false
 	}
 
@@ -207,14 +243,35 @@ func validateGraphEntityCreation(entity *pb.Entity) bool {
 
 // HandleGraphEntityCreation creates a new entity in Neo4j
 func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, entity *pb.Entity) (bool, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphEntityCreation", tracing.EntityID(entity.GetId()))
+	defer span.End()
+
 	// Validate required fields for Neo4j entity creation
 	if !validateGraphEntityCreation(entity) {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Neo4j entity creation failed for entity: %s", entity.Id)
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing required fields for Neo4j entity creation")
+		logging.FromContext(ctx).Debug("Neo4j entity creation failed for entity", "entity_id", entity.Id)
+		return false, apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntityCreation] missing required fields for Neo4j entity creation").WithEntity(entity.Id)
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphEntityCreation] Creating new entity in Neo4j: %s", entity.Id)
+	logging.FromContext(ctx).Debug("Creating new entity in Neo4j", "entity_id", entity.Id)
+
+	kind, entityMap, err := graphEntityMap(entity)
+	if err != nil {
+		return false, err
+	}
+
+	// Create the entity
+	result, err := repo.CreateGraphEntity(ctx, kind, entityMap)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error creating entity in Neo4j", "error", err)
+		return false, err
+	} else {
+		logging.FromContext(ctx).Debug("Created entity in Neo4j", "entity_id", entity.Id)
+		return result != nil, nil // Success if we got a non-nil result
+	}
+}
 
+// graphEntityMap extracts the kind and the node properties of an entity for creating it in Neo4j
+func graphEntityMap(entity *pb.Entity) (*pb.Kind, map[string]interface{}, error) {
 	// Prepare data for Neo4j with safety checks
 	entityMap := map[string]interface{}{
 		"Id": entity.Id,
@@ -222,7 +279,7 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 
 	// Validate and extract the Kind field
 	if entity.Kind == nil || entity.Kind.GetMajor() == "" || entity.Kind.GetMinor() == "" {
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] missing or invalid Kind.Major or Kind.Minor for entity %s", entity.Id)
+		return nil, nil, apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntityCreation] missing or invalid Kind.Major or Kind.Minor for entity %s", entity.Id).WithField("kind").WithEntity(entity.Id)
 	}
 
 	kind := &pb.Kind{
@@ -243,17 +300,17 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 					// The first byte is the length, followed by the actual string
 					if len(rawValue) > 1 {
 						entityMap["Name"] = string(rawValue[1:])
-						log.Printf("Using raw value from Any: %s\n", string(rawValue[1:]))
+						slog.Debug("Using raw value of Any")
 					}
 				}
 			} else {
-				fmt.Printf("Error unpacking Name value for entity %s: %v\n", entity.Id, err)
-				return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] error unpacking Name value: %v", err)
+				slog.Error("Error unpacking Name value for entity", "entity_id", entity.Id, "error", err)
+				return nil, nil, fmt.Errorf("[neo4j_handler.HandleGraphEntityCreation] error unpacking Name value: %w", err)
 			}
 		} else {
 			// Successfully unpacked to StringValue
 			entityMap["Name"] = stringValue.Value
-			log.Printf("Using unpacked StringValue: %s\n", stringValue.Value)
+			slog.Debug("Using unpacked StringValue")
 		}
 	}
 
@@ -266,32 +323,27 @@ func (repo *Neo4jRepository) HandleGraphEntityCreation(ctx context.Context, enti
 		entityMap["Terminated"] = entity.Terminated
 	}
 
-	// Create the entity
-	result, err := repo.CreateGraphEntity(ctx, kind, entityMap)
-	if err != nil {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Error creating entity in Neo4j: %v", err)
-		return false, err
-	} else {
-		log.Printf("[neo4j_handler.HandleGraphEntityCreation] Successfully created entity in Neo4j: %s", entity.Id)
-		return result != nil, nil // Success if we got a non-nil result
-	}
+	return kind, entityMap, nil
 }
 
 // HandleGraphEntityUpdate updates an existing entity in Neo4j
 func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity *pb.Entity) (bool, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphEntityUpdate", tracing.EntityID(entity.GetId()))
+	defer span.End()
+
 	// Validate required fields for Neo4j entity update
 	if entity.Id == "" {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Entity ID is required for Neo4j entity update")
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] entity ID is required")
+		logging.FromContext(ctx).Debug("Entity ID is required for Neo4j entity update")
+		return false, apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntityUpdate] entity ID is required").WithField("id")
 	}
 
 	// Check if user is trying to update Kind (not allowed)
 	if entity.Kind != nil && (entity.Kind.Major != "" || entity.Kind.Minor != "") {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Cannot update Kind for entity %s", entity.Id)
-		return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] Kind cannot be updated")
+		logging.FromContext(ctx).Debug("Cannot update Kind for entity", "entity_id", entity.Id)
+		return false, apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntityUpdate] Kind cannot be updated").WithField("kind").WithEntity(entity.Id)
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Updating existing entity in Neo4j: %s", entity.Id)
+	logging.FromContext(ctx).Debug("Updating existing entity in Neo4j", "entity_id", entity.Id)
 
 	// Prepare data for Neo4j with safety checks
 	entityMap := map[string]interface{}{
@@ -304,8 +356,8 @@ func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity
 		var stringValue wrapperspb.StringValue
 		err := entity.Name.GetValue().UnmarshalTo(&stringValue)
 		if err != nil {
-			log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Error unpacking Name value for entity %s: %v", entity.Id, err)
-			return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] error unpacking Name value: %v", err)
+			logging.FromContext(ctx).Error("Error unpacking Name value for entity", "entity_id", entity.Id, "error", err)
+			return false, fmt.Errorf("[neo4j_handler.HandleGraphEntityUpdate] error unpacking Name value: %w", err)
 		}
 		// Get the actual string value from the StringValue and check it's not empty
 		if stringValue.Value != "" {
@@ -324,197 +376,439 @@ func (repo *Neo4jRepository) HandleGraphEntityUpdate(ctx context.Context, entity
 
 	// Update the entity
 	result, err := repo.UpdateGraphEntity(ctx, entity.Id, entityMap)
-	log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Entity map for update: %+v", entityMap)
+	logging.FromContext(ctx).Debug("Updating graph entity", logging.Payload("entity", entityMap))
 	if err != nil {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Error updating entity in Neo4j: %v", err)
+		logging.FromContext(ctx).Error("Error updating entity in Neo4j", "error", err)
 		return false, err
 	} else {
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Successfully updated entity in Neo4j: %s", entity.Id)
-		log.Printf("[neo4j_handler.HandleGraphEntityUpdate] Update result: %+v", result)
+		logging.FromContext(ctx).Debug("Updated entity in Neo4j", "entity_id", entity.Id)
+		logging.FromContext(ctx).Debug("Updated graph entity", logging.Payload("result", result))
 		return result != nil, nil // Success if we got a non-nil result
 	}
 }
 
 // HandleGraphRelationshipsCreate handles creating new relationships
 func (repo *Neo4jRepository) HandleGraphRelationshipsCreate(ctx context.Context, entity *pb.Entity) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphRelationshipsCreate", tracing.EntityID(entity.GetId()))
+	defer span.End()
+
 	if len(entity.Relationships) == 0 {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] No relationships to process for entity: %s", entity.Id)
+		logging.FromContext(ctx).Debug("No relationships to process for entity", "entity_id", entity.Id)
 		return nil
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
+	logging.FromContext(ctx).Debug("Processing relationships for entity", "relationships", len(entity.Relationships), "entity_id", entity.Id)
 
 	// First verify the parent entity exists
 	parentEntity, err := repo.ReadGraphEntity(ctx, entity.Id)
 	if err != nil || parentEntity == nil {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Parent entity %s does not exist in Neo4j", entity.Id)
-		return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] parent entity %s does not exist", entity.Id)
+		logging.FromContext(ctx).Debug("Parent entity does not exist in Neo4j", "entity_id", entity.Id)
+		return apperrors.NotFoundf("[neo4j_handler.HandleGraphRelationshipsCreate] parent entity %s does not exist", entity.Id).WithEntity(entity.Id)
 	}
 
 	// Process all child entities
 	for _, relationship := range entity.Relationships {
-		if relationship == nil || relationship.Id == "" {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Relationship missing ID field")
-			return fmt.Errorf("relationship missing ID field")
+		if err := repo.HandleGraphRelationshipCreate(ctx, entity.Id, relationship); err != nil {
+			return err
 		}
+	}
 
-		// Validate required fields for creation
-		if relationship.RelatedEntityId == "" {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing RelatedEntityId for relationship creation")
-			return fmt.Errorf("missing RelatedEntityId for relationship %s. Required for creation", relationship.Id)
-		}
-		if relationship.Name == "" {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing Name for relationship creation")
-			return fmt.Errorf("missing Name for relationship %s. Required for creation", relationship.Id)
-		}
-		if relationship.StartTime == "" {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing StartTime for relationship creation")
-			return fmt.Errorf("missing StartTime for relationship %s. Required for creation", relationship.Id)
-		}
+	return nil
+}
 
-		// Check if the child entity exists
-		childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
-		if err != nil || childEntityMap == nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s does not exist in Neo4j. Make sure to create it first.",
-				relationship.RelatedEntityId)
-			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] child entity %s does not exist", relationship.RelatedEntityId)
-		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Child entity %s exists in Neo4j", relationship.RelatedEntityId)
+// validateGraphRelationshipCreation checks that a relationship has the fields required to create it
+func validateGraphRelationshipCreation(relationship *pb.Relationship) error {
+	if relationship == nil || relationship.Id == "" {
+		slog.Debug("Relationship missing ID field")
+		return apperrors.InvalidArgumentf("relationship missing ID field").WithField("relationships.id")
+	}
+	if relationship.RelatedEntityId == "" {
+		slog.Debug("Missing RelatedEntityId for relationship creation")
+		return apperrors.InvalidArgumentf("missing RelatedEntityId for relationship %s. Required for creation", relationship.Id).WithRelationship(relationship.Id).WithField("relationships.relatedEntityId")
+	}
+	if relationship.Name == "" {
+		slog.Debug("Missing Name for relationship creation")
+		return apperrors.InvalidArgumentf("missing Name for relationship %s. Required for creation", relationship.Id).WithRelationship(relationship.Id).WithField("relationships.name")
+	}
+	if relationship.StartTime == "" {
+		slog.Debug("Missing StartTime for relationship creation")
+		return apperrors.InvalidArgumentf("missing StartTime for relationship %s. Required for creation", relationship.Id).WithRelationship(relationship.Id).WithField("relationships.startTime")
+	}
+	if _, err := graphProperties(relationship.Properties); err != nil {
+		return err
+	}
+	return nil
+}
 
-		// Create the relationship
-		_, err = repo.CreateRelationship(ctx, entity.Id, relationship)
-		if err != nil {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Error creating relationship from %s to %s: %v",
-				entity.Id, relationship.RelatedEntityId, err)
-			return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] error creating relationship: %v", err)
-		}
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsCreate] Successfully created relationship from %s to %s",
-			entity.Id, relationship.RelatedEntityId)
+// HandleGraphRelationshipCreate creates a single relationship from an existing entity
+func (repo *Neo4jRepository) HandleGraphRelationshipCreate(ctx context.Context, entityID string, relationship *pb.Relationship) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphRelationshipCreate", tracing.EntityID(entityID), tracing.RelationshipID(relationship.GetId()))
+	defer span.End()
+
+	if err := validateGraphRelationshipCreation(relationship); err != nil {
+		return err
+	}
+
+	// Check if the child entity exists
+	childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
+	if err != nil || childEntityMap == nil {
+		logging.FromContext(ctx).Debug("Child entity does not exist in Neo4j. Make sure to create it first", "related_entity_id", relationship.RelatedEntityId)
+		return apperrors.FailedPreconditionf("[neo4j_handler.HandleGraphRelationshipsCreate] child entity %s does not exist", relationship.RelatedEntityId).WithEntity(entityID).WithRelationship(relationship.Id)
+	}
+	logging.FromContext(ctx).Debug("Child entity exists in Neo4j", "related_entity_id", relationship.RelatedEntityId)
+
+	// Create the relationship
+	_, err = repo.CreateRelationship(ctx, entityID, relationship)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error creating relationship", "entity_id", entityID, "related_entity_id", relationship.RelatedEntityId, "error", err)
+		return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsCreate] error creating relationship: %w", err)
 	}
+	logging.FromContext(ctx).Debug("Created relationship", "entity_id", entityID, "related_entity_id", relationship.RelatedEntityId)
 
 	return nil
 }
 
 // HandleGraphRelationshipsUpdate handles updating existing relationships
 func (repo *Neo4jRepository) HandleGraphRelationshipsUpdate(ctx context.Context, entity *pb.Entity) error {
-	log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Received entity: %+v", entity)
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphRelationshipsUpdate", tracing.EntityID(entity.GetId()))
+	defer span.End()
+
+	logging.FromContext(ctx).Debug("Received entity", "entity_id", entity.Id, logging.Payload("entity", entity))
 
 	if len(entity.Relationships) == 0 {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] No relationships to process for entity: %s", entity.Id)
+		logging.FromContext(ctx).Debug("No relationships to process for entity", "entity_id", entity.Id)
 		return nil
 	}
 
-	log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Processing %d relationships for entity: %s", len(entity.Relationships), entity.Id)
+	logging.FromContext(ctx).Debug("Processing relationships for entity", "relationships", len(entity.Relationships), "entity_id", entity.Id)
 
 	// First verify the parent entity exists
 	parentEntity, err := repo.ReadGraphEntity(ctx, entity.Id)
 	if err != nil || parentEntity == nil {
-		log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Parent entity %s does not exist in Neo4j", entity.Id)
-		return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] parent entity %s does not exist", entity.Id)
+		logging.FromContext(ctx).Debug("Parent entity does not exist in Neo4j", "entity_id", entity.Id)
+		return apperrors.NotFoundf("[neo4j_handler.HandleGraphRelationshipsUpdate] parent entity %s does not exist", entity.Id).WithEntity(entity.Id)
 	}
 
 	for _, relationship := range entity.Relationships {
-		if relationship == nil || relationship.Id == "" {
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Relationship missing ID field")
-			return fmt.Errorf("relationship missing ID field")
+		if _, err := repo.HandleGraphRelationshipUpdate(ctx, entity.Id, relationship); err != nil {
+			return err
 		}
+	}
 
-		// Check if the relationship exists
-		existingRel, err := repo.ReadRelationship(ctx, relationship.Id)
-		relationshipExists := (err == nil && existingRel != nil)
+	return nil
+}
 
-		if relationshipExists {
-			// RELATIONSHIP EXISTS - UPDATE IT
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Relationship %s exists, updating...", relationship.Id)
+// HandleGraphRelationshipUpdate updates a single relationship of an existing entity or creates it if it does not exist.
+// It returns the relationship as it was before the update, or nil when the relationship was created.
+func (repo *Neo4jRepository) HandleGraphRelationshipUpdate(ctx context.Context, entityID string, relationship *pb.Relationship) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphRelationshipUpdate", tracing.EntityID(entityID), tracing.RelationshipID(relationship.GetId()))
+	defer span.End()
 
-			// Validate: only StartTime and EndTime are allowed for updates
-			if relationship.Name != "" || relationship.RelatedEntityId != "" || relationship.Direction != "" {
-				invalidFields := []string{}
-				if relationship.Name != "" {
-					invalidFields = append(invalidFields, "Name")
-				}
-				if relationship.RelatedEntityId != "" {
-					invalidFields = append(invalidFields, "RelatedEntityId")
-				}
-				if relationship.Direction != "" {
-					invalidFields = append(invalidFields, "Direction")
-				}
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Cannot update immutable fields: %v", invalidFields)
-				return fmt.Errorf("cannot update immutable fields: %v. Only StartTime and EndTime are allowed", invalidFields)
-			}
+	if relationship == nil || relationship.Id == "" {
+		logging.FromContext(ctx).Debug("Relationship missing ID field")
+		return nil, apperrors.InvalidArgumentf("relationship missing ID field").WithEntity(entityID).WithField("relationships.id")
+	}
+
+	// Check if the relationship exists
+	existingRel, err := repo.ReadRelationship(ctx, relationship.Id)
+	relationshipExists := (err == nil && existingRel != nil)
+
+	if relationshipExists {
+		// RELATIONSHIP EXISTS - UPDATE IT
+		logging.FromContext(ctx).Debug("Relationship exists, updating", "relationship_id", relationship.Id)
 
-			// Build update data with valid fields only
-			relationshipData := map[string]interface{}{}
-			if relationship.StartTime != "" {
-				relationshipData["Created"] = relationship.StartTime
+		// Validate: only StartTime and EndTime are allowed for updates
+		if relationship.Name != "" || relationship.RelatedEntityId != "" || relationship.Direction != "" {
+			invalidFields := []string{}
+			if relationship.Name != "" {
+				invalidFields = append(invalidFields, "Name")
 			}
-			if relationship.EndTime != "" {
-				relationshipData["Terminated"] = relationship.EndTime
+			if relationship.RelatedEntityId != "" {
+				invalidFields = append(invalidFields, "RelatedEntityId")
+			}
+			if relationship.Direction != "" {
+				invalidFields = append(invalidFields, "Direction")
 			}
+			logging.FromContext(ctx).Debug("Cannot update immutable fields", "invalid_fields", invalidFields)
+			return nil, apperrors.InvalidArgumentf("cannot update immutable fields: %v. Only StartTime, EndTime and Properties are allowed", invalidFields).WithEntity(entityID).WithRelationship(relationship.Id)
+		}
 
-			// Check if we have any valid fields to update
-			if len(relationshipData) == 0 {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] No valid fields provided for update")
-				return fmt.Errorf("no valid fields provided for relationship update. Only StartTime and EndTime are allowed")
+		// Build update data with valid fields only
+		relationshipData := map[string]interface{}{}
+		if relationship.StartTime != "" {
+			relationshipData["Created"] = relationship.StartTime
+		}
+		if relationship.EndTime != "" {
+			relationshipData["Terminated"] = relationship.EndTime
+		}
+		// The properties given are added to the stored ones, replacing those with the same name
+		if len(relationship.Properties) > 0 {
+			properties := make(map[string]*anypb.Any)
+			if existing, ok := existingRel["Properties"].(map[string]*anypb.Any); ok {
+				maps.Copy(properties, existing)
 			}
+			maps.Copy(properties, relationship.Properties)
+			relationshipData["Properties"] = properties
+		}
 
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Updating relationship with data: %+v", relationshipData)
+		// Check if we have any valid fields to update
+		if len(relationshipData) == 0 {
+			logging.FromContext(ctx).Debug("No valid fields provided for update")
+			return nil, apperrors.InvalidArgumentf("no valid fields provided for relationship update. Only StartTime, EndTime and Properties are allowed").WithEntity(entityID).WithRelationship(relationship.Id)
+		}
 
-			// Update the relationship
-			_, err = repo.UpdateRelationship(ctx, relationship.Id, relationshipData)
-			if err != nil {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Failed to update relationship: %v", err)
-				return err
-			}
+		logging.FromContext(ctx).Debug("Updating relationship with data", logging.Payload("relationship", relationshipData))
+
+		// Update the relationship
+		_, err = repo.UpdateRelationship(ctx, relationship.Id, relationshipData)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to update relationship", "error", err)
+			return nil, err
+		}
 
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully updated relationship %s", relationship.Id)
+		logging.FromContext(ctx).Debug("Updated relationship", "relationship_id", relationship.Id)
+		return existingRel, nil
+
+	} else {
+		// RELATIONSHIP DOESN'T EXIST - CREATE IT
+		logging.FromContext(ctx).Debug("Relationship doesn't exist, creating", "relationship_id", relationship.Id)
+
+		// Validate required fields for creation
+		if relationship.RelatedEntityId == "" {
+			logging.FromContext(ctx).Debug("Missing RelatedEntityId for relationship creation")
+			return nil, apperrors.InvalidArgumentf("missing RelatedEntityId for relationship %s. Required for creation", relationship.Id).WithEntity(entityID).WithRelationship(relationship.Id).WithField("relationships.relatedEntityId")
+		}
+		if relationship.Name == "" {
+			logging.FromContext(ctx).Debug("Missing Name for relationship creation")
+			return nil, apperrors.InvalidArgumentf("missing Name for relationship %s. Required for creation", relationship.Id).WithEntity(entityID).WithRelationship(relationship.Id).WithField("relationships.name")
+		}
+		if relationship.StartTime == "" {
+			logging.FromContext(ctx).Debug("Missing StartTime for relationship creation")
+			return nil, apperrors.InvalidArgumentf("missing StartTime for relationship %s. Required for creation", relationship.Id).WithEntity(entityID).WithRelationship(relationship.Id).WithField("relationships.startTime")
+		}
+
+		// Check if the child entity exists
+		childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
+		if err != nil || childEntityMap == nil {
+			logging.FromContext(ctx).Debug("Child entity does not exist in Neo4j", "related_entity_id", relationship.RelatedEntityId)
+			return nil, apperrors.FailedPreconditionf("[neo4j_handler.HandleGraphRelationshipsUpdate] child entity %s does not exist", relationship.RelatedEntityId).WithEntity(entityID).WithRelationship(relationship.Id)
+		}
+
+		// Create the relationship
+		_, err = repo.CreateRelationship(ctx, entityID, relationship)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to create relationship", "error", err)
+			return nil, fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] failed to create relationship: %w", err)
+		}
+
+		logging.FromContext(ctx).Debug("Created relationship", "relationship_id", relationship.Id)
+		return nil, nil
+	}
+}
+
+// HandleGraphEntitiesCreation creates many new entities in Neo4j in a single transaction.
+// It returns the reason for every entity that was not created, entities missing from the result were created.
+func (repo *Neo4jRepository) HandleGraphEntitiesCreation(ctx context.Context, entities []*pb.Entity) map[string]error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphEntitiesCreation")
+	defer span.End()
+
+	failures := make(map[string]error)
+
+	var ids []string
+	rows := make(map[string]map[string]interface{})
+	kinds := make(map[string]string)
+	for _, entity := range entities {
+		// Validate required fields for Neo4j entity creation
+		if !validateGraphEntityCreation(entity) {
+			failures[entity.Id] = apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntitiesCreation] missing required fields for Neo4j entity creation").WithEntity(entity.Id)
+			continue
+		}
+		kind, entityMap, err := graphEntityMap(entity)
+		if err != nil {
+			failures[entity.Id] = err
+			continue
+		}
+		if _, ok := entityMap["Name"].(string); !ok {
+			failures[entity.Id] = apperrors.InvalidArgumentf("[neo4j_handler.HandleGraphEntitiesCreation] missing or invalid 'Name' field").WithField("name").WithEntity(entity.Id)
 			continue
+		}
+		entityMap["MinorKind"] = kind.Minor
 
-		} else {
-			// RELATIONSHIP DOESN'T EXIST - CREATE IT
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Relationship %s doesn't exist, creating...", relationship.Id)
+		ids = append(ids, entity.Id)
+		rows[entity.Id] = entityMap
+		kinds[entity.Id] = kind.Major
+	}
 
-			// Validate required fields for creation
-			if relationship.RelatedEntityId == "" {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing RelatedEntityId for relationship creation")
-				return fmt.Errorf("missing RelatedEntityId for relationship %s. Required for creation", relationship.Id)
-			}
-			if relationship.Name == "" {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing Name for relationship creation")
-				return fmt.Errorf("missing Name for relationship %s. Required for creation", relationship.Id)
+	existing, err := repo.ExistingEntityIds(ctx, ids)
+	if err != nil {
+		for _, id := range ids {
+			failures[id] = err
+		}
+		return failures
+	}
+
+	nodes := make(map[string][]map[string]interface{})
+	var created []string
+	for _, id := range ids {
+		if existing[id] {
+			logging.FromContext(ctx).Debug("Entity with Id already exists", "entity_id", id)
+			failures[id] = apperrors.AlreadyExistsf("[neo4j_handler.HandleGraphEntitiesCreation] entity with Id %s already exists", id).WithEntity(id)
+			continue
+		}
+		nodes[kinds[id]] = append(nodes[kinds[id]], rows[id])
+		created = append(created, id)
+	}
+	if len(created) == 0 {
+		return failures
+	}
+
+	if err := repo.CreateGraphEntities(ctx, nodes); err != nil {
+		for _, id := range created {
+			failures[id] = err
+		}
+		return failures
+	}
+
+	logging.FromContext(ctx).Debug("Created entities in Neo4j", "count", len(created))
+	return failures
+}
+
+// HandleGraphRelationshipsBatchCreate creates the relationships of many existing entities in a single transaction.
+// If that transaction fails, the relationships are created again in one transaction per entity, so that only the
+// entities at fault fail. Either all relationships of an entity are created or none of them. A relationship may point at another entity
+// of the batch, in which case it is only created if that entity does not fail itself.
+// It returns the reason for every entity whose relationships were not created.
+func (repo *Neo4jRepository) HandleGraphRelationshipsBatchCreate(ctx context.Context, entities []*pb.Entity) map[string]error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphRelationshipsBatchCreate")
+	defer span.End()
+
+	failures := make(map[string]error)
+
+	var entityIDs, relationshipIDs []string
+	relationshipOwners := make(map[string]string)
+	for _, entity := range entities {
+		entityIDs = append(entityIDs, entity.Id)
+		for _, relationship := range entity.Relationships {
+			if err := validateGraphRelationshipCreation(relationship); err != nil {
+				failures[entity.Id] = err
+				break
 			}
-			if relationship.StartTime == "" {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Missing StartTime for relationship creation")
-				return fmt.Errorf("missing StartTime for relationship %s. Required for creation", relationship.Id)
+			if owner, ok := relationshipOwners[relationship.Id]; ok {
+				failures[entity.Id] = apperrors.InvalidArgumentf("relationship with Id %s is also used by entity %s", relationship.Id, owner).WithEntity(entity.Id).WithRelationship(relationship.Id)
+				break
 			}
+			relationshipOwners[relationship.Id] = entity.Id
+			relationshipIDs = append(relationshipIDs, relationship.Id)
+			entityIDs = append(entityIDs, relationship.RelatedEntityId)
+		}
+	}
 
-			// Check if the child entity exists
-			childEntityMap, err := repo.ReadGraphEntity(ctx, relationship.RelatedEntityId)
-			if err != nil || childEntityMap == nil {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Child entity %s does not exist in Neo4j",
-					relationship.RelatedEntityId)
-				return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] child entity %s does not exist", relationship.RelatedEntityId)
+	existingEntities, err := repo.ExistingEntityIds(ctx, entityIDs)
+	if err != nil {
+		return failAll(entities, err)
+	}
+	existingRelationships, err := repo.ExistingRelationshipIds(ctx, relationshipIDs)
+	if err != nil {
+		return failAll(entities, err)
+	}
+
+	for _, entity := range entities {
+		if failures[entity.Id] != nil {
+			continue
+		}
+		if !existingEntities[entity.Id] {
+			failures[entity.Id] = apperrors.NotFoundf("[neo4j_handler.HandleGraphRelationshipsBatchCreate] parent entity %s does not exist", entity.Id).WithEntity(entity.Id)
+			continue
+		}
+		for _, relationship := range entity.Relationships {
+			if existingRelationships[relationship.Id] {
+				failures[entity.Id] = apperrors.AlreadyExistsf("relationship with Id %s already exists", relationship.Id).WithEntity(entity.Id).WithRelationship(relationship.Id)
+				break
 			}
+		}
+	}
 
-			// Create the relationship
-			_, err = repo.CreateRelationship(ctx, entity.Id, relationship)
-			if err != nil {
-				log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Failed to create relationship: %v", err)
-				return fmt.Errorf("[neo4j_handler.HandleGraphRelationshipsUpdate] failed to create relationship: %v", err)
+	// An entity failing can make the relationships of others pointing at it fail, repeat until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, entity := range entities {
+			if failures[entity.Id] != nil {
+				continue
+			}
+			for _, relationship := range entity.Relationships {
+				childID := relationship.RelatedEntityId
+				if !existingEntities[childID] || failures[childID] != nil {
+					logging.FromContext(ctx).Debug("Child entity does not exist in Neo4j", "child_id", childID)
+					failures[entity.Id] = apperrors.FailedPreconditionf("[neo4j_handler.HandleGraphRelationshipsBatchCreate] child entity %s does not exist", childID).WithEntity(entity.Id)
+					changed = true
+					break
+				}
 			}
+		}
+	}
 
-			log.Printf("[neo4j_handler.HandleGraphRelationshipsUpdate] Successfully created relationship %s", relationship.Id)
+	// The rows are kept per entity so that a failing transaction can be retried entity by entity
+	relationships := make(map[string][]map[string]interface{})
+	byEntity := make(map[string]map[string][]map[string]interface{})
+	var created []string
+	for _, entity := range entities {
+		if failures[entity.Id] != nil || len(entity.Relationships) == 0 {
 			continue
 		}
+		own := make(map[string][]map[string]interface{})
+		for _, relationship := range entity.Relationships {
+			row := map[string]interface{}{
+				"parentID": entity.Id,
+				"childID":  relationship.RelatedEntityId,
+				"Id":       relationship.Id,
+				"Created":  relationship.StartTime,
+			}
+			// The properties were validated with the relationship
+			row["properties"], _ = graphProperties(relationship.Properties)
+			if relationship.EndTime != "" {
+				row["Terminated"] = relationship.EndTime
+			}
+			relationships[relationship.Name] = append(relationships[relationship.Name], row)
+			own[relationship.Name] = append(own[relationship.Name], row)
+		}
+		byEntity[entity.Id] = own
+		created = append(created, entity.Id)
+	}
+	if len(created) == 0 {
+		return failures
 	}
 
-	return nil
+	if err := repo.CreateRelationships(ctx, relationships); err != nil {
+		// Nothing of the batch was written, find the entities at fault with one transaction each
+		logging.FromContext(ctx).Warn("Error creating relationships of the batch, creating them per entity", "error", err)
+		for _, id := range created {
+			if err := repo.CreateRelationships(ctx, byEntity[id]); err != nil {
+				failures[id] = err
+			}
+		}
+		return failures
+	}
+
+	logging.FromContext(ctx).Debug("Created relationships for entities in Neo4j", "count", len(created))
+	return failures
 }
 
-// HandleGraphEntityFilter processes a ReadEntityRequest and calls FilterEntities
-func (repo *Neo4jRepository) HandleGraphEntityFilter(ctx context.Context, req *pb.ReadEntityRequest) ([]map[string]interface{}, error) {
+// failAll reports the same error for every entity of a batch
+func failAll(entities []*pb.Entity, err error) map[string]error {
+	failures := make(map[string]error, len(entities))
+	for _, entity := range entities {
+		failures[entity.Id] = err
+	}
+	return failures
+}
+
+// HandleGraphEntityFilter processes a ReadEntityRequest and calls FilterEntitiesPage
+func (repo *Neo4jRepository) HandleGraphEntityFilter(ctx context.Context, req *pb.ReadEntityRequest) (*EntityPage, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.HandleGraphEntityFilter", tracing.EntityID(req.GetEntity().GetId()))
+	defer span.End()
+
 	if req == nil || req.Entity == nil {
-		return nil, fmt.Errorf("invalid request: ReadEntityRequest or Entity is nil")
+		return nil, apperrors.InvalidArgumentf("invalid request: ReadEntityRequest or Entity is nil").WithField("entity")
 	}
 
 	// Extract filters from the request
@@ -545,6 +839,29 @@ func (repo *Neo4jRepository) HandleGraphEntityFilter(ctx context.Context, req *p
 		}
 	}
 
-	// Call FilterEntities with the extracted filters
-	return repo.FilterEntities(ctx, req.Entity.Kind, filters)
+	// Only the entities that existed at activeAt
+	if req.ActiveAt != "" {
+		filters["activeAt"] = req.ActiveAt
+	}
+
+	if req.PageSize < 0 {
+		return nil, apperrors.InvalidArgumentf("invalid pageSize %d, must not be negative", req.PageSize).WithField("pageSize")
+	}
+	orderBy, descending, err := ParseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	after, err := DecodeEntityCursor(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call FilterEntitiesPage with the extracted filters
+	return repo.FilterEntitiesPage(ctx, req.Entity.Kind, filters, &EntityPageRequest{
+		PageSize:          int(req.PageSize),
+		OrderBy:           orderBy,
+		Descending:        descending,
+		After:             after,
+		IncludeTotalCount: req.IncludeTotalCount,
+	})
 }
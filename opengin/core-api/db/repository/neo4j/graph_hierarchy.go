@@ -0,0 +1,180 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/tracing"
+)
+
+// HierarchyRequest describes the tree ReadHierarchy reads below its root
+type HierarchyRequest struct {
+	RootID            string
+	RelationshipNames []string // Relationships from a parent to its children
+	Direction         string   // DirectionOutgoing when the relationships point to the children, or DirectionIncoming
+	ActiveAt          string   // RFC 3339, only entities and relationships active at the time when set
+	MaxDepth          int
+	CountChildren     bool
+}
+
+// ReadHierarchy reads the tree below the root in one variable-length path query. Every path from the root
+// is a branch of the tree, so an entity reached through several paths appears once per path.
+func (r *Neo4jRepository) ReadHierarchy(ctx context.Context, req *HierarchyRequest) (*pb.Hierarchy, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadHierarchy", tracing.EntityID(req.RootID))
+	defer span.End()
+
+	if req.RootID == "" {
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
+	}
+	if len(req.RelationshipNames) == 0 {
+		return nil, apperrors.InvalidArgumentf("at least one relationship name is required").WithField("relationshipNames")
+	}
+	if req.Direction == "" {
+		return nil, apperrors.InvalidArgumentf("direction is required, expected %s or %s", DirectionOutgoing, DirectionIncoming).WithField("direction")
+	}
+	if req.MaxDepth < 1 || req.MaxDepth > MaxTraversalDepth {
+		return nil, apperrors.InvalidArgumentf("invalid maxDepth %d, expected 1 to %d", req.MaxDepth, MaxTraversalDepth).WithField("maxDepth")
+	}
+	pattern, err := pathPattern(req.Direction, 1, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	// The children of the entities at maxDepth are counted too, so the leaves of the tree tell whether
+	// it goes on below them
+	childCount := func(variable string) string {
+		if !req.CountChildren {
+			return "0"
+		}
+		hop, _ := pathPattern(req.Direction, 1, 1)
+		return `size([c = (` + variable + `)` + hop + `() WHERE ` + pathConditions("c") + ` | 1])`
+	}
+	query := `
+		MATCH (root {Id: $rootID})
+		WITH root, ` + childCount("root") + ` AS rootChildCount
+		OPTIONAL MATCH p = (root)` + pattern + `(n)
+		WHERE ` + pathConditions("p") + `
+		WITH root, rootChildCount, p, n LIMIT $limit
+		RETURN ` + nodeProjection("root") + ` AS root, rootChildCount,
+		       CASE WHEN p IS NULL THEN [] ELSE [x IN relationships(p) | x.Id] END AS relationshipIds,
+		       CASE WHEN p IS NULL THEN NULL ELSE ` + nodeProjection("n") + ` END AS node,
+		       CASE WHEN p IS NULL THEN 0 ELSE ` + childCount("n") + ` END AS childCount
+	`
+	params := map[string]interface{}{
+		"rootID":   req.RootID,
+		"names":    stringList(req.RelationshipNames),
+		"excluded": []string{},
+		"kinds":    []interface{}{},
+		"activeAt": req.ActiveAt,
+		"limit":    MaxTraversalPaths + 1,
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("error reading hierarchy of entity %s: %w", req.RootID, err)
+	}
+	tree := &hierarchyBuilder{branches: make(map[string]*pb.HierarchyNode)}
+	paths := 0
+	for result.Next(ctx) {
+		record := result.Record()
+		if tree.root == nil {
+			root, _ := record.Get("root")
+			count, _ := record.Get("rootChildCount")
+			tree.root = hierarchyNode(root, "", count)
+			tree.branches[""] = tree.root
+		}
+		relationshipIDs, _ := record.Get("relationshipIds")
+		branch := stringValues(relationshipIDs)
+		if len(branch) == 0 {
+			continue
+		}
+		paths++
+		if paths > MaxTraversalPaths {
+			tree.truncated = true
+			break
+		}
+		node, _ := record.Get("node")
+		count, _ := record.Get("childCount")
+		tree.add(branch, hierarchyNode(node, branch[len(branch)-1], count))
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error reading hierarchy of entity %s: %w", req.RootID, err)
+	}
+	if tree.root == nil {
+		return nil, apperrors.NotFoundf("entity with Id %s not found", req.RootID).WithEntity(req.RootID)
+	}
+	return tree.build(), nil
+}
+
+// hierarchyNode returns an entity projected by nodeProjection as a node of a hierarchy
+func hierarchyNode(value interface{}, relationshipID string, childCount interface{}) *pb.HierarchyNode {
+	props, _ := value.(map[string]interface{})
+	count, _ := childCount.(int64)
+	return &pb.HierarchyNode{
+		Id:             stringProp(props, "id"),
+		Kind:           &pb.Kind{Major: stringProp(props, "major"), Minor: stringProp(props, "minor")},
+		Name:           stringProp(props, "name"),
+		RelationshipId: relationshipID,
+		ChildCount:     int32(count),
+	}
+}
+
+// hierarchyBuilder places the entities at the end of the paths from the root in the tree. A path is
+// identified by its relationship ids, and hangs below the path without its last relationship.
+type hierarchyBuilder struct {
+	root      *pb.HierarchyNode
+	branches  map[string]*pb.HierarchyNode
+	pending   []hierarchyBranch
+	truncated bool
+}
+
+// hierarchyBranch is the entity at the end of a path from the root
+type hierarchyBranch struct {
+	relationshipIDs []string
+	node            *pb.HierarchyNode
+}
+
+// add keeps the entity of the path until every path was read, as the paths come in any order
+func (h *hierarchyBuilder) add(relationshipIDs []string, node *pb.HierarchyNode) {
+	h.pending = append(h.pending, hierarchyBranch{relationshipIDs: relationshipIDs, node: node})
+}
+
+// build links every entity to its parent, shorter paths first, and orders the children
+func (h *hierarchyBuilder) build() *pb.Hierarchy {
+	sort.SliceStable(h.pending, func(i, j int) bool { return len(h.pending[i].relationshipIDs) < len(h.pending[j].relationshipIDs) })
+	for _, branch := range h.pending {
+		ids := branch.relationshipIDs
+		parent, ok := h.branches[strings.Join(ids[:len(ids)-1], "\x00")]
+		if !ok {
+			// The parent was cut off by the limit of the paths
+			continue
+		}
+		parent.Children = append(parent.Children, branch.node)
+		h.branches[strings.Join(ids, "\x00")] = branch.node
+	}
+	sortChildren(h.root)
+	return &pb.Hierarchy{Root: h.root, Truncated: h.truncated}
+}
+
+func sortChildren(node *pb.HierarchyNode) {
+	sort.Slice(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Id < b.Id
+	})
+	for _, child := range node.Children {
+		sortChildren(child)
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/tracing"
+)
+
+// Limits of Traverse. Variable-length paths grow exponentially with their length, so their length is
+// bounded and the paths read are capped.
+const (
+	MaxTraversalDepth = 10
+	MaxTraversalPaths = 10000
+)
+
+// Limits of FindPaths
+const (
+	MaxPathLength = 15
+	MaxPaths      = 100
+)
+
+// Directions of a traversal, an empty direction follows both
+const (
+	DirectionOutgoing = "OUTGOING"
+	DirectionIncoming = "INCOMING"
+)
+
+// TraversalRequest describes the paths Traverse follows from its start entity
+type TraversalRequest struct {
+	StartID                   string
+	RelationshipNames         []string // Every relationship when empty
+	ExcludedRelationshipNames []string // Never followed, the relationships to attribute nodes
+	Direction                 string   // One of the Direction constants, or empty for both
+	MinDepth                  int
+	MaxDepth                  int
+	ActiveAt                  string     // RFC 3339, only entities and relationships active at the time when set
+	Kinds                     []*pb.Kind // Kinds of the visited entities, an empty minor matches every minor kind
+}
+
+// Traverse follows the relationships from the start entity in one variable-length path query and returns
+// the entities and relationships of every matching path. The start comes first, the other entities are
+// ordered by depth and id, the relationships by id.
+func (r *Neo4jRepository) Traverse(ctx context.Context, req *TraversalRequest) (*pb.Graph, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.Traverse", tracing.EntityID(req.StartID))
+	defer span.End()
+
+	if req.StartID == "" {
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
+	}
+	if req.MinDepth < 1 || req.MaxDepth < req.MinDepth || req.MaxDepth > MaxTraversalDepth {
+		return nil, apperrors.InvalidArgumentf("invalid depth %d..%d, expected 1 <= minDepth <= maxDepth <= %d", req.MinDepth, req.MaxDepth, MaxTraversalDepth).WithField("maxDepth")
+	}
+	pattern, err := pathPattern(req.Direction, req.MinDepth, req.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"startID":  req.StartID,
+		"names":    stringList(req.RelationshipNames),
+		"excluded": stringList(req.ExcludedRelationshipNames),
+		"kinds":    kindParams(req.Kinds),
+		"activeAt": req.ActiveAt,
+		"limit":    MaxTraversalPaths + 1,
+	}
+	// Every entity after the start and every relationship of a path has to match the filters
+	query := `
+		MATCH (start {Id: $startID})
+		OPTIONAL MATCH p = (start)` + pattern + `(end)
+		WHERE ` + pathConditions("p") + `
+		WITH start, p LIMIT $limit
+		RETURN ` + nodeProjection("start") + ` AS start,
+		       CASE WHEN p IS NULL THEN [] ELSE [x IN nodes(p) | ` + nodeProjection("x") + `] END AS nodes,
+		       CASE WHEN p IS NULL THEN [] ELSE [x IN relationships(p) | ` + edgeProjection("x") + `] END AS edges
+	`
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("error traversing from entity %s: %w", req.StartID, err)
+	}
+	graph := newGraphBuilder()
+	paths := 0
+	for result.Next(ctx) {
+		record := result.Record()
+		start, _ := record.Get("start")
+		graph.addNode(start, 0)
+		nodes, _ := record.Get("nodes")
+		edges, _ := record.Get("edges")
+		if nodeList, ok := nodes.([]interface{}); ok && len(nodeList) > 0 {
+			paths++
+			if paths > MaxTraversalPaths {
+				graph.truncated = true
+				break
+			}
+			for depth, node := range nodeList {
+				graph.addNode(node, depth)
+			}
+			if edgeList, ok := edges.([]interface{}); ok {
+				for _, edge := range edgeList {
+					graph.addEdge(edge)
+				}
+			}
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error reading paths from entity %s: %w", req.StartID, err)
+	}
+	if len(graph.nodes) == 0 {
+		return nil, apperrors.NotFoundf("entity with Id %s not found", req.StartID).WithEntity(req.StartID)
+	}
+	return graph.build(req.StartID), nil
+}
+
+// PathRequest describes the paths FindPaths looks for
+type PathRequest struct {
+	FromID                    string
+	ToID                      string
+	RelationshipNames         []string // Every relationship when empty
+	ExcludedRelationshipNames []string // Never followed, the relationships to attribute nodes
+	ActiveAt                  string   // RFC 3339, only relationships active at the time when set
+	MaxLength                 int
+	All                       bool // Every shortest path rather than one
+	MaxPaths                  int  // Most paths returned when All is set
+}
+
+// FindPaths returns the shortest paths between two entities, following relationships in either
+// direction. It returns no paths when the entities are not connected within the length.
+func (r *Neo4jRepository) FindPaths(ctx context.Context, req *PathRequest) ([]*pb.Path, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.FindPaths", tracing.EntityID(req.FromID))
+	defer span.End()
+
+	if req.FromID == "" {
+		return nil, apperrors.InvalidArgumentf("fromId cannot be empty").WithField("fromId")
+	}
+	if req.ToID == "" {
+		return nil, apperrors.InvalidArgumentf("toId cannot be empty").WithField("toId")
+	}
+	if req.FromID == req.ToID {
+		return nil, apperrors.InvalidArgumentf("fromId and toId are both %s, expected two entities", req.FromID).WithField("toId")
+	}
+	if req.MaxLength < 1 || req.MaxLength > MaxPathLength {
+		return nil, apperrors.InvalidArgumentf("invalid maxLength %d, expected 1 to %d", req.MaxLength, MaxPathLength).WithField("maxLength")
+	}
+	limit := 1
+	shortest := "shortestPath"
+	if req.All {
+		if req.MaxPaths < 1 || req.MaxPaths > MaxPaths {
+			return nil, apperrors.InvalidArgumentf("invalid maxPaths %d, expected 1 to %d", req.MaxPaths, MaxPaths).WithField("maxPaths")
+		}
+		limit = req.MaxPaths
+		shortest = "allShortestPaths"
+	}
+
+	existing, err := r.ExistingEntityIds(ctx, []string{req.FromID, req.ToID})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range []string{req.FromID, req.ToID} {
+		if !existing[id] {
+			return nil, apperrors.NotFoundf("entity with Id %s not found", id).WithEntity(id)
+		}
+	}
+
+	// The conditions on the relationships of shortestPath are checked while searching, so a path
+	// through an inactive relationship does not hide a longer active one
+	query := fmt.Sprintf(`
+		MATCH (a {Id: $fromID}), (b {Id: $toID})
+		MATCH p = %s((a)-[*..%d]-(b))
+		WHERE `+relationshipConditions("p")+`
+		RETURN [x IN nodes(p) | x.Id] AS entityIds, [x IN relationships(p) | x.Id] AS relationshipIds
+		LIMIT $limit
+	`, shortest, req.MaxLength)
+	params := map[string]interface{}{
+		"fromID":   req.FromID,
+		"toID":     req.ToID,
+		"names":    stringList(req.RelationshipNames),
+		"excluded": stringList(req.ExcludedRelationshipNames),
+		"activeAt": req.ActiveAt,
+		"limit":    limit,
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("error finding paths from entity %s to %s: %w", req.FromID, req.ToID, err)
+	}
+	var paths []*pb.Path
+	for result.Next(ctx) {
+		record := result.Record()
+		entityIDs, _ := record.Get("entityIds")
+		relationshipIDs, _ := record.Get("relationshipIds")
+		paths = append(paths, &pb.Path{EntityIds: stringValues(entityIDs), RelationshipIds: stringValues(relationshipIDs)})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("error reading paths from entity %s to %s: %w", req.FromID, req.ToID, err)
+	}
+	return paths, nil
+}
+
+// stringValues returns the strings of a list returned by a query
+func stringValues(value interface{}) []string {
+	list, _ := value.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// pathPattern returns the variable-length relationship pattern of the direction. The depths are
+// validated integers, as Cypher does not take parameters for them.
+func pathPattern(direction string, minDepth, maxDepth int) (string, error) {
+	hops := fmt.Sprintf("[*%d..%d]", minDepth, maxDepth)
+	switch direction {
+	case DirectionOutgoing:
+		return "-" + hops + "->", nil
+	case DirectionIncoming:
+		return "<-" + hops + "-", nil
+	case "":
+		return "-" + hops + "-", nil
+	default:
+		return "", apperrors.InvalidArgumentf("invalid direction %q, expected %s, %s or none for both", direction, DirectionOutgoing, DirectionIncoming).WithField("direction")
+	}
+}
+
+// pathConditions returns the conditions the relationships and the entities after the start of the
+// path have to meet, reading the names, excluded, kinds and activeAt parameters. Relationship names are
+// compared with type() rather than written into the pattern, so they need no escaping.
+func pathConditions(path string) string {
+	return relationshipConditions(path) + `
+		  AND ALL(x IN nodes(` + path + `)[1..] WHERE ` + activeCondition("x") + ` AND ` + kindCondition("x") + `)`
+}
+
+// relationshipConditions returns the conditions every relationship of the path has to meet, reading the
+// names, excluded and activeAt parameters
+func relationshipConditions(path string) string {
+	return `ALL(x IN relationships(` + path + `) WHERE (size($names) = 0 OR type(x) IN $names) AND NOT type(x) IN $excluded AND ` + activeCondition("x") + `)`
+}
+
+// activeCondition tells whether the entity or relationship was active at $activeAt, always true without it
+func activeCondition(variable string) string {
+	return `($activeAt = '' OR (` + variable + `.Created <= datetime($activeAt) AND (` + variable + `.Terminated IS NULL OR ` + variable + `.Terminated > datetime($activeAt))))`
+}
+
+// kindCondition tells whether the entity has one of the $kinds, always true without kinds
+func kindCondition(variable string) string {
+	return `(size($kinds) = 0 OR ANY(k IN $kinds WHERE labels(` + variable + `)[0] = k.major AND (k.minor = '' OR ` + variable + `.MinorKind = k.minor)))`
+}
+
+// stringList returns the list as a parameter, an empty list rather than null when it is nil
+func stringList(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func kindParams(kinds []*pb.Kind) []interface{} {
+	params := make([]interface{}, 0, len(kinds))
+	for _, kind := range kinds {
+		params = append(params, map[string]interface{}{"major": kind.GetMajor(), "minor": kind.GetMinor()})
+	}
+	return params
+}
+
+func nodeProjection(variable string) string {
+	return `{id: ` + variable + `.Id, major: labels(` + variable + `)[0], minor: ` + variable + `.MinorKind, name: ` + variable + `.Name}`
+}
+
+func edgeProjection(variable string) string {
+	return `{id: ` + variable + `.Id, name: type(` + variable + `), sourceId: startNode(` + variable + `).Id, targetId: endNode(` + variable + `).Id, created: ` + variable + `.Created, terminated: ` + variable + `.Terminated}`
+}
+
+// graphBuilder collects the entities and relationships of paths, once each
+type graphBuilder struct {
+	nodes     map[string]*pb.GraphNode
+	edges     map[string]*pb.GraphEdge
+	truncated bool
+}
+
+func newGraphBuilder() *graphBuilder {
+	return &graphBuilder{nodes: make(map[string]*pb.GraphNode), edges: make(map[string]*pb.GraphEdge)}
+}
+
+// addNode adds an entity projected by nodeProjection, keeping its smallest depth
+func (g *graphBuilder) addNode(value interface{}, depth int) {
+	props, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	id := stringProp(props, "id")
+	if node, ok := g.nodes[id]; ok {
+		node.Depth = min(node.Depth, int32(depth))
+		return
+	}
+	g.nodes[id] = &pb.GraphNode{
+		Id:    id,
+		Kind:  &pb.Kind{Major: stringProp(props, "major"), Minor: stringProp(props, "minor")},
+		Name:  stringProp(props, "name"),
+		Depth: int32(depth),
+	}
+}
+
+// addEdge adds a relationship projected by edgeProjection
+func (g *graphBuilder) addEdge(value interface{}) {
+	props, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	id := stringProp(props, "id")
+	if _, ok := g.edges[id]; ok {
+		return
+	}
+	g.edges[id] = &pb.GraphEdge{
+		Id:        id,
+		Name:      stringProp(props, "name"),
+		SourceId:  stringProp(props, "sourceId"),
+		TargetId:  stringProp(props, "targetId"),
+		StartTime: timeProp(props, "created"),
+		EndTime:   timeProp(props, "terminated"),
+	}
+}
+
+// build returns the graph, the start entity first
+func (g *graphBuilder) build(startID string) *pb.Graph {
+	graph := &pb.Graph{Truncated: g.truncated}
+	for _, node := range g.nodes {
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		a, b := graph.Nodes[i], graph.Nodes[j]
+		if (a.Id == startID) != (b.Id == startID) {
+			return a.Id == startID
+		}
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Id < b.Id
+	})
+	for _, edge := range g.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool { return graph.Edges[i].Id < graph.Edges[j].Id })
+	return graph
+}
+
+func stringProp(props map[string]interface{}, key string) string {
+	if value, ok := props[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// timeProp formats a datetime property the way the relationships are returned elsewhere
+func timeProp(props map[string]interface{}, key string) string {
+	switch value := props[key].(type) {
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
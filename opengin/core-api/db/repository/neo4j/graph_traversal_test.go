@@ -0,0 +1,218 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"context"
+	"testing"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTraversalGraph creates a ministry with two departments, one of them with a unit, and a
+// department that left the ministry at the end of 2022:
+//
+//	ministry -HAS_DEPARTMENT-> health -HAS_UNIT-> hospitals
+//	ministry -HAS_DEPARTMENT-> education
+//	ministry -HAS_DEPARTMENT (until 2023)-> transport
+func createTraversalGraph(t *testing.T, ctx context.Context) {
+	entities := []struct {
+		id   string
+		kind *pb.Kind
+		name string
+	}{
+		{"traverse-ministry", &pb.Kind{Major: "Organisation", Minor: "Ministry"}, "Ministry of Health"},
+		{"traverse-health", &pb.Kind{Major: "Organisation", Minor: "Department"}, "Department of Health"},
+		{"traverse-education", &pb.Kind{Major: "Organisation", Minor: "Department"}, "Department of Education"},
+		{"traverse-transport", &pb.Kind{Major: "Organisation", Minor: "Department"}, "Department of Transport"},
+		{"traverse-hospitals", &pb.Kind{Major: "Organisation", Minor: "Unit"}, "Hospitals"},
+	}
+	for _, entity := range entities {
+		_, err := repository.CreateGraphEntity(ctx, entity.kind, map[string]interface{}{"Id": entity.id, "Name": entity.name, "Created": "2020-01-01T00:00:00Z"})
+		require.NoError(t, err)
+	}
+	relationships := []struct {
+		from         string
+		relationship *pb.Relationship
+	}{
+		{"traverse-ministry", &pb.Relationship{Id: "traverse-r1", Name: "HAS_DEPARTMENT", RelatedEntityId: "traverse-health", StartTime: "2020-01-01T00:00:00Z"}},
+		{"traverse-ministry", &pb.Relationship{Id: "traverse-r2", Name: "HAS_DEPARTMENT", RelatedEntityId: "traverse-education", StartTime: "2020-01-01T00:00:00Z"}},
+		{"traverse-ministry", &pb.Relationship{Id: "traverse-r3", Name: "HAS_DEPARTMENT", RelatedEntityId: "traverse-transport", StartTime: "2020-01-01T00:00:00Z", EndTime: "2023-01-01T00:00:00Z"}},
+		{"traverse-health", &pb.Relationship{Id: "traverse-r4", Name: "HAS_UNIT", RelatedEntityId: "traverse-hospitals", StartTime: "2021-01-01T00:00:00Z"}},
+	}
+	for _, r := range relationships {
+		_, err := repository.CreateRelationship(ctx, r.from, r.relationship)
+		require.NoError(t, err)
+	}
+}
+
+func nodeIDs(graph *pb.Graph) []string {
+	var ids []string
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.Id)
+	}
+	return ids
+}
+
+func TestTraverse(t *testing.T) {
+	ctx := context.Background()
+	createTraversalGraph(t, ctx)
+
+	graph, err := repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", Direction: DirectionOutgoing, MinDepth: 1, MaxDepth: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traverse-ministry", "traverse-education", "traverse-health", "traverse-transport", "traverse-hospitals"}, nodeIDs(graph))
+	assert.Equal(t, int32(2), graph.Nodes[4].Depth)
+	assert.Equal(t, "Hospitals", graph.Nodes[4].Name)
+	assert.Len(t, graph.Edges, 4)
+	assert.Equal(t, "traverse-health", graph.Edges[3].SourceId)
+	assert.Equal(t, "traverse-hospitals", graph.Edges[3].TargetId)
+	assert.False(t, graph.Truncated)
+
+	// The transport department had left and the unit did not exist yet
+	graph, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", Direction: DirectionOutgoing, MinDepth: 1, MaxDepth: 2, ActiveAt: "2020-06-01T00:00:00Z"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traverse-ministry", "traverse-education", "traverse-health", "traverse-transport"}, nodeIDs(graph))
+	graph, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", Direction: DirectionOutgoing, MinDepth: 1, MaxDepth: 2, ActiveAt: "2023-06-01T00:00:00Z"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traverse-ministry", "traverse-education", "traverse-health", "traverse-hospitals"}, nodeIDs(graph))
+
+	graph, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", Direction: DirectionOutgoing, MinDepth: 1, MaxDepth: 2, Kinds: []*pb.Kind{{Major: "Organisation", Minor: "Department"}}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traverse-ministry", "traverse-education", "traverse-health", "traverse-transport"}, nodeIDs(graph), "Expected the unit to be left out")
+
+	graph, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-hospitals", RelationshipNames: []string{"HAS_UNIT"}, Direction: DirectionIncoming, MinDepth: 1, MaxDepth: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"traverse-hospitals", "traverse-health"}, nodeIDs(graph), "Expected only HAS_UNIT to be followed")
+}
+
+func TestTraverseInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-missing", MinDepth: 1, MaxDepth: 1})
+	assert.True(t, apperrors.Is(err, apperrors.NotFound))
+	_, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", MinDepth: 1, MaxDepth: MaxTraversalDepth + 1})
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument))
+	_, err = repository.Traverse(ctx, &TraversalRequest{StartID: "traverse-ministry", Direction: "SIDEWAYS", MinDepth: 1, MaxDepth: 1})
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument))
+}
+
+// createPathGraph creates a person who was minister of a ministry from 2020 to 2022, and is connected
+// to it through two paths of two relationships:
+//
+//	person -MINISTER_OF (2020 to 2022)-> ministry
+//	person -ADVISES-> cabinet -INCLUDES-> ministry
+//	person -MEMBER_OF-> party -FUNDS-> ministry
+func createPathGraph(t *testing.T, ctx context.Context) {
+	for _, id := range []string{"paths-person", "paths-ministry", "paths-cabinet", "paths-party"} {
+		_, err := repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Organisation", Minor: "Test"}, map[string]interface{}{"Id": id, "Name": id, "Created": "2015-01-01T00:00:00Z"})
+		require.NoError(t, err)
+	}
+	relationships := []struct {
+		from         string
+		relationship *pb.Relationship
+	}{
+		{"paths-person", &pb.Relationship{Id: "paths-r1", Name: "MINISTER_OF", RelatedEntityId: "paths-ministry", StartTime: "2020-01-01T00:00:00Z", EndTime: "2022-01-01T00:00:00Z"}},
+		{"paths-person", &pb.Relationship{Id: "paths-r2", Name: "ADVISES", RelatedEntityId: "paths-cabinet", StartTime: "2015-01-01T00:00:00Z"}},
+		{"paths-cabinet", &pb.Relationship{Id: "paths-r3", Name: "INCLUDES", RelatedEntityId: "paths-ministry", StartTime: "2015-01-01T00:00:00Z"}},
+		{"paths-person", &pb.Relationship{Id: "paths-r4", Name: "MEMBER_OF", RelatedEntityId: "paths-party", StartTime: "2015-01-01T00:00:00Z"}},
+		{"paths-party", &pb.Relationship{Id: "paths-r5", Name: "FUNDS", RelatedEntityId: "paths-ministry", StartTime: "2015-01-01T00:00:00Z"}},
+	}
+	for _, r := range relationships {
+		_, err := repository.CreateRelationship(ctx, r.from, r.relationship)
+		require.NoError(t, err)
+	}
+}
+
+func TestFindPaths(t *testing.T) {
+	ctx := context.Background()
+	createPathGraph(t, ctx)
+
+	paths, err := repository.FindPaths(ctx, &PathRequest{FromID: "paths-person", ToID: "paths-ministry", ActiveAt: "2021-01-01T00:00:00Z", MaxLength: 6})
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Equal(t, []string{"paths-person", "paths-ministry"}, paths[0].EntityIds)
+	assert.Equal(t, []string{"paths-r1"}, paths[0].RelationshipIds)
+
+	// The person is no longer minister, the paths go through the cabinet and the party
+	paths, err = repository.FindPaths(ctx, &PathRequest{FromID: "paths-person", ToID: "paths-ministry", ActiveAt: "2023-01-01T00:00:00Z", MaxLength: 6, All: true, MaxPaths: 10})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+	for _, path := range paths {
+		assert.Len(t, path.EntityIds, 3)
+		assert.Len(t, path.RelationshipIds, 2)
+	}
+
+	// Paths are followed in either direction
+	paths, err = repository.FindPaths(ctx, &PathRequest{FromID: "paths-ministry", ToID: "paths-party", RelationshipNames: []string{"FUNDS"}, MaxLength: 6})
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Equal(t, []string{"paths-r5"}, paths[0].RelationshipIds)
+
+	paths, err = repository.FindPaths(ctx, &PathRequest{FromID: "paths-cabinet", ToID: "paths-party", RelationshipNames: []string{"INCLUDES"}, MaxLength: 6})
+	require.NoError(t, err)
+	assert.Empty(t, paths, "Expected no paths through the requested relationships only")
+
+	_, err = repository.FindPaths(ctx, &PathRequest{FromID: "paths-person", ToID: "paths-missing", MaxLength: 6})
+	assert.True(t, apperrors.Is(err, apperrors.NotFound))
+	_, err = repository.FindPaths(ctx, &PathRequest{FromID: "paths-person", ToID: "paths-ministry", MaxLength: MaxPathLength + 1})
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument))
+}
+
+func TestReadHierarchy(t *testing.T) {
+	ctx := context.Background()
+	for _, id := range []string{"hierarchy-ministry", "hierarchy-health", "hierarchy-transport", "hierarchy-hospitals", "hierarchy-clinics"} {
+		_, err := repository.CreateGraphEntity(ctx, &pb.Kind{Major: "Organisation", Minor: "Department"}, map[string]interface{}{"Id": id, "Name": id, "Created": "2020-01-01T00:00:00Z"})
+		require.NoError(t, err)
+	}
+	relationships := []struct {
+		from         string
+		relationship *pb.Relationship
+	}{
+		{"hierarchy-ministry", &pb.Relationship{Id: "hierarchy-r1", Name: "HAS_DEPARTMENT", RelatedEntityId: "hierarchy-health", StartTime: "2020-01-01T00:00:00Z"}},
+		{"hierarchy-ministry", &pb.Relationship{Id: "hierarchy-r2", Name: "HAS_DEPARTMENT", RelatedEntityId: "hierarchy-transport", StartTime: "2020-01-01T00:00:00Z", EndTime: "2023-01-01T00:00:00Z"}},
+		{"hierarchy-health", &pb.Relationship{Id: "hierarchy-r3", Name: "HAS_UNIT", RelatedEntityId: "hierarchy-hospitals", StartTime: "2020-01-01T00:00:00Z"}},
+		{"hierarchy-hospitals", &pb.Relationship{Id: "hierarchy-r4", Name: "HAS_UNIT", RelatedEntityId: "hierarchy-clinics", StartTime: "2020-01-01T00:00:00Z"}},
+	}
+	for _, r := range relationships {
+		_, err := repository.CreateRelationship(ctx, r.from, r.relationship)
+		require.NoError(t, err)
+	}
+
+	hierarchy, err := repository.ReadHierarchy(ctx, &HierarchyRequest{
+		RootID:            "hierarchy-ministry",
+		RelationshipNames: []string{"HAS_DEPARTMENT", "HAS_UNIT"},
+		Direction:         DirectionOutgoing,
+		ActiveAt:          "2024-01-01T00:00:00Z",
+		MaxDepth:          2,
+		CountChildren:     true,
+	})
+	require.NoError(t, err)
+	root := hierarchy.Root
+	assert.Equal(t, "hierarchy-ministry", root.Id)
+	assert.Equal(t, int32(1), root.ChildCount, "Expected the transport department to have left")
+	require.Len(t, root.Children, 1)
+	health := root.Children[0]
+	assert.Equal(t, "hierarchy-health", health.Id)
+	assert.Equal(t, "hierarchy-r1", health.RelationshipId)
+	require.Len(t, health.Children, 1)
+	hospitals := health.Children[0]
+	assert.Equal(t, "hierarchy-hospitals", hospitals.Id)
+	assert.Empty(t, hospitals.Children, "Expected the tree to stop at maxDepth")
+	assert.Equal(t, int32(1), hospitals.ChildCount, "Expected the children below maxDepth to be counted")
+
+	// Read from the bottom up
+	hierarchy, err = repository.ReadHierarchy(ctx, &HierarchyRequest{RootID: "hierarchy-clinics", RelationshipNames: []string{"HAS_UNIT"}, Direction: DirectionIncoming, MaxDepth: 5})
+	require.NoError(t, err)
+	require.Len(t, hierarchy.Root.Children, 1)
+	assert.Equal(t, "hierarchy-hospitals", hierarchy.Root.Children[0].Id)
+	require.Len(t, hierarchy.Root.Children[0].Children, 1)
+	assert.Equal(t, "hierarchy-health", hierarchy.Root.Children[0].Children[0].Id)
+
+	_, err = repository.ReadHierarchy(ctx, &HierarchyRequest{RootID: "hierarchy-ministry", Direction: DirectionOutgoing, MaxDepth: 2})
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument), "Expected the relationship names to be required")
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/metrics"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// defaultMaxConnectionPoolSize is the pool size of the driver when none is configured
+const defaultMaxConnectionPoolSize = 100
+
+// instrumentedSession records every query and transaction of a session in the metrics and as a span,
+// named after the repository method that opened the session
+type instrumentedSession struct {
+	neo4j.SessionWithContext
+	repo      *Neo4jRepository
+	operation string
+	closed    atomic.Bool
+}
+
+func (s *instrumentedSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	ctx, span := tracing.StartClient(ctx, metrics.Neo4j, s.operation)
+	start := time.Now()
+	result, err := s.SessionWithContext.Run(ctx, cypher, params, configurers...)
+	metrics.ObserveStore(metrics.Neo4j, s.operation, start, err)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *instrumentedSession) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	ctx, span := tracing.StartClient(ctx, metrics.Neo4j, s.operation)
+	start := time.Now()
+	result, err := s.SessionWithContext.ExecuteRead(ctx, work, configurers...)
+	metrics.ObserveStore(metrics.Neo4j, s.operation, start, err)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *instrumentedSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	ctx, span := tracing.StartClient(ctx, metrics.Neo4j, s.operation)
+	start := time.Now()
+	result, err := s.SessionWithContext.ExecuteWrite(ctx, work, configurers...)
+	metrics.ObserveStore(metrics.Neo4j, s.operation, start, err)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *instrumentedSession) Close(ctx context.Context) error {
+	if !s.closed.Swap(true) {
+		s.repo.openSessions.Add(-1)
+	}
+	return s.SessionWithContext.Close(ctx)
+}
+
+// PoolStats reports the open sessions as connections in use, since the driver does not expose its pool
+func (r *Neo4jRepository) PoolStats() metrics.PoolStats {
+	maxConnections := defaultMaxConnectionPoolSize
+	if r.config != nil && r.config.MaxConnectionPoolSize > 0 {
+		maxConnections = r.config.MaxConnectionPoolSize
+	}
+	return metrics.PoolStats{InUse: int(r.openSessions.Load()), Idle: -1, Max: maxConnections}
+}
+
+var (
+	operationNames   sync.Map // program counter to operation name
+	closureSuffixExp = regexp.MustCompile(`(\.func\d+)+$`)
+)
+
+// callerOperation returns the name of the repository method skip frames up the stack,
+// e.g. "CreateGraphEntity"
+func callerOperation(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	if name, ok := operationNames.Load(pc); ok {
+		return name.(string)
+	}
+
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = closureSuffixExp.ReplaceAllString(fn.Name(), "")
+		name = name[strings.LastIndex(name, ".")+1:]
+	}
+	operationNames.Store(pc, name)
+	return name
+}
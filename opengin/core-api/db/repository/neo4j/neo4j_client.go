@@ -8,34 +8,61 @@ import (
 	"fmt"
 	"lk/datafoundation/core-api/db/config"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
-	"log"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
+// InitialEntityVersion is the version of a new entity, nodes created before versions were
+// introduced are read as this version
+const InitialEntityVersion int64 = 1
+
+// quoteIdentifier quotes a label or relationship type for a query. Neo4j takes no parameters for them,
+// so they are written into the query with their backticks doubled.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
 type Neo4jRepository struct {
-	client neo4j.DriverWithContext
-	config *config.Neo4jConfig
+	client       neo4j.DriverWithContext
+	config       *config.Neo4jConfig
+	openSessions atomic.Int64
 }
 
 // NewNeo4jRepository initializes a Neo4j driver
 func NewNeo4jRepository(ctx context.Context, config *config.Neo4jConfig) (*Neo4jRepository, error) {
-	client, err := neo4j.NewDriverWithContext(config.URI, neo4j.BasicAuth(config.Username, config.Password, ""))
+	client, err := neo4j.NewDriverWithContext(config.URI, neo4j.BasicAuth(config.Username, config.Password, ""), func(driverConfig *neo4jconfig.Config) {
+		if config.MaxConnectionPoolSize > 0 {
+			driverConfig.MaxConnectionPoolSize = config.MaxConnectionPoolSize
+		}
+		if config.ConnectionAcquisitionTimeout > 0 {
+			driverConfig.ConnectionAcquisitionTimeout = config.ConnectionAcquisitionTimeout
+		}
+		if config.ConnectTimeout > 0 {
+			driverConfig.SocketConnectTimeout = config.ConnectTimeout
+		}
+	})
 	if err != nil {
-		log.Printf("[neo4j_client.NewNeo4jRepository] failed to create Neo4j driver: %v", err)
+		logging.FromContext(ctx).Error("Failed to create Neo4j driver", "error", err)
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
 
 	// Verify connectivity
 	if err := client.VerifyConnectivity(ctx); err != nil {
 		client.Close(ctx) // Close if connectivity check fails
-		log.Printf("[neo4j_client.NewNeo4jRepository] failed to connect to Neo4j: %v", err)
+		logging.FromContext(ctx).Error("Failed to connect to Neo4j", "error", err)
 		return nil, fmt.Errorf("failed to connect to Neo4j: %w", err)
 	}
 
-	log.Println("[neo4j_client.NewNeo4jRepository] Connected to Neo4j successfully!")
+	logging.FromContext(ctx).Info("Connected to Neo4j")
 
 	return &Neo4jRepository{
 		client: client,
@@ -47,84 +74,85 @@ func NewNeo4jRepository(ctx context.Context, config *config.Neo4jConfig) (*Neo4j
 func (r *Neo4jRepository) Close(ctx context.Context) {
 	if r.client != nil {
 		r.client.Close(ctx)
-		log.Println("Neo4j connection closed")
+		logging.FromContext(ctx).Info("Neo4j connection closed")
 	}
 }
 
-// getSession creates a new session
+// Ping checks that Neo4j can be reached
+func (r *Neo4jRepository) Ping(ctx context.Context) error {
+	return r.client.VerifyConnectivity(ctx)
+}
+
+// getSession creates a new session whose queries are recorded in the metrics as operations of the caller
 func (r *Neo4jRepository) getSession(ctx context.Context) neo4j.SessionWithContext {
-	return r.client.NewSession(ctx, neo4j.SessionConfig{
+	session := r.client.NewSession(ctx, neo4j.SessionConfig{
 		AccessMode: neo4j.AccessModeWrite,
 	})
+	r.openSessions.Add(1)
+	return &instrumentedSession{SessionWithContext: session, repo: r, operation: callerOperation(1)}
 }
 
 // CreateGraphEntity checks if an entity exists and creates it if it doesn't
 func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind, entityMap map[string]interface{}) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.CreateGraphEntity")
+	defer span.End()
+
 	// Validate the kind parameter
 	if kind == nil || kind.Major == "" {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field")
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field")
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Kind.Major: %v", kind.Major)
+		logging.FromContext(ctx).Debug("Missing or invalid 'Kind.Major' field")
+		return nil, apperrors.InvalidArgumentf("[neo4j_client.CreateGraphEntity] missing or invalid 'Kind.Major' field").WithField("kind.major")
 	}
 
 	// Extract the required fields from the entityMap
 	id, ok := entityMap["Id"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field")
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field")
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Id: %v", id)
+		logging.FromContext(ctx).Debug("Missing or invalid 'Id' field")
+		return nil, apperrors.InvalidArgumentf("[neo4j_client.CreateGraphEntity] missing or invalid 'Id' field").WithField("id")
 	}
 
 	name, ok := entityMap["Name"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field")
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field")
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Name: %v", name)
+		logging.FromContext(ctx).Debug("Missing or invalid 'Name' field")
+		return nil, apperrors.InvalidArgumentf("[neo4j_client.CreateGraphEntity] missing or invalid 'Name' field").WithField("name").WithEntity(id)
 	}
 
 	created, ok := entityMap["Created"].(string)
 	if !ok {
-		log.Printf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field")
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field")
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Created: %v", created)
+		logging.FromContext(ctx).Debug("Missing or invalid 'Created' field")
+		return nil, apperrors.InvalidArgumentf("[neo4j_client.CreateGraphEntity] missing or invalid 'Created' field").WithField("created").WithEntity(id)
 	}
 
 	// Optional field
 	var terminated *string
 	if term, ok := entityMap["Terminated"].(string); ok {
 		terminated = &term
-	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
 	}
+	logging.FromContext(ctx).Debug("Creating graph entity", "entity_id", id, "kind", kind.Major, "created", created)
 
 	// Open a session
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
 	// Check if the node already exists
-	existsQuery := `MATCH (e:` + kind.Major + ` {Id: $Id}) RETURN e`
+	existsQuery := `MATCH (e:` + quoteIdentifier(kind.Major) + ` {Id: $Id}) RETURN e`
 	result, err := session.Run(ctx, existsQuery, map[string]interface{}{"Id": id})
 	if err != nil {
-		log.Printf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %v", err)
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if entity exists", "error", err)
+		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error checking if entity exists: %w", err)
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] existsQuery: %v", existsQuery)
+		logging.FromContext(ctx).Debug("Checking if entity exists", "query", existsQuery)
 	}
 
 	// If entity exists, return an error
 	if result.Next(ctx) {
-		log.Printf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id)
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id)
+		logging.FromContext(ctx).Debug("Entity with Id already exists", "entity_id", id)
+		return nil, apperrors.AlreadyExistsf("[neo4j_client.CreateGraphEntity] entity with Id %s already exists", id).WithEntity(id)
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] entity with Id %s does not exist", id)
+		logging.FromContext(ctx).Debug("Entity with Id does not exist", "entity_id", id)
 	}
 
 	// Create the node
-	createQuery := `CREATE (e:` + kind.Major + ` {Id: $Id, Name: $Name, Created: datetime($Created), MinorKind: $MinorKind`
+	createQuery := `CREATE (e:` + quoteIdentifier(kind.Major) + ` {Id: $Id, Name: $Name, Created: datetime($Created), MinorKind: $MinorKind, Version: $Version`
 	if terminated != nil {
 		createQuery += `, Terminated: datetime($Terminated)`
 	}
@@ -136,6 +164,7 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 		"Name":      name,
 		"Created":   created,
 		"MinorKind": kind.Minor,
+		"Version":   InitialEntityVersion,
 	}
 	if terminated != nil {
 		params["Terminated"] = *terminated
@@ -144,10 +173,10 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 	// Run the query to create the entity and return it
 	result, err = session.Run(ctx, createQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateGraphEntity] error creating entity: %v", err)
-		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error creating entity: %v", err)
+		logging.FromContext(ctx).Error("Error creating entity", "error", err)
+		return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] error creating entity: %w", err)
 	} else {
-		log.Printf("[neo4j_client.CreateGraphEntity] created entity(run query): %v", params)
+		logging.FromContext(ctx).Debug("Created entity", "entity_id", id)
 	}
 
 	// Retrieve the created entity
@@ -155,10 +184,10 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 		createdEntity, _ := result.Record().Get("e")
 		node, ok := createdEntity.(neo4j.Node)
 		if !ok {
-			log.Printf("[neo4j_client.CreateGraphEntity] failed to cast created entity to neo4j.Node")
+			logging.FromContext(ctx).Error("Failed to cast created entity to neo4j.Node")
 			return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] failed to cast created entity to neo4j.Node")
 		} else {
-			log.Printf("[neo4j_client.CreateGraphEntity] created entity(retrieved-initial): %v", createdEntity)
+			logging.FromContext(ctx).Debug("Retrieved created entity", logging.Payload("entity", createdEntity))
 		}
 
 		// Convert the node properties to a map
@@ -181,19 +210,25 @@ func (r *Neo4jRepository) CreateGraphEntity(ctx context.Context, kind *pb.Kind,
 			} else {
 				createdEntityMap["Terminated"] = fmt.Sprintf("%v", *terminated)
 			}
-		} else {
-			log.Printf("[neo4j_client.CreateGraphEntity] Terminated: %v", terminated)
 		}
-		log.Printf("[neo4j_client.CreateGraphEntity] created entity(retrieved-final): %v", createdEntityMap)
+		logging.FromContext(ctx).Debug("Converted created entity", logging.Payload("entity", createdEntityMap))
 		return createdEntityMap, nil
 	}
 
-	log.Printf("[neo4j_client.CreateGraphEntity] failed to create entity")
+	logging.FromContext(ctx).Error("Failed to create entity")
 	return nil, fmt.Errorf("[neo4j_client.CreateGraphEntity] failed to create entity")
 }
 
 // CreateRelationship creates a relationship between two entities
 func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID string, rel *pb.Relationship) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.CreateRelationship", tracing.EntityID(entityID), tracing.RelationshipID(rel.GetId()))
+	defer span.End()
+
+	properties, err := graphProperties(rel.Properties)
+	if err != nil {
+		return nil, err
+	}
+
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
 
@@ -203,12 +238,12 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 		"relationshipID": rel.Id,
 	})
 	if err != nil {
-		log.Printf("[neo4j_client.CreateRelationship] error checking if relationship exists: %v", err)
-		return nil, fmt.Errorf("error checking if relationship exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if relationship exists", "error", err)
+		return nil, fmt.Errorf("error checking if relationship exists: %w", err)
 	}
 	if relResult.Next(ctx) {
-		log.Printf("[neo4j_client.CreateRelationship] relationship with Id %s already exists", rel.Id)
-		return nil, fmt.Errorf("relationship with Id %s already exists", rel.Id)
+		logging.FromContext(ctx).Debug("Relationship with Id already exists", "relationship_id", rel.Id)
+		return nil, apperrors.AlreadyExistsf("relationship with Id %s already exists", rel.Id).WithEntity(entityID).WithRelationship(rel.Id)
 	}
 
 	existsQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID}) RETURN p, c`
@@ -217,16 +252,16 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 		"childID":  rel.RelatedEntityId,
 	})
 	if err != nil {
-		log.Printf("[neo4j_client.CreateRelationship] error checking entities: %v", err)
-		return nil, fmt.Errorf("error checking entities: %v", err)
+		logging.FromContext(ctx).Error("Error checking entities", "error", err)
+		return nil, fmt.Errorf("error checking entities: %w", err)
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] existsQuery: %v", existsQuery)
+		logging.FromContext(ctx).Debug("Checking if entity exists", "query", existsQuery)
 	}
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.CreateRelationship] either parent or child entity does not exist")
-		return nil, fmt.Errorf("either parent or child entity does not exist")
+		logging.FromContext(ctx).Debug("Either parent or child entity does not exist")
+		return nil, apperrors.FailedPreconditionf("either parent or child entity does not exist").WithEntity(entityID).WithRelationship(rel.Id)
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] either parent or child entity exist")
+		logging.FromContext(ctx).Debug("Either parent or child entity exist")
 	}
 
 	params := map[string]interface{}{
@@ -234,40 +269,42 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 		"childID":        rel.RelatedEntityId,
 		"relationshipID": rel.Id,
 		"startDate":      rel.StartTime,
+		"properties":     properties,
 	}
 
 	createQuery := `MATCH (p {Id: $parentID}), (c {Id: $childID})
-					CREATE (p)-[r:` + rel.Name + ` {Id: $relationshipID, Created: datetime($startDate)`
+					CREATE (p)-[r:` + quoteIdentifier(rel.Name) + ` {Id: $relationshipID, Created: datetime($startDate)`
 
 	if rel.EndTime != "" {
 		createQuery += `, Terminated: datetime($endDate)`
 		params["endDate"] = rel.EndTime
 	}
 
-	createQuery += `}]->(c) RETURN r`
+	createQuery += `}]->(c) SET r += $properties RETURN r`
 
 	result, err = session.Run(ctx, createQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.CreateRelationship] error creating relationship: %v", err)
-		return nil, fmt.Errorf("error creating relationship: %v", err)
+		logging.FromContext(ctx).Error("Error creating relationship", "error", err)
+		return nil, fmt.Errorf("error creating relationship: %w", err)
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] createQuery: %v", createQuery)
-		log.Printf("[neo4j_client.CreateRelationship] params: %v", params)
+		logging.FromContext(ctx).Debug("Creating relationship", "query", createQuery)
+		logging.FromContext(ctx).Debug("Relationship parameters", logging.Payload("params", params))
 	}
 
 	if result.Next(ctx) {
 		createdRel, _ := result.Record().Get("r")
 		relationship, ok := createdRel.(neo4j.Relationship)
 		if !ok {
-			log.Printf("[neo4j_client.CreateRelationship] failed to cast created relationship to neo4j.Relationship")
+			logging.FromContext(ctx).Error("Failed to cast created relationship to neo4j.Relationship")
 			return nil, fmt.Errorf("failed to cast created relationship to neo4j.Relationship")
 		} else {
-			log.Printf("[neo4j_client.CreateRelationship] created relationship: %v", createdRel)
+			logging.FromContext(ctx).Debug("Retrieved created relationship", logging.Payload("relationship", createdRel))
 		}
 
 		relationshipMap := map[string]interface{}{
 			"Id":               fmt.Sprintf("%v", relationship.Props["Id"]),
 			"relationshipType": rel.Name,
+			"Properties":       relationshipProperties(relationship.Props),
 		}
 
 		// Handle date fields with proper formatting
@@ -285,10 +322,10 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 			}
 		}
 
-		log.Printf("[neo4j_client.CreateRelationship] created relationship: %v", relationshipMap)
+		logging.FromContext(ctx).Debug("Converted created relationship", logging.Payload("relationship", relationshipMap))
 		return relationshipMap, nil
 	} else {
-		log.Printf("[neo4j_client.CreateRelationship] failed to retrieve created relationship: %v", result)
+		logging.FromContext(ctx).Error("Failed to retrieve created relationship", "result", result)
 	}
 
 	return nil, fmt.Errorf("failed to retrieve created relationship")
@@ -296,8 +333,11 @@ func (r *Neo4jRepository) CreateRelationship(ctx context.Context, entityID strin
 
 // ReadGraphEntity retrieves an entity by its ID from the Neo4j database and returns it as a map.
 func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadGraphEntity", tracing.EntityID(entityID))
+	defer span.End()
+
 	if entityID == "" {
-		return nil, fmt.Errorf("entity Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	// Open a session
@@ -309,14 +349,15 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
         MATCH (e {Id: $Id})
         RETURN labels(e)[0] AS MajorKind, e.MinorKind AS MinorKind, e.Id AS Id, e.Name AS Name, 
                toString(e.Created) AS Created, 
-               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated
+               CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS Terminated,
+               coalesce(e.Version, $InitialVersion) AS Version
     `
 
 	// Run the query
-	result, err := session.Run(ctx, query, map[string]interface{}{"Id": entityID})
+	result, err := session.Run(ctx, query, map[string]interface{}{"Id": entityID, "InitialVersion": InitialEntityVersion})
 	if err != nil {
-		log.Printf("[neo4j_client.ReadGraphEntity] error querying entity: %v", err)
-		return nil, fmt.Errorf("error querying entity: %v", err)
+		logging.FromContext(ctx).Error("Error querying entity", "error", err)
+		return nil, fmt.Errorf("error querying entity: %w", err)
 	}
 
 	// Process the result
@@ -330,6 +371,7 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 			"Created":   fmt.Sprintf("%v", record.Values[4]), // e.Created
 			"MajorKind": fmt.Sprintf("%v", record.Values[0]), // labels(e)[0]
 			"MinorKind": fmt.Sprintf("%v", record.Values[1]), // e.MinorKind
+			"Version":   record.Values[6],                    // e.Version
 		}
 
 		// Add Terminated if it exists
@@ -341,13 +383,16 @@ func (r *Neo4jRepository) ReadGraphEntity(ctx context.Context, entityID string)
 	}
 
 	// If no entity is found
-	return nil, fmt.Errorf("entity with Id %s not found", entityID)
+	return nil, apperrors.NotFoundf("entity with Id %s not found", entityID).WithEntity(entityID)
 }
 
 // ReadRelatedGraphEntityIds retrieves related relationships based on a given relationship type and timestamp
 func (r *Neo4jRepository) ReadRelatedGraphEntityIds(ctx context.Context, entityID string, relationship string, ts string) ([]map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadRelatedGraphEntityIds", tracing.EntityID(entityID))
+	defer span.End()
+
 	if entityID == "" {
-		return nil, fmt.Errorf("entity Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	session := r.getSession(ctx)
@@ -364,8 +409,8 @@ func (r *Neo4jRepository) ReadRelatedGraphEntityIds(ctx context.Context, entityI
 		"ts":       ts,
 	})
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelatedGraphEntityIds] error querying related entities: %v", err)
-		return nil, fmt.Errorf("error querying related entities: %v", err)
+		logging.FromContext(ctx).Error("Error querying related entities", "error", err)
+		return nil, fmt.Errorf("error querying related entities: %w", err)
 	}
 
 	var relationships []map[string]interface{}
@@ -414,17 +459,19 @@ func (r *Neo4jRepository) ReadRelatedGraphEntityIds(ctx context.Context, entityI
 	}
 
 	if err := result.Err(); err != nil {
-		log.Printf("[neo4j_client.ReadRelatedGraphEntityIds] error iterating over query result: %v", err)
-		return nil, fmt.Errorf("error iterating over query result: %v", err)
+		logging.FromContext(ctx).Error("Error iterating over query result", "error", err)
+		return nil, fmt.Errorf("error iterating over query result: %w", err)
 	}
 
 	return relationships, nil
 }
 
 func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string) ([]map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadRelationships", tracing.EntityID(entityID))
+	defer span.End()
 
 	if entityID == "" {
-		return nil, fmt.Errorf("entity Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	// Open session
@@ -437,13 +484,13 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
         RETURN type(r) AS type, related.Id AS relatedID, "OUTGOING" AS direction, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated,
-               r.Id AS relationshipID
+               r.Id AS relationshipID, properties(r) AS properties
         UNION
         MATCH (e {Id: $entityID})<-[r]-(related)
         RETURN type(r) AS type, related.Id AS relatedID, "INCOMING" AS direction, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated,
-               r.Id AS relationshipID
+               r.Id AS relationshipID, properties(r) AS properties
     `
 
 	// Run the query
@@ -451,8 +498,8 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
 		"entityID": entityID,
 	})
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelationships] error querying relationships: %v", err)
-		return nil, fmt.Errorf("error querying relationships: %v", err)
+		logging.FromContext(ctx).Error("Error querying relationships", "error", err)
+		return nil, fmt.Errorf("error querying relationships: %w", err)
 	}
 
 	// Process results
@@ -462,7 +509,7 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
 		values := record.Values
 
 		// Ensure expected values exist
-		if len(values) < 6 {
+		if len(values) < 7 {
 			continue
 		}
 
@@ -474,6 +521,9 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
 			"Created":        fmt.Sprintf("%v", values[3]),
 			"relationshipID": fmt.Sprintf("%v", values[5]), // Relationship ID
 		}
+		if props, ok := values[6].(map[string]interface{}); ok {
+			rel["Properties"] = relationshipProperties(props)
+		}
 
 		// Optional Terminated
 		if values[4] != nil {
@@ -488,9 +538,11 @@ func (r *Neo4jRepository) ReadRelationships(ctx context.Context, entityID string
 }
 
 func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID string) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadRelationship", tracing.RelationshipID(relationshipID))
+	defer span.End()
 
 	if relationshipID == "" {
-		return nil, fmt.Errorf("relationship Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("relationship Id cannot be empty").WithField("relationships.id")
 	}
 
 	session := r.getSession(ctx)
@@ -503,7 +555,7 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
         RETURN type(r) AS type, startNode(r).Id AS startEntityID, endNode(r).Id AS endEntityID, 
                toString(r.Created) AS Created, 
                CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS Terminated, 
-               r.Id AS relationshipID
+               r.Id AS relationshipID, properties(r) AS properties
     `
 
 	// Run the query to fetch the relationship
@@ -511,8 +563,8 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 		"relationshipID": relationshipID,
 	})
 	if err != nil {
-		log.Printf("[neo4j_client.ReadRelationship] error querying relationship: %v", err)
-		return nil, fmt.Errorf("error querying relationship: %v", err)
+		logging.FromContext(ctx).Error("Error querying relationship", "error", err)
+		return nil, fmt.Errorf("error querying relationship: %w", err)
 	}
 
 	// Process results
@@ -521,8 +573,8 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 		values := record.Values
 
 		// Ensure expected values exist
-		if len(values) < 6 {
-			log.Printf("[neo4j_client.ReadRelationship] unexpected data format for relationship")
+		if len(values) < 7 {
+			logging.FromContext(ctx).Debug("Unexpected data format for relationship")
 			return nil, fmt.Errorf("unexpected data format for relationship")
 		}
 
@@ -534,6 +586,9 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 			"Created":        fmt.Sprintf("%v", values[3]),
 			"relationshipID": fmt.Sprintf("%v", values[5]), // Relationship ID
 		}
+		if props, ok := values[6].(map[string]interface{}); ok {
+			relationship["Properties"] = relationshipProperties(props)
+		}
 
 		// Optional Terminated
 		if values[4] != nil {
@@ -545,13 +600,16 @@ func (r *Neo4jRepository) ReadRelationship(ctx context.Context, relationshipID s
 	}
 
 	// If no relationship was found
-	return nil, fmt.Errorf("relationship with Id %s not found", relationshipID)
+	return nil, apperrors.NotFoundf("relationship with Id %s not found", relationshipID).WithRelationship(relationshipID)
 }
 
 // UpdateGraphEntity updates the properties of an existing entity
 func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, updateData map[string]interface{}) (map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.UpdateGraphEntity", tracing.EntityID(id))
+	defer span.End()
+
 	if id == "" {
-		return nil, fmt.Errorf("entity Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	// Prepare update parameters
@@ -567,13 +625,13 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	existsQuery := `MATCH (e {Id: $Id}) RETURN e`
 	result, err := session.Run(ctx, existsQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateGraphEntity] error checking if entity exists: %v", err)
-		return nil, fmt.Errorf("error checking if entity exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if entity exists", "error", err)
+		return nil, fmt.Errorf("error checking if entity exists: %w", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.UpdateGraphEntity] entity with Id %s does not exist", id)
-		return nil, fmt.Errorf("entity with Id %s does not exist", id)
+		logging.FromContext(ctx).Debug("Entity with Id does not exist", "entity_id", id)
+		return nil, apperrors.NotFoundf("entity with Id %s does not exist", id).WithEntity(id)
 	}
 
 	// Build Cypher query for updating entity
@@ -598,15 +656,15 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 
 	result, err = session.Run(ctx, query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateGraphEntity] error updating entity: %v", err)
-		return nil, fmt.Errorf("error updating entity: %v", err)
+		logging.FromContext(ctx).Error("Error updating entity", "error", err)
+		return nil, fmt.Errorf("error updating entity: %w", err)
 	}
 
 	// Retrieve updated entity
 	if result.Next(ctx) {
 		node, ok := result.Record().Get("e")
 		if !ok {
-			log.Printf("[neo4j_client.UpdateGraphEntity] unexpected error retrieving entity")
+			logging.FromContext(ctx).Debug("Unexpected error retrieving entity")
 			return nil, fmt.Errorf("unexpected error retrieving entity")
 		}
 
@@ -631,12 +689,86 @@ func (r *Neo4jRepository) UpdateGraphEntity(ctx context.Context, id string, upda
 	return nil, fmt.Errorf("failed to retrieve updated entity")
 }
 
+// BumpGraphEntityVersion increments the version of an entity and returns the versions before and after.
+// Unless expected is 0 the entity must be at the expected version, otherwise it fails with Aborted
+// and the version is left as it is.
+func (r *Neo4jRepository) BumpGraphEntityVersion(ctx context.Context, id string, expected int64) (int64, int64, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.BumpGraphEntityVersion", tracing.EntityID(id))
+	defer span.End()
+
+	if id == "" {
+		return 0, 0, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
+	}
+	if expected < 0 {
+		return 0, 0, apperrors.InvalidArgumentf("expected version %d must not be negative", expected).WithField("expectedVersion").WithEntity(id)
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	// Setting a property first locks the node, so that concurrent updates read the version one after the other
+	query := `
+        MATCH (e {Id: $Id})
+        SET e._LOCK_ = true
+        WITH e, coalesce(e.Version, $InitialVersion) AS Current
+        SET e.Version = CASE WHEN $Expected = 0 OR Current = $Expected THEN Current + 1 ELSE Current END
+        REMOVE e._LOCK_
+        RETURN Current, e.Version AS Version
+    `
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"Id":             id,
+		"Expected":       expected,
+		"InitialVersion": InitialEntityVersion,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating entity version", "error", err)
+		return 0, 0, fmt.Errorf("error updating entity version: %w", err)
+	}
+
+	if !result.Next(ctx) {
+		if err := result.Err(); err != nil {
+			return 0, 0, fmt.Errorf("error updating entity version: %w", err)
+		}
+		return 0, 0, apperrors.NotFoundf("entity with Id %s does not exist", id).WithEntity(id)
+	}
+	previous, _ := result.Record().Values[0].(int64)
+	version, _ := result.Record().Values[1].(int64)
+	if version == previous {
+		return 0, 0, apperrors.Abortedf("entity %s is at version %d, not the expected version %d", id, previous, expected).WithEntity(id).WithField("expectedVersion")
+	}
+	return previous, version, nil
+}
+
+// RestoreGraphEntityVersion sets the version of an entity back to previous, unless another update
+// advanced it beyond version since
+func (r *Neo4jRepository) RestoreGraphEntityVersion(ctx context.Context, id string, version int64, previous int64) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.RestoreGraphEntityVersion", tracing.EntityID(id))
+	defer span.End()
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	query := `MATCH (e {Id: $Id}) WHERE e.Version = $Version SET e.Version = $Previous`
+	result, err := session.Run(ctx, query, map[string]interface{}{"Id": id, "Version": version, "Previous": previous})
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("Error restoring entity version", "error", err)
+		return fmt.Errorf("error restoring entity version: %w", err)
+	}
+	return nil
+}
+
 func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID string, updateData map[string]interface{}) (map[string]interface{}, error) {
-	log.Printf("[neo4j_client.UpdateRelationship] Updating relationship %s with data: %+v", relationshipID, updateData)
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.UpdateRelationship", tracing.RelationshipID(relationshipID))
+	defer span.End()
+
+	logging.FromContext(ctx).Debug("Updating relationship with data", "relationship_id", relationshipID, logging.Payload("update", updateData))
 
 	if relationshipID == "" {
-		log.Printf("[neo4j_client.UpdateRelationship] relationship Id cannot be empty")
-		return nil, fmt.Errorf("relationship Id cannot be empty")
+		logging.FromContext(ctx).Debug("Relationship Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("relationship Id cannot be empty").WithField("relationships.id")
 	}
 
 	// Prepare update parameters
@@ -652,14 +784,15 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 	existsQuery := `MATCH ()-[r {Id: $relationshipID}]->() RETURN r`
 	result, err := session.Run(ctx, existsQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateRelationship] error checking if relationship exists: %v", err)
-		return nil, fmt.Errorf("error checking if relationship exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if relationship exists", "error", err)
+		return nil, fmt.Errorf("error checking if relationship exists: %w", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.UpdateRelationship] relationship with Id %s does not exist", relationshipID)
-		return nil, fmt.Errorf("relationship with Id %s does not exist", relationshipID)
+		logging.FromContext(ctx).Debug("Relationship with Id does not exist", "relationship_id", relationshipID)
+		return nil, apperrors.NotFoundf("relationship with Id %s does not exist", relationshipID).WithRelationship(relationshipID)
 	}
+	existing, _ := result.Record().Get("r")
 
 	// Build Cypher query for updating relationship
 	query := `
@@ -691,18 +824,38 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 		}
 	}
 
+	// Add `Properties` if provided, they replace every property of the relationship
+	if properties, exists := updateData["Properties"]; exists {
+		anyProperties, _ := properties.(map[string]*anypb.Any)
+		values, err := graphProperties(anyProperties)
+		if err != nil {
+			return nil, err
+		}
+		// Setting a property to null removes it
+		if existingRel, ok := existing.(neo4j.Relationship); ok {
+			for key := range existingRel.Props {
+				if _, kept := values[key]; !kept && strings.HasPrefix(key, relationshipPropertyPrefix) {
+					values[key] = nil
+				}
+			}
+		}
+		params["Properties"] = values
+		query += `SET r += $Properties `
+		hasUpdates = true
+	}
+
 	// Check for any unsupported fields
 	for key := range updateData {
-		if key != "Created" && key != "Terminated" {
-			log.Printf("[neo4j_client.UpdateRelationship] unsupported field '%s' provided for update", key)
-			return nil, fmt.Errorf("unsupported field '%s' for relationship update. Only 'Created' and 'Terminated' are allowed", key)
+		if key != "Created" && key != "Terminated" && key != "Properties" {
+			logging.FromContext(ctx).Debug("Unsupported field provided for update", "key", key)
+			return nil, apperrors.InvalidArgumentf("unsupported field '%s' for relationship update. Only 'Created', 'Terminated' and 'Properties' are allowed", key).WithField(key)
 		}
 	}
 
 	// If no fields to update, return error
 	if !hasUpdates {
-		log.Printf("[neo4j_client.UpdateRelationship] no valid fields provided for update")
-		return nil, fmt.Errorf("no valid fields provided for update")
+		logging.FromContext(ctx).Debug("No valid fields provided for update")
+		return nil, apperrors.InvalidArgumentf("no valid fields provided for update")
 	}
 
 	query += `RETURN r`
@@ -710,15 +863,15 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 	// Execute update query and return updated relationship
 	result, err = session.Run(ctx, query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.UpdateRelationship] error updating relationship: %v", err)
-		return nil, fmt.Errorf("error updating relationship: %v", err)
+		logging.FromContext(ctx).Error("Error updating relationship", "error", err)
+		return nil, fmt.Errorf("error updating relationship: %w", err)
 	}
 
 	// Retrieve updated relationship
 	if result.Next(ctx) {
 		rel, ok := result.Record().Get("r")
 		if !ok {
-			log.Printf("[neo4j_client.UpdateRelationship] unexpected error retrieving relationship")
+			logging.FromContext(ctx).Debug("Unexpected error retrieving relationship")
 			return nil, fmt.Errorf("unexpected error retrieving relationship")
 		}
 
@@ -726,6 +879,9 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 		relationship := rel.(neo4j.Relationship)
 		updatedRelationship := make(map[string]interface{})
 		for key, value := range relationship.Props {
+			if strings.HasPrefix(key, relationshipPropertyPrefix) {
+				continue
+			}
 			if key == "Created" || key == "Terminated" {
 				if timeValue, ok := value.(time.Time); ok {
 					updatedRelationship[key] = timeValue.Format(time.RFC3339)
@@ -736,6 +892,7 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 				updatedRelationship[key] = fmt.Sprintf("%v", value)
 			}
 		}
+		updatedRelationship["Properties"] = relationshipProperties(relationship.Props)
 
 		return updatedRelationship, nil
 	}
@@ -744,8 +901,11 @@ func (r *Neo4jRepository) UpdateRelationship(ctx context.Context, relationshipID
 }
 
 func (r *Neo4jRepository) DeleteRelationship(ctx context.Context, relationshipID string) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.DeleteRelationship", tracing.RelationshipID(relationshipID))
+	defer span.End()
+
 	if relationshipID == "" {
-		return fmt.Errorf("entity Id cannot be empty")
+		return apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	// Prepare query parameters
@@ -761,22 +921,22 @@ func (r *Neo4jRepository) DeleteRelationship(ctx context.Context, relationshipID
 	query := `MATCH ()-[r {Id: $relationshipID}]->() RETURN r`
 	result, err := session.Run(ctx, query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteRelationship] error checking if relationship exists: %v", err)
-		return fmt.Errorf("error checking if relationship exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if relationship exists", "error", err)
+		return fmt.Errorf("error checking if relationship exists: %w", err)
 	}
 
 	// If no relationship is found, return an error
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.DeleteRelationship] relationship with Id %s does not exist", relationshipID)
-		return fmt.Errorf("relationship with Id %s does not exist", relationshipID)
+		logging.FromContext(ctx).Debug("Relationship with Id does not exist", "relationship_id", relationshipID)
+		return apperrors.NotFoundf("relationship with Id %s does not exist", relationshipID).WithRelationship(relationshipID)
 	}
 
 	// Delete the relationship
 	deleteQuery := `MATCH ()-[r {Id: $relationshipID}]->() DELETE r`
 	_, err = session.Run(ctx, deleteQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteRelationship] error deleting relationship: %v", err)
-		return fmt.Errorf("error deleting relationship: %v", err)
+		logging.FromContext(ctx).Error("Error deleting relationship", "error", err)
+		return fmt.Errorf("error deleting relationship: %w", err)
 	}
 
 	return nil
@@ -784,9 +944,12 @@ func (r *Neo4jRepository) DeleteRelationship(ctx context.Context, relationshipID
 
 // DeleteGraphEntity deletes an entity by its ID
 func (r *Neo4jRepository) DeleteGraphEntity(ctx context.Context, entityID string) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.DeleteGraphEntity", tracing.EntityID(entityID))
+	defer span.End()
+
 	if entityID == "" {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity Id cannot be empty")
-		return fmt.Errorf("entity Id cannot be empty")
+		logging.FromContext(ctx).Debug("Entity Id cannot be empty")
+		return apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	session := r.getSession(ctx)
@@ -799,40 +962,61 @@ func (r *Neo4jRepository) DeleteGraphEntity(ctx context.Context, entityID string
 
 	result, err := session.Run(ctx, query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error checking if entity exists: %v", err)
-		return fmt.Errorf("error checking if entity exists: %v", err)
+		logging.FromContext(ctx).Error("Error checking if entity exists", "error", err)
+		return fmt.Errorf("error checking if entity exists: %w", err)
 	}
 
 	if !result.Next(ctx) {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity with Id %s does not exist", entityID)
-		return fmt.Errorf("entity with Id %s does not exist", entityID)
+		logging.FromContext(ctx).Debug("Entity with Id does not exist", "entity_id", entityID)
+		return apperrors.NotFoundf("entity with Id %s does not exist", entityID).WithEntity(entityID)
 	}
 
 	// Get the relationships of the entity
 	relationships, err := r.ReadRelationships(ctx, entityID)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error getting relationships: %v", err)
-		return fmt.Errorf("error getting relationships: %v", err)
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
+		return fmt.Errorf("error getting relationships: %w", err)
 	}
 
 	// If there are relationships, return an error with relationship details
 	if len(relationships) > 0 {
-		log.Printf("[neo4j_client.DeleteGraphEntity] entity has relationships and cannot be deleted. Relationships: %v", relationships)
-		return fmt.Errorf("entity has relationships and cannot be deleted. Relationships: %v", relationships)
+		logging.FromContext(ctx).Debug("Entity has relationships and cannot be deleted", "relationships", relationships)
+		return apperrors.FailedPreconditionf("entity has relationships and cannot be deleted. Relationships: %v", relationships).WithEntity(entityID)
 	}
 
 	// Delete the entity (node) with the given Id
 	deleteQuery := `MATCH (e {Id: $entityID}) DELETE e`
 	_, err = session.Run(ctx, deleteQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.DeleteGraphEntity] error deleting entity: %v", err)
-		return fmt.Errorf("error deleting entity: %v", err)
+		logging.FromContext(ctx).Error("Error deleting entity", "error", err)
+		return fmt.Errorf("error deleting entity: %w", err)
 	}
 
 	return nil
 }
 
+// FilterEntities retrieves every entity matching the filters, ordered by id, reading them page by page
 func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, filters map[string]interface{}) ([]map[string]interface{}, error) {
+	var entities []map[string]interface{}
+	request := &EntityPageRequest{PageSize: MaxPageSize}
+	for {
+		page, err := r.FilterEntitiesPage(ctx, kind, filters, request)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, page.Entities...)
+		if page.Next == nil {
+			return entities, nil
+		}
+		request.After = page.Next
+	}
+}
+
+// FilterEntitiesPage retrieves a page of the entities matching the filters, see EntityPageRequest
+func (r *Neo4jRepository) FilterEntitiesPage(ctx context.Context, kind *pb.Kind, filters map[string]interface{}, page *EntityPageRequest) (*EntityPage, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.FilterEntitiesPage")
+	defer span.End()
+
 	// Open a session
 	session := r.getSession(ctx)
 	defer session.Close(ctx)
@@ -842,25 +1026,18 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 
 	// If we have an ID filter, use a simpler query
 	if id, ok := filters["id"].(string); ok && id != "" {
-		query = `
-			MATCH (e {Id: $id})
-			RETURN e.Id AS id, labels(e)[0] AS kind, 
-				   toString(e.Created) AS created, 
-				   CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS terminated, 
-				   e.Name AS name, 
-				   e.MinorKind AS minorKind
-		`
+		query = `MATCH (e {Id: $id}) WHERE 1=1 `
 		params = map[string]interface{}{
 			"id": id,
 		}
 	} else {
 		// Original query for other filters
 		if kind == nil || kind.Major == "" {
-			return nil, fmt.Errorf("kind.Major is required")
+			return nil, apperrors.InvalidArgumentf("kind.Major is required").WithField("kind.major")
 		}
 
 		// Start building the Cypher query
-		query = `MATCH (e:` + kind.Major + `) WHERE 1=1 ` // Use kind.Major as the label
+		query = `MATCH (e:` + quoteIdentifier(kind.Major) + `) WHERE 1=1 ` // Use kind.Major as the label
 		params = map[string]interface{}{}
 
 		// Add MinorKind filter if provided
@@ -886,22 +1063,64 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 			query += `AND e.Name =~ $namePattern `
 			params["namePattern"] = namePattern
 		}
+	}
 
-		// Return the matched entities
-		query += `
-			RETURN e.Id AS id, labels(e)[0] AS kind, 
-				   toString(e.Created) AS created, 
-				   CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS terminated, 
-				   e.Name AS name, 
-				   e.MinorKind AS minorKind
-		`
+	// An entity exists from its creation until its termination
+	if activeAt, ok := filters["activeAt"].(string); ok && activeAt != "" {
+		query += `AND e.Created <= datetime($activeAt) AND (e.Terminated IS NULL OR e.Terminated > datetime($activeAt)) `
+		params["activeAt"] = activeAt
 	}
 
+	// The count ignores the page, so it is taken before the cursor is applied
+	var totalCount int64
+	if page.IncludeTotalCount {
+		countResult, err := session.Run(ctx, query+`RETURN count(e) AS total`, params)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error counting entities", "error", err)
+			return nil, fmt.Errorf("error counting entities: %w", err)
+		}
+		if countResult.Next(ctx) {
+			totalCount, _ = countResult.Record().Values[0].(int64)
+		}
+		if err := countResult.Err(); err != nil {
+			logging.FromContext(ctx).Error("Error counting entities", "error", err)
+			return nil, fmt.Errorf("error counting entities: %w", err)
+		}
+	}
+
+	if page.After != nil && (page.After.OrderBy != page.OrderBy || page.After.Descending != page.Descending) {
+		return nil, apperrors.InvalidArgumentf("page token does not match the requested order").WithField("pageToken")
+	}
+	orderBy, after := page.orderClause(params)
+	if after != "" {
+		query += `AND ` + after + ` `
+	}
+
+	// Return the matched entities
+	query += `
+		RETURN e.Id AS id, labels(e)[0] AS kind, 
+			   toString(e.Created) AS created, 
+			   CASE WHEN e.Terminated IS NOT NULL THEN toString(e.Terminated) ELSE NULL END AS terminated, 
+			   e.Name AS name, 
+			   e.MinorKind AS minorKind,
+			   coalesce(e.Version, $initialVersion) AS version
+	` + orderBy
+	params["initialVersion"] = InitialEntityVersion
+
+	// One entity more than the page size tells whether there is a next page
+	pageSize := page.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+	query += ` LIMIT $limit`
+	params["limit"] = pageSize + 1
+
 	// Run the query
 	result, err := session.Run(ctx, query, params)
 	if err != nil {
-		log.Printf("[neo4j_client.FilterEntities] error querying entities: %v", err)
-		return nil, fmt.Errorf("error querying entities: %v", err)
+		logging.FromContext(ctx).Error("Error querying entities", "error", err)
+		return nil, fmt.Errorf("error querying entities: %w", err)
 	}
 
 	// Process the results
@@ -916,6 +1135,7 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 			"terminated": record.Values[3], // e.Terminated
 			"name":       record.Values[4], // e.Name
 			"minorKind":  record.Values[5], // e.MinorKind
+			"version":    record.Values[6], // e.Version
 		}
 
 		entities = append(entities, entity)
@@ -923,17 +1143,27 @@ func (r *Neo4jRepository) FilterEntities(ctx context.Context, kind *pb.Kind, fil
 
 	// Check for errors during iteration
 	if err := result.Err(); err != nil {
-		log.Printf("[neo4j_client.FilterEntities] error iterating over query results: %v", err)
-		return nil, fmt.Errorf("error iterating over query results: %v", err)
+		logging.FromContext(ctx).Error("Error iterating over query results", "error", err)
+		return nil, fmt.Errorf("error iterating over query results: %w", err)
 	}
 
-	return entities, nil
+	entityPage := &EntityPage{TotalCount: totalCount}
+	if len(entities) > pageSize {
+		entities = entities[:pageSize]
+		entityPage.Next = page.cursorOf(entities[pageSize-1])
+	}
+	entityPage.Entities = entities
+
+	return entityPage, nil
 }
 
 // ReadFilteredRelationships retrieves relationships for an entity based on provided filters
 func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityID string, relationshipFilters map[string]interface{}, activeAt string) ([]map[string]interface{}, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ReadFilteredRelationships", tracing.EntityID(entityID))
+	defer span.End()
+
 	if entityID == "" {
-		return nil, fmt.Errorf("entity Id cannot be empty")
+		return nil, apperrors.InvalidArgumentf("entity Id cannot be empty").WithField("id")
 	}
 
 	session := r.getSession(ctx)
@@ -1001,6 +1231,20 @@ func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityI
 		paramIndex++
 	}
 
+	// Every property filtered on has to have the value, compared by key so that property names need no escaping
+	if properties, ok := relationshipFilters["properties"].(map[string]interface{}); ok {
+		for key, value := range properties {
+			keyParam := fmt.Sprintf("propertyKey%d", paramIndex)
+			valueParam := fmt.Sprintf("propertyValue%d", paramIndex)
+			params[keyParam] = key
+			params[valueParam] = value
+			propertyCondition := fmt.Sprintf(` AND r[$%s] = $%s`, keyParam, valueParam)
+			outgoingQuery += propertyCondition
+			incomingQuery += propertyCondition
+			paramIndex++
+		}
+	}
+
 	// Add activeAt filter if provided
 	if activeAt != "" {
 		paramName := fmt.Sprintf("activeAt%d", paramIndex)
@@ -1016,14 +1260,14 @@ func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityI
 		RETURN r.Id AS relationshipID, type(r) AS name, related.Id AS relatedEntityId, 
 		       toString(r.Created) AS startTime, 
 		       CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS endTime,
-		       "OUTGOING" AS direction
+		       "OUTGOING" AS direction, properties(r) AS properties
 	`
 
 	incomingQuery += `
 		RETURN r.Id AS relationshipID, type(r) AS name, related.Id AS relatedEntityId, 
 		       toString(r.Created) AS startTime, 
 		       CASE WHEN r.Terminated IS NOT NULL THEN toString(r.Terminated) ELSE NULL END AS endTime,
-		       "INCOMING" AS direction
+		       "INCOMING" AS direction, properties(r) AS properties
 	`
 
 	// Determine which queries to run based on direction filter
@@ -1048,8 +1292,8 @@ func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityI
 	// Execute the query
 	result, err := session.Run(ctx, finalQuery, params)
 	if err != nil {
-		log.Printf("[neo4j_client.ReadFilteredRelationships] error querying relationships: %v", err)
-		return nil, fmt.Errorf("error querying relationships: %v", err)
+		logging.FromContext(ctx).Error("Error querying relationships", "error", err)
+		return nil, fmt.Errorf("error querying relationships: %w", err)
 	}
 
 	// Process results
@@ -1064,6 +1308,7 @@ func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityI
 		startTime, _ := record.Get("startTime")
 		endTime, _ := record.Get("endTime")
 		direction, _ := record.Get("direction")
+		props, _ := record.Get("properties")
 
 		// Ensure the relationship ID exists
 		if relationshipID == nil {
@@ -1096,14 +1341,139 @@ func (r *Neo4jRepository) ReadFilteredRelationships(ctx context.Context, entityI
 			"endTime":         formattedEndTime,
 			"direction":       fmt.Sprintf("%v", direction),
 		}
+		if props, ok := props.(map[string]interface{}); ok {
+			relationship["properties"] = relationshipProperties(props)
+		}
 
 		relationships = append(relationships, relationship)
 	}
 
 	if err := result.Err(); err != nil {
-		log.Printf("[neo4j_client.ReadFilteredRelationships] error iterating over query result: %v", err)
-		return nil, fmt.Errorf("error iterating over query result: %v", err)
+		logging.FromContext(ctx).Error("Error iterating over query result", "error", err)
+		return nil, fmt.Errorf("error iterating over query result: %w", err)
 	}
 
 	return relationships, nil
 }
+
+// ExistingEntityIds returns which of the given entity ids already exist in the graph
+func (r *Neo4jRepository) ExistingEntityIds(ctx context.Context, entityIDs []string) (map[string]bool, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ExistingEntityIds")
+	defer span.End()
+
+	existing := make(map[string]bool)
+	if len(entityIDs) == 0 {
+		return existing, nil
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `MATCH (e) WHERE e.Id IN $ids RETURN e.Id AS Id`, map[string]interface{}{"ids": entityIDs})
+	if err != nil {
+		logging.FromContext(ctx).Error("Error checking if entities exist", "error", err)
+		return nil, fmt.Errorf("error checking if entities exist: %w", err)
+	}
+	for result.Next(ctx) {
+		if id, ok := result.Record().Values[0].(string); ok {
+			existing[id] = true
+		}
+	}
+	if err := result.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error iterating over query result", "error", err)
+		return nil, fmt.Errorf("error iterating over query result: %w", err)
+	}
+
+	return existing, nil
+}
+
+// ExistingRelationshipIds returns which of the given relationship ids already exist in the graph
+func (r *Neo4jRepository) ExistingRelationshipIds(ctx context.Context, relationshipIDs []string) (map[string]bool, error) {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.ExistingRelationshipIds")
+	defer span.End()
+
+	existing := make(map[string]bool)
+	if len(relationshipIDs) == 0 {
+		return existing, nil
+	}
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, `MATCH ()-[r]->() WHERE r.Id IN $ids RETURN r.Id AS Id`, map[string]interface{}{"ids": relationshipIDs})
+	if err != nil {
+		logging.FromContext(ctx).Error("Error checking if relationships exist", "error", err)
+		return nil, fmt.Errorf("error checking if relationships exist: %w", err)
+	}
+	for result.Next(ctx) {
+		if id, ok := result.Record().Values[0].(string); ok {
+			existing[id] = true
+		}
+	}
+	if err := result.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error iterating over query result", "error", err)
+		return nil, fmt.Errorf("error iterating over query result: %w", err)
+	}
+
+	return existing, nil
+}
+
+// CreateGraphEntities creates entity nodes in a single transaction with one UNWIND query per Kind.Major.
+// The nodes are keyed by Kind.Major and each node carries Id, Name, Created, MinorKind and an optional Terminated.
+// Existence is not checked here, see ExistingEntityIds.
+func (r *Neo4jRepository) CreateGraphEntities(ctx context.Context, nodes map[string][]map[string]interface{}) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.CreateGraphEntities")
+	defer span.End()
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for major, rows := range nodes {
+			// A missing Terminated evaluates to null and is not stored
+			query := `UNWIND $rows AS row
+				CREATE (e:` + quoteIdentifier(major) + ` {Id: row.Id, Name: row.Name, Created: datetime(row.Created), MinorKind: row.MinorKind, Terminated: datetime(row.Terminated), Version: $version})`
+			if _, err := tx.Run(ctx, query, map[string]interface{}{"rows": rows, "version": InitialEntityVersion}); err != nil {
+				return nil, fmt.Errorf("error creating %s entities: %w", major, err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Error creating entities", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateRelationships creates relationships in a single transaction with one UNWIND query per relationship name.
+// The relationships are keyed by name and each one carries parentID, childID, Id, Created, an optional Terminated
+// and the properties stored on the edge.
+// Existence of the relationships and their entities is not checked here.
+func (r *Neo4jRepository) CreateRelationships(ctx context.Context, relationships map[string][]map[string]interface{}) error {
+	ctx, span := tracing.Start(ctx, "Neo4jRepository.CreateRelationships")
+	defer span.End()
+
+	session := r.getSession(ctx)
+	defer session.Close(ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for name, rows := range relationships {
+			query := `UNWIND $rows AS row
+				MATCH (p {Id: row.parentID}), (c {Id: row.childID})
+				CREATE (p)-[r:` + quoteIdentifier(name) + ` {Id: row.Id, Created: datetime(row.Created), Terminated: datetime(row.Terminated)}]->(c)
+				SET r += row.properties`
+			if _, err := tx.Run(ctx, query, map[string]interface{}{"rows": rows}); err != nil {
+				return nil, fmt.Errorf("error creating %s relationships: %w", name, err)
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Error creating relationships", "error", err)
+		return err
+	}
+
+	return nil
+}
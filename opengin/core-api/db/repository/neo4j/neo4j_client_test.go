@@ -11,8 +11,11 @@ import (
 
 	"lk/datafoundation/core-api/db/config"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var repository *Neo4jRepository
@@ -71,6 +74,12 @@ func TestMain(m *testing.M) {
 }
 
 // TestCreateEntity tests the CreateGraphEntity method of the Neo4jRepository
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`Person`", quoteIdentifier("Person"))
+	assert.Equal(t, "`Person) DETACH DELETE (n`", quoteIdentifier("Person) DETACH DELETE (n"))
+	assert.Equal(t, "`A``B`", quoteIdentifier("A`B"), "Expected backticks to be doubled")
+}
+
 func TestCreateEntity(t *testing.T) {
 	ctx := context.Background()
 	// defer cleanupDatabase(ctx, repository)
@@ -394,6 +403,52 @@ func TestUpdateEntity(t *testing.T) {
 	assert.Equal(t, "2025-12-31T00:00:00Z", entity["Terminated"], "Expected database to have updated dateEnded")
 }
 
+func TestBumpGraphEntityVersion(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{
+		Major: "Person",
+		Minor: "Minister",
+	}
+	entityData := map[string]interface{}{
+		"Id":      "version_entity_1",
+		"Name":    "Anne",
+		"Created": "2025-03-18",
+	}
+	_, err := repository.CreateGraphEntity(ctx, kind, entityData)
+	assert.Nil(t, err, "Expected no error when creating entity")
+
+	entity, err := repository.ReadGraphEntity(ctx, "version_entity_1")
+	assert.Nil(t, err, "Expected no error when reading entity")
+	assert.Equal(t, InitialEntityVersion, entity["Version"], "Expected a new entity to be at the initial version")
+
+	previous, version, err := repository.BumpGraphEntityVersion(ctx, "version_entity_1", 1)
+	assert.Nil(t, err, "Expected no error when updating the expected version")
+	assert.Equal(t, int64(1), previous)
+	assert.Equal(t, int64(2), version)
+
+	// A caller still holding version 1 is rejected
+	_, _, err = repository.BumpGraphEntityVersion(ctx, "version_entity_1", 1)
+	assert.True(t, apperrors.Is(err, apperrors.Aborted), "Expected Aborted for an outdated version, got %v", err)
+
+	// Without an expected version any version is updated
+	_, version, err = repository.BumpGraphEntityVersion(ctx, "version_entity_1", 0)
+	assert.Nil(t, err, "Expected no error when updating without an expected version")
+	assert.Equal(t, int64(3), version)
+
+	// Restoring is skipped once the version moved on
+	err = repository.RestoreGraphEntityVersion(ctx, "version_entity_1", 2, 1)
+	assert.Nil(t, err)
+	err = repository.RestoreGraphEntityVersion(ctx, "version_entity_1", 3, 2)
+	assert.Nil(t, err)
+	entity, err = repository.ReadGraphEntity(ctx, "version_entity_1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), entity["Version"], "Expected only the latest version to be restored")
+
+	_, _, err = repository.BumpGraphEntityVersion(ctx, "version_missing_entity", 0)
+	assert.True(t, apperrors.Is(err, apperrors.NotFound), "Expected NotFound for a missing entity, got %v", err)
+}
+
 func TestUpdateRelationship(t *testing.T) {
 	ctx := context.Background()
 
@@ -499,6 +554,7 @@ func TestDeleteRelationship(t *testing.T) {
 	deletedRelationship, err := repository.ReadRelationship(ctx, "delete_rel_test")
 	assert.NotNil(t, err, "Expected error when fetching deleted relationship")
 	assert.Contains(t, err.Error(), "not found", "Expected error message to indicate relationship not found")
+	assert.True(t, apperrors.Is(err, apperrors.NotFound), "Expected a NotFound error")
 	assert.Nil(t, deletedRelationship, "Expected relationship to be nil after deletion")
 }
 
@@ -527,6 +583,7 @@ func TestDeleteEntity(t *testing.T) {
 	_, err = repository.ReadGraphEntity(ctx, "delete_entity_1")
 	assert.NotNil(t, err, "Expected error when fetching deleted entity")
 	assert.Contains(t, err.Error(), "not found", "Expected error message to indicate entity not found")
+	assert.True(t, apperrors.Is(err, apperrors.NotFound), "Expected a NotFound error")
 
 	// Test deleting an entity with relationships (should fail)
 	// Create two entities
@@ -719,6 +776,7 @@ func TestCreateRelationshipWithDuplicateId(t *testing.T) {
 	// Verify that the creation failed
 	assert.NotNil(t, err, "Expected error when creating relationship with duplicate ID")
 	assert.Contains(t, err.Error(), "already exists", "Expected error message to indicate relationship already exists")
+	assert.True(t, apperrors.Is(err, apperrors.AlreadyExists), "Expected an AlreadyExists error")
 	assert.Nil(t, createdDup, "Expected no relationship to be returned when creation fails")
 	log.Printf("Duplicate creation error (expected): %v", err)
 
@@ -901,6 +959,85 @@ func TestUpdateRelationshipFields(t *testing.T) {
 	assert.Equal(t, totalRelsBefore, len(allRelsFinal), "Expected same number of relationships at end of all updates")
 }
 
+func TestRelationshipProperties(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "Organisation", Minor: "Department"}
+	for _, id := range []string{"properties_entity_1", "properties_entity_2"} {
+		_, err := repository.CreateGraphEntity(ctx, kind, map[string]interface{}{
+			"Id":      id,
+			"Name":    id,
+			"Created": "2025-01-01T00:00:00Z",
+		})
+		assert.Nil(t, err, "Expected no error when creating entity %s", id)
+	}
+
+	role, _ := anypb.New(wrapperspb.String("director"))
+	share, _ := anypb.New(wrapperspb.Double(0.5))
+	_, err := repository.CreateRelationship(ctx, "properties_entity_1", &pb.Relationship{
+		Id:              "properties_rel",
+		RelatedEntityId: "properties_entity_2",
+		Name:            "OWNS",
+		StartTime:       "2025-01-01T00:00:00Z",
+		Properties:      map[string]*anypb.Any{"role": role, "share": share},
+	})
+	assert.Nil(t, err, "Expected no error when creating a relationship with properties")
+
+	// Properties of an unsupported type are rejected
+	unsupported, _ := anypb.New(&pb.Kind{Major: "Unsupported"})
+	_, err = repository.CreateRelationship(ctx, "properties_entity_1", &pb.Relationship{
+		Id:              "properties_rel_unsupported",
+		RelatedEntityId: "properties_entity_2",
+		Name:            "OWNS",
+		StartTime:       "2025-01-01T00:00:00Z",
+		Properties:      map[string]*anypb.Any{"kind": unsupported},
+	})
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument), "Expected an invalid argument error for an unsupported property type")
+
+	fetched, err := repository.ReadRelationship(ctx, "properties_rel")
+	assert.Nil(t, err, "Expected no error when reading the relationship")
+	properties, _ := fetched["Properties"].(map[string]*anypb.Any)
+	assert.Equal(t, 2, len(properties), "Expected both properties to be read back")
+	var roleValue wrapperspb.StringValue
+	assert.Nil(t, properties["role"].UnmarshalTo(&roleValue))
+	assert.Equal(t, "director", roleValue.Value)
+	var shareValue wrapperspb.DoubleValue
+	assert.Nil(t, properties["share"].UnmarshalTo(&shareValue))
+	assert.Equal(t, 0.5, shareValue.Value)
+
+	// Relationships can be filtered on their properties
+	rels, err := repository.GetFilteredRelationships(ctx, "properties_entity_1", "", "", "", "", "", "", "", map[string]*anypb.Any{"role": role})
+	assert.Nil(t, err, "Expected no error when filtering on a property")
+	assert.Contains(t, rels, "properties_rel", "Expected the relationship with the property to match")
+	otherRole, _ := anypb.New(wrapperspb.String("auditor"))
+	rels, err = repository.GetFilteredRelationships(ctx, "properties_entity_1", "", "", "", "", "", "", "", map[string]*anypb.Any{"role": otherRole})
+	assert.Nil(t, err, "Expected no error when filtering on a property")
+	assert.NotContains(t, rels, "properties_rel", "Expected the relationship with another value of the property not to match")
+
+	// An update adds the properties given to the stored ones
+	_, err = repository.HandleGraphRelationshipUpdate(ctx, "properties_entity_1", &pb.Relationship{
+		Id:         "properties_rel",
+		Properties: map[string]*anypb.Any{"role": otherRole},
+	})
+	assert.Nil(t, err, "Expected no error when updating the properties")
+	fetched, err = repository.ReadRelationship(ctx, "properties_rel")
+	assert.Nil(t, err, "Expected no error when reading the updated relationship")
+	properties, _ = fetched["Properties"].(map[string]*anypb.Any)
+	assert.Nil(t, properties["role"].UnmarshalTo(&roleValue))
+	assert.Equal(t, "auditor", roleValue.Value, "Expected the property to be replaced")
+	assert.Contains(t, properties, "share", "Expected the other property to be kept")
+
+	// UpdateRelationship replaces every property
+	_, err = repository.UpdateRelationship(ctx, "properties_rel", map[string]interface{}{
+		"Properties": map[string]*anypb.Any{"role": role},
+	})
+	assert.Nil(t, err, "Expected no error when replacing the properties")
+	fetched, err = repository.ReadRelationship(ctx, "properties_rel")
+	assert.Nil(t, err, "Expected no error when reading the replaced relationship")
+	properties, _ = fetched["Properties"].(map[string]*anypb.Any)
+	assert.Equal(t, 1, len(properties), "Expected only the given property to be left")
+}
+
 func TestReadFilteredRelationships(t *testing.T) {
 	ctx := context.Background()
 
@@ -1357,3 +1494,121 @@ func TestFilterEntitiesWithPartialNameMatch(t *testing.T) {
 		assert.Contains(t, names, "Alice (test)", "Expected 'Alice (test)' to be in results")
 	})
 }
+
+// TestBatchCreateEntities tests creating entities and their relationships in batches
+func TestBatchCreateEntities(t *testing.T) {
+	ctx := context.Background()
+
+	newEntity := func(id string, name string, relationships map[string]*pb.Relationship) *pb.Entity {
+		nameValue, err := anypb.New(wrapperspb.String(name))
+		assert.Nil(t, err, "Expected no error when packing the name")
+		return &pb.Entity{
+			Id:            id,
+			Kind:          &pb.Kind{Major: "Organisation", Minor: "Department"},
+			Name:          &pb.TimeBasedValue{StartTime: "2025-03-18T00:00:00Z", Value: nameValue},
+			Created:       "2025-03-18T00:00:00Z",
+			Relationships: relationships,
+		}
+	}
+
+	entities := []*pb.Entity{
+		newEntity("batch-1", "Ministry", map[string]*pb.Relationship{
+			"batch-rel-1": {Id: "batch-rel-1", RelatedEntityId: "batch-2", Name: "HAS_DEPARTMENT", StartTime: "2025-03-18T00:00:00Z"},
+		}),
+		newEntity("batch-2", "Department", nil),
+		newEntity("batch-3", "Unit", map[string]*pb.Relationship{
+			"batch-rel-2": {Id: "batch-rel-2", RelatedEntityId: "batch-missing", Name: "HAS_UNIT", StartTime: "2025-03-18T00:00:00Z"},
+		}),
+		{Id: "batch-4"}, // Missing kind, name and created
+	}
+
+	failures := repository.HandleGraphEntitiesCreation(ctx, entities)
+	assert.Len(t, failures, 1, "Expected only the invalid entity to fail")
+	assert.NotNil(t, failures["batch-4"], "Expected the entity without required fields to fail")
+
+	// Creating the same entity again fails without affecting the others
+	failures = repository.HandleGraphEntitiesCreation(ctx, []*pb.Entity{newEntity("batch-1", "Ministry", nil), newEntity("batch-5", "Office", nil)})
+	assert.Len(t, failures, 1, "Expected only the existing entity to fail")
+	assert.Contains(t, failures["batch-1"].Error(), "already exists")
+
+	failures = repository.HandleGraphRelationshipsBatchCreate(ctx, entities[:3])
+	assert.Len(t, failures, 1, "Expected only the relationship to a missing entity to fail")
+	assert.Contains(t, failures["batch-3"].Error(), "batch-missing")
+
+	relationship, err := repository.ReadRelationship(ctx, "batch-rel-1")
+	assert.Nil(t, err, "Expected the relationship to be created")
+	assert.Equal(t, "batch-2", relationship["endEntityID"])
+
+	_, err = repository.ReadRelationship(ctx, "batch-rel-2")
+	assert.NotNil(t, err, "Expected the failing relationship not to be created")
+}
+
+func TestFilterEntitiesPage(t *testing.T) {
+	ctx := context.Background()
+
+	kind := &pb.Kind{Major: "PagedDepartment", Minor: "Paging"}
+	testEntities := []map[string]interface{}{
+		{"Id": "page-1", "Name": "Delta", "Created": "2025-01-04T00:00:00Z"},
+		{"Id": "page-2", "Name": "Alpha", "Created": "2025-01-02T00:00:00Z", "Terminated": "2025-06-01T00:00:00Z"},
+		{"Id": "page-3", "Name": "Charlie", "Created": "2025-01-01T00:00:00Z"},
+		{"Id": "page-4", "Name": "Bravo", "Created": "2025-01-03T00:00:00Z", "Terminated": "2025-05-01T00:00:00Z"},
+		{"Id": "page-5", "Name": "Alpha", "Created": "2025-01-05T00:00:00Z"},
+	}
+	for _, entity := range testEntities {
+		_, err := repository.CreateGraphEntity(ctx, kind, entity)
+		assert.Nil(t, err, "Expected no error when creating test entity: %s", entity["Id"])
+	}
+
+	// readAll follows the page tokens and returns the ids in the order they were read
+	readAll := func(orderBy string, pageSize int) []string {
+		key, descending, err := ParseOrderBy(orderBy)
+		assert.Nil(t, err, "Expected no error when parsing %q", orderBy)
+
+		ids := []string{}
+		var after *EntityCursor
+		for {
+			page, err := repository.FilterEntitiesPage(ctx, kind, map[string]interface{}{}, &EntityPageRequest{
+				PageSize: pageSize, OrderBy: key, Descending: descending, After: after, IncludeTotalCount: true,
+			})
+			assert.Nil(t, err, "Expected no error when reading a page")
+			assert.Equal(t, int64(len(testEntities)), page.TotalCount)
+			assert.LessOrEqual(t, len(page.Entities), pageSize)
+			for _, entity := range page.Entities {
+				ids = append(ids, entity["id"].(string))
+			}
+			if page.Next == nil {
+				return ids
+			}
+
+			// The cursor survives the trip through a page token
+			after, err = DecodeEntityCursor(EncodeEntityCursor(page.Next))
+			assert.Nil(t, err, "Expected the page token to decode")
+		}
+	}
+
+	assert.Equal(t, []string{"page-1", "page-2", "page-3", "page-4", "page-5"}, readAll("", 2))
+	assert.Equal(t, []string{"page-5", "page-4", "page-3", "page-2", "page-1"}, readAll("id desc", 2))
+	assert.Equal(t, []string{"page-2", "page-5", "page-4", "page-3", "page-1"}, readAll("name", 2))
+	assert.Equal(t, []string{"page-5", "page-1", "page-4", "page-2", "page-3"}, readAll("created desc", 3))
+	assert.Equal(t, []string{"page-4", "page-2", "page-1", "page-3", "page-5"}, readAll("terminated", 1))
+	assert.Equal(t, []string{"page-2", "page-4", "page-5", "page-3", "page-1"}, readAll("terminated desc", 2))
+
+	// A page size of 0 reads a page of DefaultPageSize, which holds every match here
+	page, err := repository.FilterEntitiesPage(ctx, kind, map[string]interface{}{}, &EntityPageRequest{})
+	assert.Nil(t, err, "Expected no error when reading without a page size")
+	assert.Len(t, page.Entities, len(testEntities))
+	assert.Nil(t, page.Next, "Expected no next page")
+
+	// A token cannot be used with a different order
+	_, err = repository.FilterEntitiesPage(ctx, kind, map[string]interface{}{}, &EntityPageRequest{
+		PageSize: 2, OrderBy: OrderByName, After: &EntityCursor{ID: "page-1"},
+	})
+	assert.NotNil(t, err, "Expected an error for a token of another order")
+
+	_, _, err = ParseOrderBy("name sideways")
+	assert.NotNil(t, err, "Expected an error for an unknown direction")
+	_, _, err = ParseOrderBy("kind")
+	assert.NotNil(t, err, "Expected an error for an unknown sort key")
+	_, err = DecodeEntityCursor("not a token")
+	assert.NotNil(t, err, "Expected an error for an invalid page token")
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package neo4jrepository
+
+import (
+	"strings"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// The properties of a relationship are stored on its edge with this prefix, apart from the Id, Created
+// and Terminated every edge has
+const relationshipPropertyPrefix = "property."
+
+// graphProperties converts the properties of a relationship to the values stored on its edge, keyed with
+// relationshipPropertyPrefix. Neo4j only stores scalar values, so only strings, integers, floating-point
+// numbers and booleans are supported.
+func graphProperties(properties map[string]*anypb.Any) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(properties))
+	for key, property := range properties {
+		if key == "" {
+			return nil, apperrors.InvalidArgumentf("relationship property names cannot be empty").WithField("relationships.properties")
+		}
+		field := "relationships.properties." + key
+		if property == nil {
+			return nil, apperrors.InvalidArgumentf("relationship property %s has no value", key).WithField(field)
+		}
+		message, err := property.UnmarshalNew()
+		if err != nil {
+			return nil, apperrors.InvalidArgumentf("invalid value of relationship property %s: %v", key, err).WithField(field)
+		}
+		var value interface{}
+		switch v := message.(type) {
+		case *wrapperspb.StringValue:
+			value = v.Value
+		case *wrapperspb.BoolValue:
+			value = v.Value
+		case *wrapperspb.Int32Value:
+			value = int64(v.Value)
+		case *wrapperspb.Int64Value:
+			value = v.Value
+		case *wrapperspb.UInt32Value:
+			value = int64(v.Value)
+		case *wrapperspb.FloatValue:
+			value = float64(v.Value)
+		case *wrapperspb.DoubleValue:
+			value = v.Value
+		default:
+			return nil, apperrors.InvalidArgumentf("unsupported type %s of relationship property %s, expected a string, integer, floating-point number or boolean", property.TypeUrl, key).WithField(field)
+		}
+		values[relationshipPropertyPrefix+key] = value
+	}
+	return values, nil
+}
+
+// relationshipProperties returns the properties stored on an edge, leaving out its other properties.
+// Integers are returned as Int64Value and floating-point numbers as DoubleValue.
+func relationshipProperties(props map[string]interface{}) map[string]*anypb.Any {
+	properties := make(map[string]*anypb.Any)
+	for key, value := range props {
+		name, ok := strings.CutPrefix(key, relationshipPropertyPrefix)
+		if !ok {
+			continue
+		}
+		var property *anypb.Any
+		switch v := value.(type) {
+		case string:
+			property, _ = anypb.New(wrapperspb.String(v))
+		case bool:
+			property, _ = anypb.New(wrapperspb.Bool(v))
+		case int64:
+			property, _ = anypb.New(wrapperspb.Int64(v))
+		case float64:
+			property, _ = anypb.New(wrapperspb.Double(v))
+		default:
+			continue
+		}
+		properties[name] = property
+	}
+	return properties
+}
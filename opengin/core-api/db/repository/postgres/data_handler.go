@@ -5,17 +5,21 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
 	"lk/datafoundation/core-api/pkg/schema"
+	"lk/datafoundation/core-api/pkg/tracing"
 	"lk/datafoundation/core-api/pkg/typeinference"
 
 	commons "lk/datafoundation/core-api/commons"
+	"lk/datafoundation/core-api/pkg/logging"
 
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -63,7 +67,7 @@ func UnmarshalAnyToString(anyValue *anypb.Any) (string, error) {
 
 	var stringValue wrapperspb.StringValue
 	if err := anyValue.UnmarshalTo(&stringValue); err != nil {
-		return "", fmt.Errorf("error unmarshaling to string value: %v", err)
+		return "", fmt.Errorf("error unmarshaling to string value: %w", err)
 	}
 	return stringValue.Value, nil
 }
@@ -76,7 +80,7 @@ func UnmarshalTimeBasedValueList(anyValue *anypb.Any) ([]interface{}, error) {
 
 	var timeBasedValueList pb.TimeBasedValueList
 	if err := anyValue.UnmarshalTo(&timeBasedValueList); err != nil {
-		return nil, fmt.Errorf("error unmarshaling to TimeBasedValueList: %v", err)
+		return nil, fmt.Errorf("error unmarshaling to TimeBasedValueList: %w", err)
 	}
 
 	// Convert TimeBasedValueList to []interface{}
@@ -111,7 +115,7 @@ func UnmarshalEntityAttributes(attributes map[string]*anypb.Any) (map[string]int
 			continue
 		}
 
-		log.Printf("Warning: Could not unmarshal attribute %s with type %s", key, value.TypeUrl)
+		slog.Warn("Could not unmarshal attribute with type", "key", key, "type_url", value.TypeUrl)
 	}
 
 	return result, nil
@@ -123,7 +127,7 @@ func isTabularData(value *anypb.Any) (bool, *structpb.Struct, error) {
 	// Try to unmarshal as struct
 	var dataStruct structpb.Struct
 	if err := value.UnmarshalTo(&dataStruct); err != nil {
-		return false, nil, fmt.Errorf("failed to unmarshal as struct: %v", err)
+		return false, nil, fmt.Errorf("failed to unmarshal as struct: %w", err)
 	}
 
 	// Check for required fields
@@ -148,7 +152,7 @@ func isTabularData(value *anypb.Any) (bool, *structpb.Struct, error) {
 	// Verify all columns are strings
 	for i, col := range columnsList.Values {
 		if col.GetStringValue() == "" {
-			return false, nil, fmt.Errorf("column %d is not a string", i)
+			return false, nil, apperrors.InvalidArgumentf("column %d is not a string", i)
 		}
 	}
 
@@ -157,10 +161,10 @@ func isTabularData(value *anypb.Any) (bool, *structpb.Struct, error) {
 	for i, row := range rowsList.Values {
 		rowData := row.GetListValue()
 		if rowData == nil {
-			return false, nil, fmt.Errorf("row %d is not a list", i)
+			return false, nil, apperrors.InvalidArgumentf("row %d is not a list", i)
 		}
 		if len(rowData.Values) != columnCount {
-			return false, nil, fmt.Errorf("row %d has incorrect number of columns", i)
+			return false, nil, apperrors.InvalidArgumentf("row %d has incorrect number of columns", i)
 		}
 	}
 
@@ -306,7 +310,7 @@ func validateDataAgainstSchema(data *structpb.Struct, schemaInfo *schema.SchemaI
 	for _, col := range columnsList.Values {
 		colName := col.GetStringValue()
 		if !schemaColumns[colName] {
-			return fmt.Errorf("column %s not found in schema", colName)
+			return apperrors.InvalidArgumentf("column %s not found in schema", colName)
 		}
 	}
 
@@ -321,19 +325,19 @@ func validateDataAgainstSchema(data *structpb.Struct, schemaInfo *schema.SchemaI
 			switch fieldSchema.TypeInfo.Type {
 			case typeinference.IntType:
 				if v, ok := value.Kind.(*structpb.Value_NumberValue); !ok || v.NumberValue != float64(int64(v.NumberValue)) {
-					return fmt.Errorf("row %d, column %s: expected integer, got %v", i, colName, value)
+					return apperrors.InvalidArgumentf("row %d, column %s: expected integer, got %v", i, colName, value)
 				}
 			case typeinference.FloatType:
 				if _, ok := value.Kind.(*structpb.Value_NumberValue); !ok {
-					return fmt.Errorf("row %d, column %s: expected float, got %v", i, colName, value)
+					return apperrors.InvalidArgumentf("row %d, column %s: expected float, got %v", i, colName, value)
 				}
 			case typeinference.BoolType:
 				if _, ok := value.Kind.(*structpb.Value_BoolValue); !ok {
-					return fmt.Errorf("row %d, column %s: expected boolean, got %v", i, colName, value)
+					return apperrors.InvalidArgumentf("row %d, column %s: expected boolean, got %v", i, colName, value)
 				}
 			case typeinference.DateTimeType:
 				if v, ok := value.Kind.(*structpb.Value_StringValue); !ok || !isDateTime(v.StringValue) {
-					return fmt.Errorf("row %d, column %s: expected datetime, got %v", i, colName, value)
+					return apperrors.InvalidArgumentf("row %d, column %s: expected datetime, got %v", i, colName, value)
 				}
 			}
 		}
@@ -345,7 +349,7 @@ func validateDataAgainstSchema(data *structpb.Struct, schemaInfo *schema.SchemaI
 // compareSchemas compares two schemas and returns true if they are compatible
 func compareSchemas(existing, newSchema *schema.SchemaInfo) (bool, error) {
 	if existing.StorageType != newSchema.StorageType {
-		return false, fmt.Errorf("storage type mismatch: existing=%s, newSchema=%s",
+		return false, apperrors.FailedPreconditionf("storage type mismatch: existing=%s, newSchema=%s",
 			existing.StorageType, newSchema.StorageType)
 	}
 
@@ -354,18 +358,18 @@ func compareSchemas(existing, newSchema *schema.SchemaInfo) (bool, error) {
 		newField, exists := newSchema.Fields[fieldName]
 		if !exists {
 			// Missing column in newSchema
-			return false, fmt.Errorf("column %s missing in newSchema", fieldName)
+			return false, apperrors.FailedPreconditionf("column %s missing in newSchema", fieldName)
 		}
 
 		// Check type compatibility
 		if !isTypeCompatible(existingField.TypeInfo.Type, newField.TypeInfo.Type) {
-			return false, fmt.Errorf("incompatible type for column %s: existing=%s, newSchema=%s",
+			return false, apperrors.FailedPreconditionf("incompatible type for column %s: existing=%s, newSchema=%s",
 				fieldName, existingField.TypeInfo.Type, newField.TypeInfo.Type)
 		}
 
 		// Check nullability
 		if !existingField.TypeInfo.IsNullable && newField.TypeInfo.IsNullable {
-			return false, fmt.Errorf("column %s cannot be changed from NOT NULL to NULL", fieldName)
+			return false, apperrors.FailedPreconditionf("column %s cannot be changed from NOT NULL to NULL", fieldName)
 		}
 	}
 
@@ -397,6 +401,18 @@ func isTypeCompatible(existingType, newType typeinference.DataType) bool {
 
 // handleTabularData processes tabular data attributes
 func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID, attrName string, value *pb.TimeBasedValue, schemaInfo *schema.SchemaInfo) error {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.HandleTabularData", tracing.EntityID(entityID), tracing.AttributeName(attrName))
+	defer span.End()
+
+	startTime, err := parseValueTime(value.StartTime, "startTime")
+	if err != nil {
+		return err
+	}
+	endTime, err := parseValueTime(value.EndTime, "endTime")
+	if err != nil {
+		return err
+	}
+
 	// Generate table name - UUID without hyphens (32 chars) + prefix (5 chars) = 37 chars total
 	unique_id := uuid.New().String()
 	unique_id = strings.ReplaceAll(unique_id, "-", "") // Remove hyphens for PostgreSQL compatibility
@@ -408,68 +424,68 @@ func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID,
 	// Check if table exists
 	exists, err := repo.TableExists(ctx, tableName)
 	if err != nil {
-		return fmt.Errorf("error checking table existence: %v", err)
+		return fmt.Errorf("error checking table existence: %w", err)
 	}
 
 	if exists {
 		// Get existing schema
 		var schemaJSON []byte
-		err = repo.DB().QueryRowContext(ctx,
+		err = repo.conn().QueryRowContext(ctx,
 			`SELECT schema_definition FROM attribute_schemas WHERE table_name = $1 ORDER BY schema_version DESC LIMIT 1`,
 			tableName).Scan(&schemaJSON)
 		if err != nil {
-			return fmt.Errorf("error getting existing schema: %v", err)
+			return fmt.Errorf("error getting existing schema: %w", err)
 		}
 
 		var existingSchema schema.SchemaInfo
 		if err := json.Unmarshal(schemaJSON, &existingSchema); err != nil {
-			return fmt.Errorf("error unmarshaling existing schema: %v", err)
+			return fmt.Errorf("error unmarshaling existing schema: %w", err)
 		}
 
 		// Compare schemas
 		compatible, err := compareSchemas(&existingSchema, schemaInfo)
 		if err != nil {
-			return fmt.Errorf("schema compatibility check failed: %v", err)
+			return fmt.Errorf("schema compatibility check failed: %w", err)
 		}
 
 		if !compatible {
-			return fmt.Errorf("incompatible schema changes detected")
+			return apperrors.FailedPreconditionf("incompatible schema changes detected").WithEntity(entityID).WithAttribute(attrName)
 		}
 
 		// Validate data against existing schema
 		var tabularStruct structpb.Struct
 		if err := value.Value.UnmarshalTo(&tabularStruct); err != nil {
-			return fmt.Errorf("error unmarshaling tabular data: %v", err)
+			return fmt.Errorf("error unmarshaling tabular data: %w", err)
 		}
 
 		if err := validateDataAgainstSchema(&tabularStruct, &existingSchema); err != nil {
-			return fmt.Errorf("data validation failed: %v", err)
+			return fmt.Errorf("data validation failed: %w", err)
 		}
 	} else {
 		// Create new table
 		if err := repo.CreateDynamicTable(ctx, tableName, columns); err != nil {
-			return fmt.Errorf("error creating table: %v", err)
+			return fmt.Errorf("error creating table: %w", err)
 		}
 
 		// Store schema information
 		schemaJSON, err := json.Marshal(schemaInfo)
 		if err != nil {
-			return fmt.Errorf("error marshaling schema: %v", err)
+			return fmt.Errorf("error marshaling schema: %w", err)
 		}
 
 		// Insert schema record
-		_, err = repo.DB().ExecContext(ctx,
+		_, err = repo.conn().ExecContext(ctx,
 			`INSERT INTO attribute_schemas (table_name, schema_version, schema_definition)
 			VALUES ($1, $2, $3)`,
 			tableName, 1, schemaJSON)
 		if err != nil {
-			return fmt.Errorf("error storing schema: %v", err)
+			return fmt.Errorf("error storing schema: %w", err)
 		}
 	}
 
 	// Create entity attribute record if it doesn't exist
 	var attributeID int
-	err = repo.DB().QueryRowContext(ctx,
+	err = repo.conn().QueryRowContext(ctx,
 		`INSERT INTO entity_attributes (entity_id, attribute_name, table_name)
 		VALUES ($1, $2, $3)
 		ON CONFLICT (entity_id, attribute_name) DO UPDATE
@@ -477,13 +493,13 @@ func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID,
 		RETURNING id`,
 		entityID, attrName, tableName).Scan(&attributeID)
 	if err != nil {
-		return fmt.Errorf("error creating entity attribute record: %v", err)
+		return fmt.Errorf("error creating entity attribute record: %w", err)
 	}
 
 	// Extract data from the TimeBasedValue
 	var tabularStruct structpb.Struct
 	if err := value.Value.UnmarshalTo(&tabularStruct); err != nil {
-		return fmt.Errorf("error unmarshaling tabular data: %v", err)
+		return fmt.Errorf("error unmarshaling tabular data: %w", err)
 	}
 
 	// Extract columns and rows
@@ -491,7 +507,7 @@ func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID,
 	rowsValue := tabularStruct.Fields["rows"].GetListValue()
 
 	if columnsValue == nil || rowsValue == nil {
-		return fmt.Errorf("invalid tabular data format")
+		return apperrors.InvalidArgumentf("invalid tabular data format").WithEntity(entityID).WithAttribute(attrName)
 	}
 
 	// Convert columns to string slice
@@ -505,7 +521,7 @@ func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID,
 	for i, row := range rowsValue.Values {
 		rowList := row.GetListValue()
 		if rowList == nil {
-			return fmt.Errorf("invalid row format at index %d", i)
+			return apperrors.InvalidArgumentf("invalid row format at index %d", i).WithEntity(entityID).WithAttribute(attrName)
 		}
 
 		rows[i] = make([]interface{}, len(rowList.Values))
@@ -525,12 +541,34 @@ func (repo *PostgresRepository) HandleTabularData(ctx context.Context, entityID,
 
 	// Insert the data
 	if err := repo.InsertTabularData(ctx, tableName, attributeID, columnNames, rows); err != nil {
-		return fmt.Errorf("error inserting tabular data: %v", err)
+		return fmt.Errorf("error inserting tabular data: %w", err)
+	}
+
+	// Record the interval of the value, for the reads at a point in time
+	_, err = repo.conn().ExecContext(ctx,
+		`INSERT INTO attribute_values (table_name, entity_id, attribute_name, start_time, end_time)
+		VALUES ($1, $2, $3, $4, $5)`,
+		tableName, entityID, attrName, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("error recording interval of tabular data: %w", err)
 	}
 
 	return nil
 }
 
+// parseValueTime parses a time of a TimeBasedValue, an RFC 3339 time or a date. An empty time is NULL.
+func parseValueTime(value, field string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return sql.NullTime{Time: parsed, Valid: true}, nil
+		}
+	}
+	return sql.NullTime{}, apperrors.InvalidArgumentf("invalid %s %q, expected an RFC 3339 time", field, value).WithField(field)
+}
+
 // schemaToColumns converts a schema to database columns
 func schemaToColumns(schemaInfo *schema.SchemaInfo) []Column {
 	var columns []Column
@@ -582,14 +620,17 @@ type Column struct {
 
 // GetTableList retrieves a list of attribute tables for a given entity ID.
 func GetTableList(ctx context.Context, repo *PostgresRepository, entityID string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "postgres.GetTableList", tracing.EntityID(entityID))
+	defer span.End()
+
 	query := `
 		SELECT table_name
 		FROM entity_attributes
 		WHERE entity_id = $1
 	`
-	rows, err := repo.DB().QueryContext(ctx, query, entityID)
+	rows, err := repo.conn().QueryContext(ctx, query, entityID)
 	if err != nil {
-		return nil, fmt.Errorf("error querying for table list: %v", err)
+		return nil, fmt.Errorf("error querying for table list: %w", err)
 	}
 	defer rows.Close()
 
@@ -597,13 +638,13 @@ func GetTableList(ctx context.Context, repo *PostgresRepository, entityID string
 	for rows.Next() {
 		var tableName string
 		if err := rows.Scan(&tableName); err != nil {
-			return nil, fmt.Errorf("error scanning table name: %v", err)
+			return nil, fmt.Errorf("error scanning table name: %w", err)
 		}
 		tableList = append(tableList, tableName)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over table list rows: %v", err)
+		return nil, fmt.Errorf("error iterating over table list rows: %w", err)
 	}
 
 	return tableList, nil
@@ -611,6 +652,9 @@ func GetTableList(ctx context.Context, repo *PostgresRepository, entityID string
 
 // GetSchemaOfTable retrieves the schema for a given attribute table.
 func GetSchemaOfTable(ctx context.Context, repo *PostgresRepository, tableName string) (*schema.SchemaInfo, error) {
+	ctx, span := tracing.Start(ctx, "postgres.GetSchemaOfTable", tracing.TableName(tableName))
+	defer span.End()
+
 	query := `
 		SELECT schema_definition
 		FROM attribute_schemas
@@ -619,14 +663,14 @@ func GetSchemaOfTable(ctx context.Context, repo *PostgresRepository, tableName s
 		LIMIT 1
 	`
 	var schemaJSON []byte
-	err := repo.DB().QueryRowContext(ctx, query, tableName).Scan(&schemaJSON)
+	err := repo.conn().QueryRowContext(ctx, query, tableName).Scan(&schemaJSON)
 	if err != nil {
-		return nil, fmt.Errorf("error getting schema for table %s: %v", tableName, err)
+		return nil, fmt.Errorf("error getting schema for table %s: %w", tableName, err)
 	}
 
 	var schemaInfo schema.SchemaInfo
 	if err := json.Unmarshal(schemaJSON, &schemaInfo); err != nil {
-		return nil, fmt.Errorf("error unmarshaling schema for table %s: %v", tableName, err)
+		return nil, fmt.Errorf("error unmarshaling schema for table %s: %w", tableName, err)
 	}
 
 	return &schemaInfo, nil
@@ -640,11 +684,14 @@ type TabularData struct {
 
 // GetData retrieves data from a table with optional field selection and filters, returns it as pb.Any with JSON-formatted tabular data.
 func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, filters map[string]interface{}, fields ...string) (*anypb.Any, error) {
-	log.Printf("DEBUG: GetData: tableName=%s, \t\nfilters=%v, \t\nfields=%v", tableName, filters, fields)
+	ctx, span := tracing.Start(ctx, "PostgresRepository.GetData", tracing.TableName(tableName))
+	defer span.End()
+
+	logging.FromContext(ctx).Debug("Getting data", "table", tableName, logging.Payload("filters", filters), "fields", fields)
 	// Build the SELECT clause
 	var selectClause string
 	if len(fields) > 0 {
-		log.Printf("DEBUG: [DataHandler.GetData] selectClause: %v", fields)
+		logging.FromContext(ctx).Debug("Selecting fields", "fields", fields)
 		// Sanitize and quote field names
 		sanitizedFields := make([]string, len(fields))
 		for i, field := range fields {
@@ -652,15 +699,15 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 		}
 		selectClause = strings.Join(sanitizedFields, ", ")
 	} else {
-		log.Printf("DEBUG: [DataHandler.GetData] selectClause: *")
+		logging.FromContext(ctx).Debug("Selecting all fields")
 		selectClause = "*"
 	}
 
-	log.Printf("DEBUG: [DataHandler.GetData] selectClause: %s", selectClause)
+	logging.FromContext(ctx).Debug("Built select clause", "select_clause", selectClause)
 	// Base query
 	query := fmt.Sprintf("SELECT %s FROM %s", selectClause, commons.SanitizeIdentifier(tableName))
 
-	log.Printf("DEBUG: [DataHandler.GetData] query: %s", query)
+	logging.FromContext(ctx).Debug("Built query", "query", query)
 
 	var args []interface{}
 	var whereClauses []string
@@ -678,24 +725,24 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 	}
 
 	// Execute the query
-	rows, err := repo.DB().QueryContext(ctx, query, args...)
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying data from %s: %v", tableName, err)
+		return nil, fmt.Errorf("error querying data from %s: %w", tableName, err)
 	}
 	defer rows.Close()
 
 	// Get column names from the result set
 	resultColumns, err := rows.Columns()
 	if err != nil {
-		return nil, fmt.Errorf("error getting columns from %s: %v", tableName, err)
+		return nil, fmt.Errorf("error getting columns from %s: %w", tableName, err)
 	}
 
 	// Filter out internal columns that shouldn't be returned by default
 	// unless they are explicitly requested in the fields parameter
 	filteredColumns, columnIndices := filterInternalColumns(resultColumns, fields)
-	log.Printf("DEBUG: [DataHandler.GetData] Original columns: %v", resultColumns)
-	log.Printf("DEBUG: [DataHandler.GetData] Filtered columns: %v", filteredColumns)
-	log.Printf("DEBUG: [DataHandler.GetData] Column indices to keep: %v", columnIndices)
+	logging.FromContext(ctx).Debug("Original columns", "columns", resultColumns)
+	logging.FromContext(ctx).Debug("Filtered columns", "columns", filteredColumns)
+	logging.FromContext(ctx).Debug("Column indices to keep", "indices", columnIndices)
 
 	// Log which internal columns were filtered out or included
 	internalColumns := map[string]bool{
@@ -714,9 +761,9 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 					}
 				}
 				if found {
-					log.Printf("INFO: [DataHandler.GetData] Internal column '%s' included (explicitly requested)", column)
+					logging.FromContext(ctx).Debug("Internal column included, explicitly requested", "column", column)
 				} else {
-					log.Printf("INFO: [DataHandler.GetData] Internal column '%s' filtered out (not requested)", column)
+					logging.FromContext(ctx).Debug("Internal column filtered out, not requested", "column", column)
 				}
 			}
 		}
@@ -731,7 +778,7 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 		}
 
 		if err := rows.Scan(rowPointers...); err != nil {
-			return nil, fmt.Errorf("error scanning row: %v", err)
+			return nil, fmt.Errorf("error scanning row: %w", err)
 		}
 
 		// Convert row values to interface{} slice, but only include filtered columns
@@ -749,7 +796,7 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %v", err)
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
 
 	// Create the tabular data structure
@@ -761,23 +808,23 @@ func (repo *PostgresRepository) GetData(ctx context.Context, tableName string, f
 	// Convert to JSON string
 	jsonData, err := json.Marshal(tabularData)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling tabular data to JSON: %v", err)
+		return nil, fmt.Errorf("error marshaling tabular data to JSON: %w", err)
 	}
 
-	log.Printf("DEBUG: [DataHandler.GetData] jsonData: %s", string(jsonData))
+	logging.FromContext(ctx).Debug("Retrieved data", "table", tableName, "rows", len(tabularRows), logging.Payload("data", string(jsonData)))
 
 	// Create a struct with the JSON string
 	structValue, err := structpb.NewStruct(map[string]interface{}{
 		"data": string(jsonData),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error creating struct for JSON data: %v", err)
+		return nil, fmt.Errorf("error creating struct for JSON data: %w", err)
 	}
 
 	// Convert to Any
 	anyValue, err := anypb.New(structValue)
 	if err != nil {
-		return nil, fmt.Errorf("error converting struct to Any: %v", err)
+		return nil, fmt.Errorf("error converting struct to Any: %w", err)
 	}
 
 	return anyValue, nil
@@ -103,6 +103,134 @@ func TestGetTableList(t *testing.T) {
 	assert.Equal(t, []string{tableName}, tableList)
 }
 
+func TestDeleteEntityAttributeTables(t *testing.T) {
+	repo := setupTestDB(t)
+	// Do not defer repo.Close() here - let cleanup handle it
+
+	ctx := context.Background()
+	entityID := fmt.Sprintf("test_entity_%d", time.Now().UnixNano())
+	tableName := fmt.Sprintf("attr_test_%d", time.Now().UnixNano())
+
+	err := repo.CreateDynamicTable(ctx, tableName, []Column{{Name: "col1", Type: "TEXT"}})
+	assert.NoError(t, err)
+
+	_, err = repo.DB().Exec(`
+		INSERT INTO entity_attributes (entity_id, attribute_name, table_name)
+		VALUES ($1, $2, $3)
+	`, entityID, "test_attribute", tableName)
+	assert.NoError(t, err)
+
+	_, err = repo.DB().Exec(`
+		INSERT INTO attribute_schemas (table_name, schema_version, schema_definition)
+		VALUES ($1, 1, '{}')
+	`, tableName)
+	assert.NoError(t, err)
+
+	deleted, err := repo.DeleteEntityAttributeTables(ctx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{tableName}, deleted)
+
+	exists, err := repo.TableExists(ctx, tableName)
+	assert.NoError(t, err)
+	assert.False(t, exists, "attribute table should be dropped")
+
+	tableList, err := repo.ListEntityAttributeTables(ctx, entityID)
+	assert.NoError(t, err)
+	assert.Empty(t, tableList)
+
+	var schemaCount int
+	err = repo.DB().QueryRow(`SELECT COUNT(*) FROM attribute_schemas WHERE table_name = $1`, tableName).Scan(&schemaCount)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, schemaCount)
+
+	// Deleting again is a no-op
+	deleted, err = repo.DeleteEntityAttributeTables(ctx, entityID)
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func TestRestoreEntityAttributeTables(t *testing.T) {
+	repo := setupTestDB(t)
+	// Do not defer repo.Close() here - let cleanup handle it
+
+	ctx := context.Background()
+	entityID := fmt.Sprintf("test_entity_%d", time.Now().UnixNano())
+	oldTable := fmt.Sprintf("attr_test_old_%d", time.Now().UnixNano())
+	newTable := fmt.Sprintf("attr_test_new_%d", time.Now().UnixNano())
+	otherTable := fmt.Sprintf("attr_test_other_%d", time.Now().UnixNano())
+
+	for _, tableName := range []string{oldTable, newTable, otherTable} {
+		err := repo.CreateDynamicTable(ctx, tableName, []Column{{Name: "col1", Type: "TEXT"}})
+		assert.NoError(t, err)
+	}
+
+	_, err := repo.DB().Exec(`
+		INSERT INTO entity_attributes (entity_id, attribute_name, table_name)
+		VALUES ($1, $2, $3)
+	`, entityID, "test_attribute", oldTable)
+	assert.NoError(t, err)
+
+	snapshot, err := repo.SnapshotEntityAttributeTables(ctx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{oldTable}, snapshot.Tables)
+	assert.Equal(t, map[string]string{"test_attribute": oldTable}, snapshot.Mappings)
+
+	// A new value of the existing attribute and a new attribute
+	_, err = repo.DB().Exec(`UPDATE entity_attributes SET table_name = $2 WHERE entity_id = $1`, entityID, newTable)
+	assert.NoError(t, err)
+	_, err = repo.DB().Exec(`
+		INSERT INTO entity_attributes (entity_id, attribute_name, table_name)
+		VALUES ($1, $2, $3)
+	`, entityID, "test_other_attribute", otherTable)
+	assert.NoError(t, err)
+
+	dropped, err := repo.RestoreEntityAttributeTables(ctx, snapshot)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{newTable, otherTable}, dropped)
+
+	restored, err := repo.SnapshotEntityAttributeTables(ctx, entityID)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Mappings, restored.Mappings)
+
+	exists, err := repo.TableExists(ctx, oldTable)
+	assert.NoError(t, err)
+	assert.True(t, exists, "table from before the snapshot should be kept")
+}
+
+func TestBeginSavepoint(t *testing.T) {
+	repo := setupTestDB(t)
+	// Do not defer repo.Close() here - let cleanup handle it
+
+	ctx := context.Background()
+	keptTable := fmt.Sprintf("attr_test_kept_%d", time.Now().UnixNano())
+	undoneTable := fmt.Sprintf("attr_test_undone_%d", time.Now().UnixNano())
+
+	tx, err := repo.Begin(ctx)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	assert.NoError(t, tx.Savepoint(ctx, "entity"))
+	assert.NoError(t, tx.CreateDynamicTable(ctx, keptTable, []Column{{Name: "col1", Type: "TEXT"}}))
+	assert.NoError(t, tx.ReleaseSavepoint(ctx, "entity"))
+
+	assert.NoError(t, tx.Savepoint(ctx, "entity"))
+	assert.NoError(t, tx.CreateDynamicTable(ctx, undoneTable, []Column{{Name: "col1", Type: "TEXT"}}))
+	assert.NoError(t, tx.RollbackToSavepoint(ctx, "entity"))
+
+	exists, err := repo.TableExists(ctx, keptTable)
+	assert.NoError(t, err)
+	assert.False(t, exists, "table should not be visible before the commit")
+
+	assert.NoError(t, tx.Commit())
+
+	exists, err = repo.TableExists(ctx, keptTable)
+	assert.NoError(t, err)
+	assert.True(t, exists, "table created before the savepoint was released should be committed")
+	exists, err = repo.TableExists(ctx, undoneTable)
+	assert.NoError(t, err)
+	assert.False(t, exists, "table rolled back to the savepoint should not be committed")
+}
+
 func TestGetSchemaOfTable(t *testing.T) {
 	repo := setupTestDB(t)
 	// Do not defer repo.Close() here - let cleanup handle it
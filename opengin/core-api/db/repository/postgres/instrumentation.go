@@ -0,0 +1,110 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/metrics"
+	"lk/datafoundation/core-api/pkg/tracing"
+)
+
+// instrumentedConnector records every statement run on its connections in the metrics and as a span,
+// named after the SQL command, e.g. "SELECT". Prepared statements, such as COPY, are not recorded.
+type instrumentedConnector struct {
+	driver.Connector
+}
+
+func (c instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{conn}, nil
+}
+
+// pqConn is what database/sql uses of a lib/pq connection
+type pqConn interface {
+	driver.Conn
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.ConnPrepareContext
+	driver.ConnBeginTx
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+type instrumentedConn struct {
+	driver.Conn
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, done := observeStatement(ctx, query)
+	rows, err := c.Conn.(pqConn).QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ctx, done := observeStatement(ctx, query)
+	result, err := c.Conn.(pqConn).ExecContext(ctx, query, args)
+	done(err)
+	return result, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(pqConn).PrepareContext(ctx, query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(pqConn).BeginTx(ctx, opts)
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	return c.Conn.(pqConn).Ping(ctx)
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(pqConn).ResetSession(ctx)
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	return c.Conn.(pqConn).IsValid()
+}
+
+// sqlCommands are the operations statements are recorded as, anything else is "OTHER"
+var sqlCommands = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "ALTER", "DROP", "WITH", "BEGIN", "COMMIT", "ROLLBACK"}
+
+// observeStatement starts a span for the statement and returns the function that records
+// the statement in the metrics and ends the span
+func observeStatement(ctx context.Context, query string) (context.Context, func(err error)) {
+	operation := "OTHER"
+	if fields := strings.Fields(query); len(fields) > 0 && slices.Contains(sqlCommands, strings.ToUpper(fields[0])) {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	ctx, span := tracing.StartClient(ctx, metrics.Postgres, operation)
+	start := time.Now()
+	return ctx, func(err error) {
+		if errors.Is(err, driver.ErrSkip) {
+			// database/sql retries with a prepared statement
+			span.End()
+			return
+		}
+		metrics.ObserveStore(metrics.Postgres, operation, start, err)
+		tracing.End(span, err)
+	}
+}
+
+// PoolStats reports the connection pool of the database
+func (r *PostgresRepository) PoolStats() metrics.PoolStats {
+	stats := r.db.Stats()
+	return metrics.PoolStats{InUse: stats.InUse, Idle: stats.Idle, Max: stats.MaxOpenConnections}
+}
@@ -7,52 +7,83 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"lk/datafoundation/core-api/commons"
+	"lk/datafoundation/core-api/db/config"
 	"lk/datafoundation/core-api/pkg/schema"
+	"lk/datafoundation/core-api/pkg/tracing"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // Config holds the database configuration
-type Config struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
-}
+type Config = config.PostgresConfig
+
+// Pool settings used when the configuration leaves them unset
+const (
+	defaultMaxOpenConns    = 25
+	defaultMaxIdleConns    = 25
+	defaultConnMaxLifetime = 5 * time.Minute
+)
 
 // PostgresRepository represents a PostgreSQL database repository
 type PostgresRepository struct {
 	db *sql.DB
+	tx *sql.Tx // Set on the repositories returned by Begin
+}
+
+// querier runs statements on the database or within a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 // NewPostgresRepository creates a new PostgreSQL repository
 func NewPostgresRepository(cfg Config) (*PostgresRepository, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode)
+	if cfg.ConnectTimeout > 0 {
+		// connect_timeout is in seconds, rounded up so that a short timeout does not become 0, which means none
+		dsn += fmt.Sprintf(" connect_timeout=%d", int((cfg.ConnectTimeout+time.Second-1)/time.Second))
+	}
 
-	return NewPostgresRepositoryFromDSN(dsn)
+	return newPostgresRepository(dsn, cfg)
 }
 
 // NewPostgresRepositoryFromDSN creates a new PostgreSQL repository from a connection string
 func NewPostgresRepositoryFromDSN(dsn string) (*PostgresRepository, error) {
-	db, err := sql.Open("postgres", dsn)
+	return newPostgresRepository(dsn, Config{})
+}
+
+func newPostgresRepository(dsn string, cfg Config) (*PostgresRepository, error) {
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
-		return nil, fmt.Errorf("error opening database: %v", err)
+		return nil, fmt.Errorf("error opening database: %w", err)
 	}
+	db := sql.OpenDB(instrumentedConnector{connector})
 
 	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	maxOpenConns, maxIdleConns, connMaxLifetime := cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.ConnMaxLifetime
+	if maxOpenConns == 0 {
+		maxOpenConns = defaultMaxOpenConns
+	}
+	if maxIdleConns == 0 {
+		maxIdleConns = min(defaultMaxIdleConns, maxOpenConns)
+	}
+	if connMaxLifetime == 0 {
+		connMaxLifetime = defaultConnMaxLifetime
+	}
+	db.SetMaxOpenConns(maxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(connMaxLifetime)
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("error connecting to the database: %v", err)
+		return nil, fmt.Errorf("error connecting to the database: %w", err)
 	}
 
 	return &PostgresRepository{db: db}, nil
@@ -68,6 +99,55 @@ func (r *PostgresRepository) DB() *sql.DB {
 	return r.db
 }
 
+// conn returns the transaction of the repository, or the database if it has none
+func (r *PostgresRepository) conn() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// Begin returns a repository writing through a new transaction, until Commit or Rollback.
+// Savepoints let a failing part of the transaction be rolled back without the rest.
+func (r *PostgresRepository) Begin(ctx context.Context) (*PostgresRepository, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	return &PostgresRepository{db: r.db, tx: tx}, nil
+}
+
+// Commit commits the transaction of a repository returned by Begin
+func (r *PostgresRepository) Commit() error {
+	if err := r.tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback rolls back the transaction of a repository returned by Begin
+func (r *PostgresRepository) Rollback() error {
+	return r.tx.Rollback()
+}
+
+// Savepoint marks the point of the transaction RollbackToSavepoint returns to
+func (r *PostgresRepository) Savepoint(ctx context.Context, name string) error {
+	_, err := r.tx.ExecContext(ctx, "SAVEPOINT "+commons.SanitizeIdentifier(name))
+	return err
+}
+
+// RollbackToSavepoint undoes what the transaction wrote since the savepoint
+func (r *PostgresRepository) RollbackToSavepoint(ctx context.Context, name string) error {
+	_, err := r.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+commons.SanitizeIdentifier(name))
+	return err
+}
+
+// ReleaseSavepoint keeps what the transaction wrote since the savepoint
+func (r *PostgresRepository) ReleaseSavepoint(ctx context.Context, name string) error {
+	_, err := r.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+commons.SanitizeIdentifier(name))
+	return err
+}
+
 // InitializeTables creates the necessary tables if they don't exist
 // The entity_attributes table serves as the core mapping between entities and their dynamic attributes.
 // Purpose:
@@ -85,6 +165,9 @@ func (r *PostgresRepository) DB() *sql.DB {
 // Each attribute's actual data is stored in a separate dynamic table (named in table_name)
 // which is created on-demand when new attributes are added to an entity.
 func (r *PostgresRepository) InitializeTables(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.InitializeTables")
+	defer span.End()
+
 	// Create entity_attributes table
 	entityAttributesSQL := `
 	CREATE TABLE IF NOT EXISTS entity_attributes (
@@ -117,13 +200,32 @@ func (r *PostgresRepository) InitializeTables(ctx context.Context) error {
 		UNIQUE(table_name, schema_version)
 	);`
 
+	// Create attribute_values table
+	// Every value of an attribute is stored in a table of its own. The attribute_values table records
+	// the time-based interval of the value in each table, so that reads can find the value that held
+	// at a given time. A NULL start_time holds since ever and a NULL end_time until now.
+	attributeValuesSQL := `
+	CREATE TABLE IF NOT EXISTS attribute_values (
+		table_name VARCHAR(255) PRIMARY KEY,
+		entity_id VARCHAR(255) NOT NULL,
+		attribute_name VARCHAR(255) NOT NULL,
+		start_time TIMESTAMPTZ,
+		end_time TIMESTAMPTZ,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS attribute_values_entity_attribute ON attribute_values (entity_id, attribute_name, start_time);`
+
 	// Execute the creation queries
-	if _, err := r.db.ExecContext(ctx, entityAttributesSQL); err != nil {
-		return fmt.Errorf("error creating entity_attributes table: %v", err)
+	if _, err := r.conn().ExecContext(ctx, entityAttributesSQL); err != nil {
+		return fmt.Errorf("error creating entity_attributes table: %w", err)
+	}
+
+	if _, err := r.conn().ExecContext(ctx, attributeSchemasSQL); err != nil {
+		return fmt.Errorf("error creating attribute_schemas table: %w", err)
 	}
 
-	if _, err := r.db.ExecContext(ctx, attributeSchemasSQL); err != nil {
-		return fmt.Errorf("error creating attribute_schemas table: %v", err)
+	if _, err := r.conn().ExecContext(ctx, attributeValuesSQL); err != nil {
+		return fmt.Errorf("error creating attribute_values table: %w", err)
 	}
 
 	return nil
@@ -131,6 +233,9 @@ func (r *PostgresRepository) InitializeTables(ctx context.Context) error {
 
 // TableExists checks if a table exists in the database
 func (r *PostgresRepository) TableExists(ctx context.Context, tableName string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.TableExists", tracing.TableName(tableName))
+	defer span.End()
+
 	query := `
 	SELECT EXISTS (
 		SELECT FROM pg_tables
@@ -139,9 +244,9 @@ func (r *PostgresRepository) TableExists(ctx context.Context, tableName string)
 	);`
 
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, tableName).Scan(&exists)
+	err := r.conn().QueryRowContext(ctx, query, tableName).Scan(&exists)
 	if err != nil {
-		return false, fmt.Errorf("error checking table existence: %v", err)
+		return false, fmt.Errorf("error checking table existence: %w", err)
 	}
 
 	return exists, nil
@@ -149,6 +254,9 @@ func (r *PostgresRepository) TableExists(ctx context.Context, tableName string)
 
 // CreateDynamicTable creates a new table for storing attribute data
 func (r *PostgresRepository) CreateDynamicTable(ctx context.Context, tableName string, columns []Column) error {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.CreateDynamicTable", tracing.TableName(tableName))
+	defer span.End()
+
 	// Build column definitions
 	var columnDefs []string
 
@@ -171,8 +279,8 @@ func (r *PostgresRepository) CreateDynamicTable(ctx context.Context, tableName s
 	);`, tableName, strings.Join(columnDefs, ",\n"))
 
 	// Execute the creation query
-	if _, err := r.db.ExecContext(ctx, createTableSQL); err != nil {
-		return fmt.Errorf("error creating dynamic table: %v", err)
+	if _, err := r.conn().ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("error creating dynamic table: %w", err)
 	}
 
 	return nil
@@ -180,6 +288,9 @@ func (r *PostgresRepository) CreateDynamicTable(ctx context.Context, tableName s
 
 // InsertTabularData inserts rows into a dynamic table
 func (r *PostgresRepository) InsertTabularData(ctx context.Context, tableName string, entityAttributeID int, columns []string, rows [][]interface{}) error {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.InsertTabularData", tracing.TableName(tableName))
+	defer span.End()
+
 	// Build the INSERT query
 	columnNames := append([]string{"entity_attribute_id"}, columns...)
 	placeholders := make([]string, len(rows))
@@ -208,9 +319,9 @@ func (r *PostgresRepository) InsertTabularData(ctx context.Context, tableName st
 	}
 
 	// Execute the query
-	_, err := r.db.ExecContext(ctx, query, values...)
+	_, err := r.conn().ExecContext(ctx, query, values...)
 	if err != nil {
-		return fmt.Errorf("error inserting data: %v", err)
+		return fmt.Errorf("error inserting data: %w", err)
 	}
 
 	return nil
@@ -218,10 +329,290 @@ func (r *PostgresRepository) InsertTabularData(ctx context.Context, tableName st
 
 // GetTableList retrieves a list of attribute tables for a given entity ID.
 func (r *PostgresRepository) GetTableList(ctx context.Context, entityID string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.GetTableList", tracing.EntityID(entityID))
+	defer span.End()
+
 	return GetTableList(ctx, r, entityID)
 }
 
+// ListEntityAttributeTables retrieves every attribute table holding values of an entity: the table of
+// the latest value of each attribute from entity_attributes and the table of every value recorded in
+// attribute_values. Earlier values stored before attribute_values was introduced are not recorded
+// and are not found.
+// It returns an empty list when no tabular attribute was ever stored.
+func (r *PostgresRepository) ListEntityAttributeTables(ctx context.Context, entityID string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.ListEntityAttributeTables", tracing.EntityID(entityID))
+	defer span.End()
+
+	exists, err := r.TableExists(ctx, "entity_attributes")
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []string{}, nil
+	}
+	query := `SELECT table_name FROM entity_attributes WHERE entity_id = $1`
+	// Databases written before value intervals were recorded have no attribute_values table
+	hasIntervals, err := r.TableExists(ctx, "attribute_values")
+	if err != nil {
+		return nil, err
+	}
+	if hasIntervals {
+		query += ` UNION SELECT table_name FROM attribute_values WHERE entity_id = $1`
+	}
+
+	rows, err := r.conn().QueryContext(ctx, query+` ORDER BY table_name`, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying attribute tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := []string{}
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("error scanning attribute table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over attribute tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+// AttributeTableSnapshot records the attribute tables of an entity at a point in time
+type AttributeTableSnapshot struct {
+	EntityID string
+	// Tables holds every table with values of the entity
+	Tables []string
+	// Mappings maps each attribute name to the table of its latest value as stored in entity_attributes
+	Mappings map[string]string
+}
+
+// SnapshotEntityAttributeTables records the attribute tables of an entity so that
+// RestoreEntityAttributeTables can later undo tabular attribute writes
+func (r *PostgresRepository) SnapshotEntityAttributeTables(ctx context.Context, entityID string) (*AttributeTableSnapshot, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.SnapshotEntityAttributeTables", tracing.EntityID(entityID))
+	defer span.End()
+
+	snapshot := &AttributeTableSnapshot{EntityID: entityID, Mappings: map[string]string{}}
+
+	tables, err := r.ListEntityAttributeTables(ctx, entityID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Tables = tables
+	if len(tables) == 0 {
+		return snapshot, nil
+	}
+
+	rows, err := r.conn().QueryContext(ctx, `SELECT attribute_name, table_name FROM entity_attributes WHERE entity_id = $1`, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying entity attributes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var attributeName, tableName string
+		if err := rows.Scan(&attributeName, &tableName); err != nil {
+			return nil, fmt.Errorf("error scanning entity attribute: %w", err)
+		}
+		snapshot.Mappings[attributeName] = tableName
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over entity attributes: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// RestoreEntityAttributeTables brings the attribute tables of an entity back to a snapshot.
+// Tables created after the snapshot are dropped together with their schema records and the
+// entity_attributes mappings are reset. It returns the dropped table names.
+func (r *PostgresRepository) RestoreEntityAttributeTables(ctx context.Context, snapshot *AttributeTableSnapshot) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.RestoreEntityAttributeTables")
+	defer span.End()
+
+	current, err := r.ListEntityAttributeTables(ctx, snapshot.EntityID)
+	if err != nil {
+		return nil, err
+	}
+
+	dropped := []string{}
+	for _, tableName := range current {
+		if !slices.Contains(snapshot.Tables, tableName) {
+			dropped = append(dropped, tableName)
+		}
+	}
+	// Mappings only change when a table is created, so there is nothing else to restore
+	if len(dropped) == 0 {
+		return dropped, nil
+	}
+	// Databases written before value intervals were recorded have no attribute_values table
+	hasIntervals, err := r.TableExists(ctx, "attribute_values")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tableName := range dropped {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", commons.SanitizeIdentifier(tableName))); err != nil {
+			return nil, fmt.Errorf("error dropping table %s: %w", tableName, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM attribute_schemas WHERE table_name = $1`, tableName); err != nil {
+			return nil, fmt.Errorf("error deleting schema of table %s: %w", tableName, err)
+		}
+		if hasIntervals {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM attribute_values WHERE table_name = $1`, tableName); err != nil {
+				return nil, fmt.Errorf("error deleting interval of table %s: %w", tableName, err)
+			}
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT attribute_name, table_name FROM entity_attributes WHERE entity_id = $1`, snapshot.EntityID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying entity attributes: %w", err)
+	}
+	mappings := map[string]string{}
+	for rows.Next() {
+		var attributeName, tableName string
+		if err := rows.Scan(&attributeName, &tableName); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning entity attribute: %w", err)
+		}
+		mappings[attributeName] = tableName
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over entity attributes: %w", err)
+	}
+
+	for attributeName, tableName := range mappings {
+		previous, existed := snapshot.Mappings[attributeName]
+		switch {
+		case !existed:
+			_, err = tx.ExecContext(ctx, `DELETE FROM entity_attributes WHERE entity_id = $1 AND attribute_name = $2`, snapshot.EntityID, attributeName)
+		case previous != tableName:
+			_, err = tx.ExecContext(ctx, `UPDATE entity_attributes SET table_name = $3 WHERE entity_id = $1 AND attribute_name = $2`, snapshot.EntityID, attributeName, previous)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error restoring entity attribute %s of %s: %w", attributeName, snapshot.EntityID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return dropped, nil
+}
+
+// AttributeValue is the table holding a value of a tabular attribute and the interval the value holds for
+type AttributeValue struct {
+	AttributeName string
+	TableName     string
+	StartTime     string // RFC 3339, empty if the value holds since ever or its interval was not recorded
+	EndTime       string // RFC 3339, empty if the value still holds
+}
+
+// AttributeValueAt returns the value of the attribute that held at activeAt, or nil if none did.
+// Without activeAt it returns the latest value written. Values written before their intervals were
+// recorded in attribute_values are only found as the latest value.
+func (r *PostgresRepository) AttributeValueAt(ctx context.Context, entityID, attrName string, activeAt time.Time) (*AttributeValue, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.AttributeValueAt", tracing.EntityID(entityID), tracing.AttributeName(attrName))
+	defer span.End()
+
+	value := &AttributeValue{AttributeName: attrName}
+	var startTime, endTime sql.NullTime
+	var err error
+	if activeAt.IsZero() {
+		err = r.conn().QueryRowContext(ctx, `
+			SELECT ea.table_name, av.start_time, av.end_time
+			FROM entity_attributes ea LEFT JOIN attribute_values av ON av.table_name = ea.table_name
+			WHERE ea.entity_id = $1 AND ea.attribute_name = $2`,
+			entityID, attrName).Scan(&value.TableName, &startTime, &endTime)
+	} else {
+		// Of overlapping values the one starting last wins, like a later value replaces an earlier one
+		err = r.conn().QueryRowContext(ctx, `
+			SELECT table_name, start_time, end_time
+			FROM attribute_values
+			WHERE entity_id = $1 AND attribute_name = $2
+				AND (start_time IS NULL OR start_time <= $3) AND (end_time IS NULL OR end_time > $3)
+			ORDER BY start_time DESC NULLS LAST, created_at DESC
+			LIMIT 1`,
+			entityID, attrName, activeAt).Scan(&value.TableName, &startTime, &endTime)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	value.setInterval(startTime, endTime)
+	return value, nil
+}
+
+// ListAttributeValues returns every value of the tabular attributes of the entity, ordered by start time.
+// The latest value of an attribute written before intervals were recorded has no interval and comes first.
+func (r *PostgresRepository) ListAttributeValues(ctx context.Context, entityID string) ([]*AttributeValue, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.ListAttributeValues", tracing.EntityID(entityID))
+	defer span.End()
+
+	rows, err := r.conn().QueryContext(ctx, `
+		SELECT attribute_name, table_name, start_time, end_time FROM attribute_values WHERE entity_id = $1
+		UNION ALL
+		SELECT ea.attribute_name, ea.table_name, NULL, NULL FROM entity_attributes ea
+		WHERE ea.entity_id = $1 AND NOT EXISTS (SELECT 1 FROM attribute_values av WHERE av.table_name = ea.table_name)
+		ORDER BY start_time NULLS FIRST, attribute_name`, entityID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying attribute values: %w", err)
+	}
+	defer rows.Close()
+
+	values := []*AttributeValue{}
+	for rows.Next() {
+		value := &AttributeValue{}
+		var startTime, endTime sql.NullTime
+		if err := rows.Scan(&value.AttributeName, &value.TableName, &startTime, &endTime); err != nil {
+			return nil, fmt.Errorf("error scanning attribute value: %w", err)
+		}
+		value.setInterval(startTime, endTime)
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over attribute values: %w", err)
+	}
+	return values, nil
+}
+
+func (v *AttributeValue) setInterval(startTime, endTime sql.NullTime) {
+	if startTime.Valid {
+		v.StartTime = startTime.Time.UTC().Format(time.RFC3339)
+	}
+	if endTime.Valid {
+		v.EndTime = endTime.Time.UTC().Format(time.RFC3339)
+	}
+}
+
+// DeleteEntityAttributeTables drops every attribute table of an entity together with
+// its schema records and entity_attributes mappings. It returns the dropped table names.
+func (r *PostgresRepository) DeleteEntityAttributeTables(ctx context.Context, entityID string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.DeleteEntityAttributeTables", tracing.EntityID(entityID))
+	defer span.End()
+
+	return r.RestoreEntityAttributeTables(ctx, &AttributeTableSnapshot{EntityID: entityID})
+}
+
 // GetSchemaOfTable retrieves the schema for a given attribute table.
 func (r *PostgresRepository) GetSchemaOfTable(ctx context.Context, tableName string) (*schema.SchemaInfo, error) {
+	ctx, span := tracing.Start(ctx, "PostgresRepository.GetSchemaOfTable", tracing.TableName(tableName))
+	defer span.End()
+
 	return GetSchemaOfTable(ctx, r, tableName)
 }
@@ -0,0 +1,126 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DiffTabularData compares two values returned by GetData and returns the rows only the after value
+// has and the rows only the before value has, in the same format. Rows are compared by the values of
+// their columns, whatever the order of the columns, leaving out the id every table numbers its rows with.
+// A nil value has no rows.
+func DiffTabularData(before, after *anypb.Any) (added, removed *anypb.Any, err error) {
+	beforeData, err := decodeTabularData(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterData, err := decodeTabularData(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	beforeKeys, err := rowKeys(beforeData)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterKeys, err := rowKeys(afterData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if added, err = encodeTabularData(rowsMissing(afterData, afterKeys, beforeKeys)); err != nil {
+		return nil, nil, err
+	}
+	if removed, err = encodeTabularData(rowsMissing(beforeData, beforeKeys, afterKeys)); err != nil {
+		return nil, nil, err
+	}
+	return added, removed, nil
+}
+
+// rowsMissing returns the rows of the data, keyed by keys, that the other keys do not hold as often
+func rowsMissing(data *TabularData, keys []string, other []string) *TabularData {
+	counts := make(map[string]int, len(other))
+	for _, key := range other {
+		counts[key]++
+	}
+	missing := &TabularData{Columns: data.Columns, Rows: [][]interface{}{}}
+	for i, key := range keys {
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		missing.Rows = append(missing.Rows, data.Rows[i])
+	}
+	return missing
+}
+
+// rowKeys returns a key per row identifying it by the values of its columns
+func rowKeys(data *TabularData) ([]string, error) {
+	keys := make([]string, len(data.Rows))
+	for i, row := range data.Rows {
+		values := make(map[string]interface{}, len(row))
+		for j, column := range data.Columns {
+			if j < len(row) {
+				values[column] = row[j]
+			}
+		}
+		// encoding/json orders the keys of maps, so equal rows get equal keys
+		key, err := json.Marshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding row %d: %w", i, err)
+		}
+		keys[i] = string(key)
+	}
+	return keys, nil
+}
+
+// decodeTabularData decodes a value returned by GetData, without its id column
+func decodeTabularData(value *anypb.Any) (*TabularData, error) {
+	data := &TabularData{}
+	if value == nil {
+		return data, nil
+	}
+	var dataStruct structpb.Struct
+	if err := value.UnmarshalTo(&dataStruct); err != nil {
+		return nil, fmt.Errorf("error unmarshaling tabular data: %w", err)
+	}
+	if err := json.Unmarshal([]byte(dataStruct.Fields["data"].GetStringValue()), data); err != nil {
+		return nil, fmt.Errorf("error decoding tabular data: %w", err)
+	}
+	idIndex := -1
+	for i, column := range data.Columns {
+		if column == "id" {
+			idIndex = i
+		}
+	}
+	if idIndex < 0 {
+		return data, nil
+	}
+	data.Columns = append(data.Columns[:idIndex:idIndex], data.Columns[idIndex+1:]...)
+	for i, row := range data.Rows {
+		if idIndex < len(row) {
+			data.Rows[i] = append(row[:idIndex:idIndex], row[idIndex+1:]...)
+		}
+	}
+	return data, nil
+}
+
+// encodeTabularData encodes the data the way GetData returns it
+func encodeTabularData(data *TabularData) (*anypb.Any, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling tabular data to JSON: %w", err)
+	}
+	structValue, err := structpb.NewStruct(map[string]interface{}{
+		"data": string(jsonData),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating struct for JSON data: %w", err)
+	}
+	return anypb.New(structValue)
+}
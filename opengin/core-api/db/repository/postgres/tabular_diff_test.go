@@ -0,0 +1,56 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func tabular(t *testing.T, columns []string, rows ...[]interface{}) *anypb.Any {
+	value, err := encodeTabularData(&TabularData{Columns: columns, Rows: rows})
+	require.NoError(t, err)
+	return value
+}
+
+func TestDiffTabularData(t *testing.T) {
+	before := tabular(t, []string{"id", "department", "budget"},
+		[]interface{}{1, "Health", 100},
+		[]interface{}{2, "Education", 200},
+		[]interface{}{3, "Education", 200})
+	// A new table numbers its rows again and may order its columns differently
+	after := tabular(t, []string{"id", "budget", "department"},
+		[]interface{}{1, 100, "Health"},
+		[]interface{}{2, 200, "Education"},
+		[]interface{}{3, 300, "Transport"})
+
+	added, removed, err := DiffTabularData(before, after)
+	require.NoError(t, err)
+
+	addedData, err := decodeTabularData(added)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"budget", "department"}, addedData.Columns)
+	assert.Equal(t, [][]interface{}{{float64(300), "Transport"}}, addedData.Rows)
+
+	removedData, err := decodeTabularData(removed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"department", "budget"}, removedData.Columns)
+	assert.Equal(t, [][]interface{}{{"Education", float64(200)}}, removedData.Rows, "Expected a repeated row to be removed once")
+}
+
+func TestDiffTabularDataWithoutValue(t *testing.T) {
+	after := tabular(t, []string{"id", "department"}, []interface{}{1, "Health"})
+
+	added, removed, err := DiffTabularData(nil, after)
+	require.NoError(t, err)
+	addedData, err := decodeTabularData(added)
+	require.NoError(t, err)
+	assert.Len(t, addedData.Rows, 1)
+	removedData, err := decodeTabularData(removed)
+	require.NoError(t, err)
+	assert.Empty(t, removedData.Rows)
+}
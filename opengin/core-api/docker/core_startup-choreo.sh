@@ -16,5 +16,10 @@ echo "POSTGRES_HOST: $POSTGRES_HOST"
 
 
 echo "=== Starting CORE Service (Choreo Environment) ==="
-exec core-service 2>&1 | tee /app/core-service-choreo.log
+# Replace the shell so that core-service receives SIGTERM and shuts down gracefully,
+# its output still goes through tee
+rm -f /tmp/core-service.pipe
+mkfifo /tmp/core-service.pipe
+tee /app/core-service-choreo.log < /tmp/core-service.pipe &
+exec core-service > /tmp/core-service.pipe 2>&1
 
@@ -75,5 +75,10 @@ echo "✅ All tests passed!"
 clean_databases "After Tests"
 
 echo "=== Starting CORE Service ==="
-exec core-service 2>&1 | tee /app/core-service.log
+# Replace the shell so that core-service receives SIGTERM and shuts down gracefully,
+# its output still goes through tee
+rm -f /tmp/core-service.pipe
+mkfifo /tmp/core-service.pipe
+tee /app/core-service.log < /tmp/core-service.pipe &
+exec core-service > /tmp/core-service.pipe 2>&1
 
@@ -6,11 +6,15 @@ package engine
 import (
 	"context"
 	"fmt"
-	dbcommons "lk/datafoundation/core-api/commons/db"
+	postgres "lk/datafoundation/core-api/db/repository/postgres"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/metrics"
 	schema "lk/datafoundation/core-api/pkg/schema"
 	storageinference "lk/datafoundation/core-api/pkg/storageinference"
-	"log"
+	"lk/datafoundation/core-api/pkg/tracing"
+	"log/slog"
 
 	"time"
 
@@ -28,7 +32,7 @@ type Result struct {
 type AttributeResolver interface {
 	Initialize() error
 	CreateResolve(ctx context.Context, entityID, attrName string, value *pb.TimeBasedValue) *Result
-	ReadResolve(ctx context.Context, entityID, attrName string, filters map[string]interface{}, fields ...string) *Result
+	ReadResolve(ctx context.Context, entityID, attrName string, options *ReadOptions) *Result
 	UpdateResolve(ctx context.Context, entityID, attrName string, value *pb.TimeBasedValue) *Result
 	DeleteResolve(ctx context.Context, entityID, attrName string, value *pb.TimeBasedValue) *Result
 	Finalize() error
@@ -54,28 +58,46 @@ type EntityAttributeProcessor struct {
 	graphManager *GraphMetadataManager
 }
 
-// NewEntityAttributeProcessor creates a new processor with all resolvers initialized
-func NewEntityAttributeProcessor() *EntityAttributeProcessor {
+// NewEntityAttributeProcessor creates a new processor with all resolvers initialized, writing through the repositories
+func NewEntityAttributeProcessor(repositories *Repositories) *EntityAttributeProcessor {
 	processor := &EntityAttributeProcessor{
 		resolvers:    make(map[storageinference.StorageType]AttributeResolver),
-		graphManager: NewGraphMetadataManager(),
+		graphManager: NewGraphMetadataManager(repositories),
 	}
 
 	// Initialize all resolvers
 	processor.resolvers[storageinference.GraphData] = &GraphAttributeResolver{}
-	processor.resolvers[storageinference.TabularData] = &TabularAttributeResolver{}
+	processor.resolvers[storageinference.TabularData] = &TabularAttributeResolver{repo: repositories.Postgres}
 	processor.resolvers[storageinference.MapData] = &DocumentAttributeResolver{}
 
 	// Initialize each resolver
 	for _, resolver := range processor.resolvers {
 		if err := resolver.Initialize(); err != nil {
-			fmt.Printf("Warning: failed to initialize resolver: %v\n", err)
+			slog.Warn("Failed to initialize resolver", "error", err)
 		}
 	}
 
 	return processor
 }
 
+// WithPostgres returns a processor writing tabular attributes through another repository, such as
+// one returned by PostgresRepository.Begin
+func (p *EntityAttributeProcessor) WithPostgres(repo *postgres.PostgresRepository) *EntityAttributeProcessor {
+	processor := &EntityAttributeProcessor{
+		resolvers:    make(map[storageinference.StorageType]AttributeResolver, len(p.resolvers)),
+		graphManager: p.graphManager,
+	}
+	for storageType, resolver := range p.resolvers {
+		processor.resolvers[storageType] = resolver
+	}
+	tabular := &TabularAttributeResolver{repo: repo}
+	if err := tabular.Initialize(); err != nil {
+		slog.Warn("Failed to initialize resolver", "error", err)
+	}
+	processor.resolvers[storageinference.TabularData] = tabular
+	return processor
+}
+
 // GetResolver returns the resolver for a specific storage type
 func (p *EntityAttributeProcessor) GetResolver(storageType storageinference.StorageType) (AttributeResolver, bool) {
 	resolver, exists := p.resolvers[storageType]
@@ -85,7 +107,11 @@ func (p *EntityAttributeProcessor) GetResolver(storageType storageinference.Stor
 // ProcessEntityAttributes processes all attributes in an Entity with operation options
 // Returns a map of attribute names to their processing results
 func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context, entity *pb.Entity, operation string, options *Options) map[string]*Result {
-	log.Printf("Processing entity attributes at [processor.ProcessEntityAttributes] [operation: %s] [entity: %+v]", operation, entity)
+	ctx, span := tracing.Start(ctx, "EntityAttributeProcessor.ProcessEntityAttributes", tracing.EntityID(entity.GetId()), tracing.Operation(operation))
+	defer span.End()
+	logger := logging.FromContext(ctx).With("entity_id", entity.GetId(), "operation", operation)
+	logger.Debug("Processing entity attributes", "attributes", len(entity.GetAttributes()))
+
 	if entity == nil || entity.Attributes == nil {
 		return make(map[string]*Result)
 	}
@@ -95,9 +121,9 @@ func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context,
 
 	// Process each attribute
 	for attrName, timeBasedValueList := range entity.Attributes {
-		fmt.Printf("DEBUG: Processing attribute[%s] %s\n", operation, attrName)
+		logger.Debug("Processing attribute", "attribute", attrName)
 		if timeBasedValueList == nil {
-			log.Printf("DEBUG: Time-based value list is nil for attribute %s", attrName)
+			logger.Debug("Attribute has no values", "attribute", attrName)
 			attributeResults[attrName] = &Result{
 				Success: true,
 				Data:    nil,
@@ -106,7 +132,7 @@ func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context,
 			continue
 		}
 
-		log.Printf("DEBUG: Time-based value list is not nil for attribute %s, length: %d", attrName, len(timeBasedValueList.Values))
+		logger.Debug("Attribute has values", "attribute", attrName, "values", len(timeBasedValueList.Values))
 
 		// Process each time-based value
 		for _, value := range timeBasedValueList.Values {
@@ -114,75 +140,29 @@ func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context,
 				continue
 			}
 
-			log.Printf("DEBUG: Processing time-based value for attribute %s: %+v", attrName, value)
+			logger.Debug("Processing attribute value", "attribute", attrName, "start_time", value.StartTime, "end_time", value.EndTime, logging.Payload("value", value.Value))
 
 			// Determine storage type
 			storageType, err := p.determineStorageType(value.Value)
-			fmt.Printf("DEBUG: Determined storage type[%s] for attribute %s: %s\n", operation, attrName, storageType)
+			logger.Debug("Determined storage type", "attribute", attrName, "storage_type", storageType)
 			if err != nil {
 				attributeResults[attrName] = &Result{
 					Success: false,
 					Data:    nil,
-					Error:   fmt.Errorf("error determining storage type for attribute %s: %v", attrName, err),
-				}
-				continue
-			}
-
-			// Create or update graph metadata BEFORE processing the attribute
-			// NOTE: for the attribute the timestamp is always the value carried at the attribute level
-			// not the entity level. The entity level timestamp is used for the entity itself.
-			attributeStartTime, _ := time.Parse(time.RFC3339, value.StartTime)
-			if err := p.handleAttributeLookUp(ctx, entity.Id, attrName, storageType, operation, attributeStartTime); err != nil {
-				attributeResults[attrName] = &Result{
-					Success: false,
-					Data:    nil,
-					Error:   fmt.Errorf("error handling graph metadata for attribute %s: %v", attrName, err),
-				}
-				continue
-			}
-
-			// Get appropriate resolver
-			resolver, exists := p.resolvers[storageType]
-			if !exists {
-				fmt.Printf("Warning: no resolver found for storage type %s, skipping attribute %s\n", storageType, attrName)
-				attributeResults[attrName] = &Result{
-					Success: false,
-					Data:    nil,
-					Error:   fmt.Errorf("no resolver found for storage type %s", storageType),
+					Error:   apperrors.Wrap(apperrors.InvalidArgument, err, "error determining storage type for attribute %s", attrName).WithEntity(entity.Id).WithAttribute(attrName),
 				}
 				continue
 			}
 
-			// Execute the appropriate operation
-			var operationOptions *Options
-			if operation == "read" {
-				// Use provided options or default to empty filters
-				// TODO: Limitation in multi-value attribute reads.
-				// FIXME: https://github.com/LDFLK/nexoan/issues/285
-				if options != nil {
-					operationOptions = options
-				} else {
-					operationOptions = &Options{
-						ReadOptions: &ReadOptions{
-							Filters: make(map[string]interface{}),
-							Fields:  []string{}, // Empty means all fields
-						},
-					}
-				}
-			} else {
-				// For non-read operations, pass the options as-is
-				operationOptions = options
-			}
-			result := p.executeOperation(ctx, resolver, operation, entity.Id, attrName, value, operationOptions)
+			result := p.resolveAttribute(ctx, entity.Id, attrName, storageType, operation, value, options)
 
-			log.Printf("DEBUG: Result for attribute %s: %+v", attrName, result)
+			logger.Debug("Resolved attribute", "attribute", attrName, "success", result.Success, "error", result.Error)
 
 			// Store the result for this attribute
 			attributeResults[attrName] = result
 
 			// For read operations, we might want to do something with the result
 			if operation == "read" && result.Data != nil {
-				fmt.Printf("Read operation completed for attribute %s\n", attrName)
 				// TODO: Handle the read result (e.g., store it, return it, etc.)
 			}
 		}
@@ -191,6 +171,63 @@ func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context,
 	return attributeResults
 }
 
+// resolveAttribute creates the graph metadata of an attribute value and runs the operation
+// with the resolver of its storage type
+func (p *EntityAttributeProcessor) resolveAttribute(ctx context.Context, entityID, attrName string, storageType storageinference.StorageType, operation string, value *pb.TimeBasedValue, options *Options) (result *Result) {
+	ctx, span := tracing.Start(ctx, "EntityAttributeProcessor.resolveAttribute", tracing.EntityID(entityID), tracing.AttributeName(attrName), tracing.StorageType(string(storageType)), tracing.Operation(operation))
+	defer func() {
+		tracing.End(span, result.Error)
+	}()
+
+	// Create or update graph metadata BEFORE processing the attribute
+	// NOTE: for the attribute the timestamp is always the value carried at the attribute level
+	// not the entity level. The entity level timestamp is used for the entity itself.
+	attributeStartTime, _ := time.Parse(time.RFC3339, value.StartTime)
+	if err := p.handleAttributeLookUp(ctx, entityID, attrName, storageType, operation, attributeStartTime); err != nil {
+		return &Result{
+			Success: false,
+			Data:    nil,
+			Error:   fmt.Errorf("error handling graph metadata for attribute %s: %w", attrName, err),
+		}
+	}
+
+	// Get appropriate resolver
+	resolver, exists := p.resolvers[storageType]
+	if !exists {
+		logging.FromContext(ctx).Warn("No resolver for storage type, skipping attribute", "entity_id", entityID, "attribute", attrName, "storage_type", storageType)
+		return &Result{
+			Success: false,
+			Data:    nil,
+			Error:   fmt.Errorf("no resolver found for storage type %s", storageType),
+		}
+	}
+
+	// Execute the appropriate operation
+	var operationOptions *Options
+	if operation == "read" {
+		// Use provided options or default to empty filters
+		// TODO: Limitation in multi-value attribute reads.
+		// FIXME: https://github.com/LDFLK/nexoan/issues/285
+		if options != nil {
+			operationOptions = options
+		} else {
+			operationOptions = &Options{
+				ReadOptions: &ReadOptions{
+					Filters: make(map[string]interface{}),
+					Fields:  []string{}, // Empty means all fields
+				},
+			}
+		}
+	} else {
+		// For non-read operations, pass the options as-is
+		operationOptions = options
+	}
+	resolveStart := time.Now()
+	result = p.executeOperation(ctx, resolver, operation, entityID, attrName, value, operationOptions)
+	metrics.ObserveAttribute(string(storageType), operation, resolveStart, result.Error)
+	return result
+}
+
 // handleAttributeLookUp handles the attribute look up operations
 // This is the first step in the attribute processing pipeline.
 // It creates the attribute look up metadata and the attribute node in the graph.
@@ -198,7 +235,7 @@ func (p *EntityAttributeProcessor) ProcessEntityAttributes(ctx context.Context,
 // It also creates the attribute metadata in the document database.
 func (p *EntityAttributeProcessor) handleAttributeLookUp(ctx context.Context, entityID, attrName string, storageType storageinference.StorageType, operation string, startTime time.Time) error {
 	// Generate attribute metadata
-	fmt.Printf("DEBUG: Handling graph metadata for attribute %s\n", attrName)
+	logging.FromContext(ctx).Debug("Handling graph metadata of attribute", "entity_id", entityID, "attribute", attrName)
 	attributeID := GenerateAttributeID()
 	storagePath := GenerateStoragePath(entityID, attrName, storageType)
 
@@ -219,31 +256,31 @@ func (p *EntityAttributeProcessor) handleAttributeLookUp(ctx context.Context, en
 	case "create":
 		// Create attribute node in graph
 		if err := p.graphManager.CreateAttribute(ctx, metadata); err != nil {
-			return fmt.Errorf("failed to create attribute node: %v", err)
+			return fmt.Errorf("failed to create attribute node: %w", err)
 		}
 
 	case "update":
 		// Update attribute metadata in graph
 		if err := p.graphManager.UpdateAttribute(ctx, metadata); err != nil {
 			metadata.Updated = time.Now()
-			return fmt.Errorf("failed to update attribute metadata: %v", err)
+			return fmt.Errorf("failed to update attribute metadata: %w", err)
 		}
 
 	case "delete":
 		// Delete attribute node and relationships from graph
 		if err := p.graphManager.DeleteAttribute(ctx, entityID, attrName); err != nil {
 			metadata.Updated = time.Now()
-			return fmt.Errorf("failed to delete attribute node: %v", err)
+			return fmt.Errorf("failed to delete attribute node: %w", err)
 		}
 
 	case "read":
 		// For read operations, retrieve the attribute metadata from the graph
 		attributeMetadata, err := p.graphManager.GetAttribute(ctx, entityID, attrName, startTime)
 		if err != nil {
-			fmt.Printf("Warning: attribute %s not found in graph metadata for entity %s\n", attrName, entityID)
+			logging.FromContext(ctx).Warn("Attribute not found in graph metadata", "entity_id", entityID, "attribute", attrName, "error", err)
 		} else if attributeMetadata != nil {
 			// Store the retrieved metadata for potential use
-			fmt.Printf("DEBUG: Retrieved attribute metadata for %s: %+v\n", attrName, attributeMetadata)
+			logging.FromContext(ctx).Debug("Retrieved attribute metadata", "entity_id", entityID, "attribute", attrName, "attribute_id", attributeMetadata.AttributeID, "storage_path", attributeMetadata.StoragePath)
 		}
 	}
 
@@ -253,7 +290,7 @@ func (p *EntityAttributeProcessor) handleAttributeLookUp(ctx context.Context, en
 // determineStorageType determines the storage type of a TimeBasedValue
 func (p *EntityAttributeProcessor) determineStorageType(anyValue *anypb.Any) (storageinference.StorageType, error) {
 	if anyValue == nil {
-		return storageinference.UnknownData, fmt.Errorf("anyValue is nil")
+		return storageinference.UnknownData, apperrors.InvalidArgumentf("anyValue is nil")
 	}
 
 	// Use the storage inference logic to determine type
@@ -282,6 +319,8 @@ type Options struct {
 type ReadOptions struct {
 	Filters map[string]interface{}
 	Fields  []string
+	// ActiveAt reads the value that held at this time instead of the latest one
+	ActiveAt time.Time
 }
 
 // CreateOptions contains options for create operations
@@ -345,26 +384,22 @@ func (p *EntityAttributeProcessor) executeOperation(ctx context.Context, resolve
 	switch operation {
 	case "create":
 		// TODO: Use CreateOptions when implemented
-		log.Printf("Creating attribute %s for entity %s\n", attrName, entityID)
+		logging.FromContext(ctx).Debug("Creating attribute", "entity_id", entityID, "attribute", attrName)
 		return resolver.CreateResolve(ctx, entityID, attrName, value)
 	case "read":
 		// Use provided options or default to empty filters
-		log.Printf("Reading attribute %s for entity %s\n", attrName, entityID)
-		var filters map[string]interface{}
-		var fields []string
+		logging.FromContext(ctx).Debug("Reading attribute", "entity_id", entityID, "attribute", attrName)
+		readOptions := &ReadOptions{Filters: make(map[string]interface{})}
 		if options != nil && options.ReadOptions != nil {
-			filters = options.ReadOptions.Filters
-			fields = options.ReadOptions.Fields
-		} else {
-			filters = make(map[string]interface{})
+			readOptions = options.ReadOptions
 		}
-		return resolver.ReadResolve(ctx, entityID, attrName, filters, fields...)
+		return resolver.ReadResolve(ctx, entityID, attrName, readOptions)
 	case "update":
-		log.Printf("Updating attribute %s for entity %s\n", attrName, entityID)
+		logging.FromContext(ctx).Debug("Updating attribute", "entity_id", entityID, "attribute", attrName)
 		// TODO: Use UpdateOptions when implemented
 		return resolver.UpdateResolve(ctx, entityID, attrName, value)
 	case "delete":
-		log.Printf("Deleting attribute %s for entity %s\n", attrName, entityID)
+		logging.FromContext(ctx).Debug("Deleting attribute", "entity_id", entityID, "attribute", attrName)
 		// TODO: Use DeleteOptions when implemented
 		return resolver.DeleteResolve(ctx, entityID, attrName, value)
 	default:
@@ -386,7 +421,7 @@ func (r *GraphAttributeResolver) CreateResolve(ctx context.Context, entityID, at
 	// - Validate graph structure (nodes and edges)
 	// - Store in graph database (Neo4j)
 	// - Handle graph relationships
-	fmt.Printf("Creating graph attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Creating graph attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -394,12 +429,12 @@ func (r *GraphAttributeResolver) CreateResolve(ctx context.Context, entityID, at
 	}
 }
 
-func (r *GraphAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, filters map[string]interface{}, fields ...string) *Result {
+func (r *GraphAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, options *ReadOptions) *Result {
 	// TODO: implement graph-specific read logic
 	// - Query graph database
 	// - Retrieve nodes and edges
 	// - Return graph structure
-	fmt.Printf("Reading graph attribute %s for entity %s with filters: %+v and fields: %+v\n", attrName, entityID, filters, fields)
+	logging.FromContext(ctx).Debug("Reading graph attribute", "entity_id", entityID, "attribute", attrName, logging.Payload("filters", options.Filters), "fields", options.Fields)
 
 	// TODO: Return actual graph data from Neo4j
 	// For now, return empty TimeBasedValue
@@ -421,7 +456,7 @@ func (r *GraphAttributeResolver) UpdateResolve(ctx context.Context, entityID, at
 	// - Update nodes and edges
 	// - Handle graph modifications
 	// - Maintain graph consistency
-	fmt.Printf("Updating graph attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Updating graph attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -434,7 +469,7 @@ func (r *GraphAttributeResolver) DeleteResolve(ctx context.Context, entityID, at
 	// - Remove nodes and edges
 	// - Clean up relationships
 	// - Handle cascading deletes
-	fmt.Printf("Deleting graph attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Deleting graph attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -445,6 +480,7 @@ func (r *GraphAttributeResolver) DeleteResolve(ctx context.Context, entityID, at
 // TabularAttributeResolver handles tabular data structures with columns and rows
 type TabularAttributeResolver struct {
 	BaseAttributeResolver
+	repo *postgres.PostgresRepository
 }
 
 func (r *TabularAttributeResolver) CreateResolve(ctx context.Context, entityID, attrName string, value *pb.TimeBasedValue) *Result {
@@ -459,45 +495,27 @@ func (r *TabularAttributeResolver) CreateResolve(ctx context.Context, entityID,
 		return &Result{
 			Data:    nil,
 			Success: false,
-			Error:   fmt.Errorf("values are nil"),
+			Error:   apperrors.InvalidArgumentf("values are nil"),
 		}
 	}
 
-	fmt.Printf("Creating tabular attribute %s for entity %s (validated as tabular) from %v to %v\n", attrName, entityID, startDate, endDate)
-
-	repo, err := dbcommons.GetPostgresRepository(ctx)
-	if err != nil {
-		return &Result{
-			Data:    nil,
-			Success: false,
-			Error:   fmt.Errorf("failed to get Postgres repository: %v", err),
-		}
-	}
-
-	// Initialize database tables if they don't exist
-	if err := repo.InitializeTables(ctx); err != nil {
-		return &Result{
-			Data:    nil,
-			Success: false,
-			Error:   fmt.Errorf("failed to initialize database tables: %v", err),
-		}
-	}
+	logging.FromContext(ctx).Debug("Creating tabular attribute", "entity_id", entityID, "attribute", attrName, "start_time", startDate, "end_time", endDate)
 
 	schemaInfo, err := schema.GenerateSchema(value.Value)
 	if err != nil {
 		return &Result{
 			Data:    nil,
 			Success: false,
-			Error:   fmt.Errorf("failed to generate schema: %v", err),
+			Error:   fmt.Errorf("failed to generate schema: %w", err),
 		}
 	}
 
-	err = repo.HandleTabularData(ctx, entityID, attrName, value, schemaInfo)
+	err = r.repo.HandleTabularData(ctx, entityID, attrName, value, schemaInfo)
 	if err != nil {
 		return &Result{
 			Data:    nil,
 			Success: false,
-			Error:   fmt.Errorf("failed to handle tabular data: %v", err),
+			Error:   fmt.Errorf("failed to handle tabular data: %w", err),
 		}
 	}
 
@@ -508,53 +526,48 @@ func (r *TabularAttributeResolver) CreateResolve(ctx context.Context, entityID,
 	}
 }
 
-func (r *TabularAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, filters map[string]interface{}, fields ...string) *Result {
+func (r *TabularAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, options *ReadOptions) *Result {
 	// TODO: implement tabular-specific read logic
 	// - Query database table
 	// - Retrieve rows and columns
 	// - Return tabular structure
-	fmt.Printf("[TabularAttributeResolver.ReadResolve] Reading tabular attribute %s for entity %s with filters: %+v and fields: %+v\n", attrName, entityID, filters, fields)
+	logging.FromContext(ctx).Debug("Reading tabular attribute", "entity_id", entityID, "attribute", attrName, logging.Payload("filters", options.Filters), "fields", options.Fields, "active_at", options.ActiveAt)
 
-	repo, err := dbcommons.GetPostgresRepository(ctx)
+	// Look up the table of the value, every value is stored in a table of its own
+	// The table name is UUID-based and stored during create operation
+	attributeValue, err := r.repo.AttributeValueAt(ctx, entityID, attrName, options.ActiveAt)
 	if err != nil {
 		return &Result{
 			Data:    nil,
 			Success: false,
-			Error:   fmt.Errorf("failed to get Postgres repository: %v", err),
+			Error:   fmt.Errorf("failed to find table for attribute %s of entity %s: %w", attrName, entityID, err),
 		}
 	}
-
-	// Look up the actual table name from entity_attributes table
-	// The table name is UUID-based and stored during create operation
-	var tableName string
-	err = repo.DB().QueryRowContext(ctx,
-		`SELECT table_name FROM entity_attributes WHERE entity_id = $1 AND attribute_name = $2`,
-		entityID, attrName).Scan(&tableName)
-	if err != nil {
+	if attributeValue == nil {
+		// The attribute had no value at that time
+		logging.FromContext(ctx).Debug("No value of attribute at time", "entity_id", entityID, "attribute", attrName, "active_at", options.ActiveAt)
 		return &Result{
 			Data:    nil,
-			Success: false,
-			Error:   fmt.Errorf("failed to find table for attribute %s of entity %s: %v", attrName, entityID, err),
+			Success: true,
+			Error:   nil,
 		}
 	}
-	log.Printf("[TabularAttributeResolver.ReadResolve] Found tableName: %s", tableName)
+	logging.FromContext(ctx).Debug("Found table of attribute", "entity_id", entityID, "attribute", attrName, "table", attributeValue.TableName)
 
 	// Use the GetData method from the repository to retrieve data with filters and fields
-	anyData, err := repo.GetData(ctx, tableName, filters, fields...)
+	anyData, err := r.repo.GetData(ctx, attributeValue.TableName, options.Filters, options.Fields...)
 	if err != nil {
 		return &Result{
 			Data:    nil,
 			Success: false,
-			Error:   fmt.Errorf("failed to get data: %v", err),
+			Error:   fmt.Errorf("failed to get data: %w", err),
 		}
 	}
 
-	fmt.Printf("Retrieved data from table %s\n", tableName)
-
 	// The data is already in the correct format (pb.Any with JSON)
 	timeBasedValue := &pb.TimeBasedValue{
-		StartTime: "",
-		EndTime:   "",
+		StartTime: attributeValue.StartTime,
+		EndTime:   attributeValue.EndTime,
 		Value:     anyData,
 	}
 
@@ -570,7 +583,7 @@ func (r *TabularAttributeResolver) UpdateResolve(ctx context.Context, entityID,
 	// - Update table schema if needed
 	// - Update data rows
 	// - Handle schema evolution
-	fmt.Printf("Updating tabular attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Updating tabular attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -583,7 +596,7 @@ func (r *TabularAttributeResolver) DeleteResolve(ctx context.Context, entityID,
 	// - Delete data rows
 	// - Optionally drop table
 	// - Clean up schema
-	fmt.Printf("Deleting tabular attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Deleting tabular attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -601,7 +614,7 @@ func (r *DocumentAttributeResolver) CreateResolve(ctx context.Context, entityID,
 	// - Validate document structure
 	// - Store in document database (MongoDB)
 	// - Handle document indexing
-	fmt.Printf("Creating document attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Creating document attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -609,12 +622,12 @@ func (r *DocumentAttributeResolver) CreateResolve(ctx context.Context, entityID,
 	}
 }
 
-func (r *DocumentAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, filters map[string]interface{}, fields ...string) *Result {
+func (r *DocumentAttributeResolver) ReadResolve(ctx context.Context, entityID, attrName string, options *ReadOptions) *Result {
 	// TODO: implement document-specific read logic
 	// - Query document database
 	// - Retrieve document structure
 	// - Return key-value pairs
-	fmt.Printf("Reading document attribute %s for entity %s with filters: %+v and fields: %+v\n", attrName, entityID, filters, fields)
+	logging.FromContext(ctx).Debug("Reading document attribute", "entity_id", entityID, "attribute", attrName, logging.Payload("filters", options.Filters), "fields", options.Fields)
 
 	// TODO: Return actual document data from MongoDB
 	// For now, return empty TimeBasedValue
@@ -636,7 +649,7 @@ func (r *DocumentAttributeResolver) UpdateResolve(ctx context.Context, entityID,
 	// - Update document fields
 	// - Handle partial updates
 	// - Maintain document consistency
-	fmt.Printf("Updating document attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Updating document attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -649,7 +662,7 @@ func (r *DocumentAttributeResolver) DeleteResolve(ctx context.Context, entityID,
 	// - Remove document
 	// - Clean up indexes
 	// - Handle cascading deletes
-	fmt.Printf("Deleting document attribute %s for entity %s\n", attrName, entityID)
+	logging.FromContext(ctx).Debug("Deleting document attribute", "entity_id", entityID, "attribute", attrName)
 	return &Result{
 		Data:    nil,
 		Success: true,
@@ -6,11 +6,16 @@ package engine
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
 	"testing"
 	"time"
 
 	"lk/datafoundation/core-api/commons"
-	dbcommons "lk/datafoundation/core-api/commons/db"
+	"lk/datafoundation/core-api/db/config"
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	neo4jrepository "lk/datafoundation/core-api/db/repository/neo4j"
+	postgres "lk/datafoundation/core-api/db/repository/postgres"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
 	"lk/datafoundation/core-api/pkg/schema"
 	"lk/datafoundation/core-api/pkg/storageinference"
@@ -18,6 +23,48 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var testRepositories *Repositories
+
+// TestMain connects the repositories the engine is tested with from the environment variables
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	neo4jRepository, err := neo4jrepository.NewNeo4jRepository(ctx, &config.Neo4jConfig{
+		URI:      os.Getenv("NEO4J_URI"),
+		Username: os.Getenv("NEO4J_USER"),
+		Password: os.Getenv("NEO4J_PASSWORD"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create Neo4j repository: %v", err)
+	}
+	mongoRepository := mongorepository.NewMongoRepository(ctx, &config.MongoConfig{
+		URI:        os.Getenv("MONGO_URI"),
+		DBName:     os.Getenv("MONGO_DB_NAME"),
+		Collection: os.Getenv("MONGO_COLLECTION"),
+	})
+	postgresRepository, err := postgres.NewPostgresRepository(postgres.Config{
+		Host:     os.Getenv("POSTGRES_HOST"),
+		Port:     os.Getenv("POSTGRES_PORT"),
+		User:     os.Getenv("POSTGRES_USER"),
+		Password: os.Getenv("POSTGRES_PASSWORD"),
+		DBName:   os.Getenv("POSTGRES_DB"),
+		SSLMode:  os.Getenv("POSTGRES_SSL_MODE"),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create Postgres repository: %v", err)
+	}
+	if err := postgresRepository.InitializeTables(ctx); err != nil {
+		log.Fatalf("Failed to initialize Postgres tables: %v", err)
+	}
+	testRepositories = &Repositories{Neo4j: neo4jRepository, Mongo: mongoRepository, Postgres: postgresRepository}
+
+	code := m.Run()
+
+	neo4jRepository.Close(ctx)
+	mongoRepository.Close(ctx)
+	postgresRepository.Close()
+	os.Exit(code)
+}
+
 // createTimeBasedValue creates a TimeBasedValue with the given JSON data
 func createTimeBasedValue(jsonStr string) (*pb.TimeBasedValue, error) {
 	anyValue, err := schema.JSONToAny(jsonStr)
@@ -60,12 +107,7 @@ func createEntityWithAttributes(entityID string, entityName string, attributes m
 }
 
 func saveEntityToDatabase(ctx context.Context, entity *pb.Entity) error {
-	neo4jRepository, err := dbcommons.GetNeo4jRepository(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get Neo4j repository: %w", err)
-	}
-
-	success, err := neo4jRepository.HandleGraphEntityCreation(ctx, entity)
+	success, err := testRepositories.Neo4j.HandleGraphEntityCreation(ctx, entity)
 	if !success {
 		return fmt.Errorf("failed to save entity: %w", err)
 	}
@@ -112,7 +154,7 @@ func TestEntityWithGraphDataOnly(t *testing.T) {
 	err = saveEntityToDatabase(ctx, entity)
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 
 	// Test all CORE operations
 	// create test merely checks if the ProcessEntityAttributes function is working
@@ -154,7 +196,7 @@ func TestEntityWithTabularDataOnly(t *testing.T) {
 	err = saveEntityToDatabase(ctx, entity)
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 
 	// Test all CORE operations
 	// TODO: "read", "update", "delete"
@@ -205,7 +247,7 @@ func TestEntityWithDocumentDataOnly(t *testing.T) {
 	err = saveEntityToDatabase(ctx, entity)
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 
 	// Test all CORE operations
 	operations := []string{"create", "read", "update", "delete"}
@@ -264,7 +306,7 @@ func TestEntityWithMixedDataTypes(t *testing.T) {
 	err = saveEntityToDatabase(ctx, entity)
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 
 	// Test all CORE operations
 	// TODO: "read", "update", "delete"
@@ -310,7 +352,7 @@ func TestComplexGraphEntity(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save parent entity to the database
@@ -353,7 +395,7 @@ func TestComplexTabularEntity(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save parent entity to the database
@@ -423,7 +465,7 @@ func TestComplexDocumentEntity(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save parent entity to the database
@@ -487,7 +529,7 @@ func TestEntityWithMultipleAttributesOfSameType(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save parent entity to the database
@@ -549,7 +591,7 @@ func TestStorageTypeDetection(t *testing.T) {
 		},
 	}
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 
 	for testName, testCase := range testCases {
 		t.Run(testName, func(t *testing.T) {
@@ -575,7 +617,7 @@ func TestEmptyEntity(t *testing.T) {
 		Attributes: make(map[string]*pb.TimeBasedValueList),
 	}
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// Test all CORE operations
@@ -596,7 +638,7 @@ func TestEmptyEntity(t *testing.T) {
 
 // TestNilEntity tests handling of nil entity
 func TestNilEntity(t *testing.T) {
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// Test all CORE operations
@@ -628,7 +670,7 @@ func TestInvalidOperation(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	options := getOptionsForOperation("invalid_operation")
@@ -654,7 +696,7 @@ func TestUnsupportedStorageType(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save parent entity to the database
@@ -683,7 +725,7 @@ func TestUnsupportedStorageType(t *testing.T) {
 // TestBasicFunctionality tests basic functionality of the attribute resolver
 func TestBasicFunctionality(t *testing.T) {
 	// Test that we can create a processor
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	assert.NotNil(t, processor)
 	assert.NotNil(t, processor.resolvers)
 
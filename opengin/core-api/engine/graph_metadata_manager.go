@@ -6,14 +6,19 @@ package engine
 import (
 	"context"
 	"fmt"
-	"log"
+	"slices"
 	"strings"
 	"time"
 
 	"lk/datafoundation/core-api/commons"
-	dbcommons "lk/datafoundation/core-api/commons/db"
+	mongorepository "lk/datafoundation/core-api/db/repository/mongo"
+	neo4jrepository "lk/datafoundation/core-api/db/repository/neo4j"
+	postgres "lk/datafoundation/core-api/db/repository/postgres"
 	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/logging"
 	"lk/datafoundation/core-api/pkg/storageinference"
+	"lk/datafoundation/core-api/pkg/tracing"
 
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -37,15 +42,22 @@ const IS_ATTRIBUTE_RELATIONSHIP = "IS_ATTRIBUTE"
 // The reason for outgoing is the attribute we create here is an attribute of the parent entity.
 const IS_ATTRIBUTE_RELATIONSHIP_DIRECTION = "OUTGOING"
 
+// Repositories are the databases the engine reads and writes attributes through. They are the
+// repositories of the server, which closes them.
+type Repositories struct {
+	Neo4j    *neo4jrepository.Neo4jRepository
+	Mongo    *mongorepository.MongoRepository
+	Postgres *postgres.PostgresRepository
+}
+
 // GraphMetadataManager handles the reference graph for tracking attributes
 type GraphMetadataManager struct {
-	// This would typically connect to Neo4j or another graph database
-	// For now, we'll define the interface and structure
+	repositories *Repositories
 }
 
 // NewGraphMetadataManager creates a new graph metadata manager
-func NewGraphMetadataManager() *GraphMetadataManager {
-	return &GraphMetadataManager{}
+func NewGraphMetadataManager(repositories *Repositories) *GraphMetadataManager {
+	return &GraphMetadataManager{repositories: repositories}
 }
 
 // AttributeMetadata represents metadata for an attribute in the graph
@@ -63,8 +75,11 @@ type AttributeMetadata struct {
 
 // CreateAttributeNode creates a node in the graph for an attribute
 func (g *GraphMetadataManager) CreateAttribute(ctx context.Context, metadata *AttributeMetadata) error {
-	fmt.Printf("Creating attribute node: Entity=%s, Attribute=%s, StorageType=%s, Path=%s\n",
-		metadata.EntityID, metadata.AttributeName, metadata.StorageType, metadata.StoragePath)
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.CreateAttribute", tracing.EntityID(metadata.EntityID), tracing.AttributeName(metadata.AttributeName))
+	defer span.End()
+
+	logging.FromContext(ctx).Debug("Creating attribute node", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName,
+		"storage_type", metadata.StorageType, "storage_path", metadata.StoragePath)
 	// create the attribute look up graph
 	err := g.createAttributeLookUpGraph(ctx, metadata)
 	if err != nil {
@@ -116,8 +131,8 @@ func (g *GraphMetadataManager) CreateAttribute(ctx context.Context, metadata *At
 // Note: This method creates the attribute node and relationship but does not create
 // the parent entity node itself.
 func (g *GraphMetadataManager) createAttributeLookUpGraph(ctx context.Context, metadata *AttributeMetadata) error {
-	fmt.Printf("Creating attribute look up graph: Entity=%s, Attribute=%s, StorageType=%s, Path=%s\n",
-		metadata.EntityID, metadata.AttributeName, metadata.StorageType, metadata.StoragePath)
+	logging.FromContext(ctx).Debug("Creating attribute look up graph", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName,
+		"storage_type", metadata.StorageType, "storage_path", metadata.StoragePath)
 	// TODO: Explore a way to update the Look up graph
 	// FIXME: https://github.com/LDFLK/nexoan/issues/288
 
@@ -155,57 +170,53 @@ func (g *GraphMetadataManager) createAttributeLookUpGraph(ctx context.Context, m
 		},
 	}
 
-	neo4jRepository, err := dbcommons.GetNeo4jRepository(ctx)
-	if err != nil {
-		log.Printf("[GraphMetadataManager.CreateAttribute] Error getting Neo4j repository: %v", err)
-		return err
-	}
+	neo4jRepository := g.repositories.Neo4j
 
 	// Check if the attribute node already exists
 	existingEntity, err := neo4jRepository.ReadGraphEntity(ctx, metadata.AttributeID)
 	if err == nil && existingEntity != nil {
-		log.Printf("[GraphMetadataManager.CreateAttribute] Attribute node already exists: %s, skipping creation", metadata.AttributeID)
+		logging.FromContext(ctx).Debug("Attribute node already exists, skipping creation", "attribute_id", metadata.AttributeID)
 		// Node already exists, we can still proceed to create/update the relationship
 	} else {
 		// Node doesn't exist, create it
 		success, err := neo4jRepository.HandleGraphEntityCreation(ctx, attributeNode)
 		if !success {
-			log.Printf("[GraphMetadataManager.CreateAttribute] Error creating attributeNode as a graph entity: %v", err)
+			logging.FromContext(ctx).Error("Error creating attributeNode as a graph entity", "error", err)
 			return err
 		}
-		log.Printf("[GraphMetadataManager.CreateAttribute] Successfully created attribute node for entity: %s, attribute: %s", metadata.EntityID, metadata.AttributeName)
+		logging.FromContext(ctx).Debug("Created attribute node", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName)
 
 		// FIXME: This means that when updating an attribute we cannot update the relationship
 		// FIXME: https://github.com/LDFLK/nexoan/issues/346
 		// create the relationship between the entity and the attribute
 		err = neo4jRepository.HandleGraphRelationshipsUpdate(ctx, parentNode)
 		if err != nil {
-			log.Printf("[GraphMetadataManager.CreateAttribute] Error creating relationship between entity and attribute: %v", err)
+			logging.FromContext(ctx).Error("Error creating relationship between entity and attribute", "error", err)
 			return err
 		}
 	}
 
-	log.Printf("[GraphMetadataManager.CreateAttribute] Successfully created relationship for entity: %s, attribute: %s", metadata.EntityID, metadata.AttributeName)
+	logging.FromContext(ctx).Debug("Created relationship to attribute", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName)
 
 	// create the attribute metadata in the mongo database
 	// stored parameters: attribute_id, attribute_name, storage_type, storage_path, updated, schema
-	mongoRepository := dbcommons.GetMongoRepository(ctx)
+	mongoRepository := g.repositories.Mongo
 
 	// Check if the attribute metadata already exists
 	existingMetadata, err := mongoRepository.ReadEntity(ctx, metadata.AttributeID)
 	if err == nil && existingMetadata != nil {
-		log.Printf("[GraphMetadataManager.CreateAttribute] Attribute metadata already exists: %s, skipping creation", metadata.AttributeID)
+		logging.FromContext(ctx).Debug("Attribute metadata already exists, skipping creation", "attribute_id", metadata.AttributeID)
 	} else {
 		// Metadata doesn't exist, create it
 		_, err = mongoRepository.CreateEntity(ctx, attributeNode)
 		if err != nil {
-			log.Printf("[GraphMetadataManager.CreateAttribute] Error creating attribute metadata: %v", err)
+			logging.FromContext(ctx).Error("Error creating attribute metadata", "error", err)
 			return err
 		}
-		log.Printf("[GraphMetadataManager.CreateAttribute] Successfully created attribute metadata for entity: %s, attribute: %s", metadata.EntityID, metadata.AttributeName)
+		logging.FromContext(ctx).Debug("Created attribute metadata", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName)
 	}
 
-	log.Print("Lookup graph created successfully!")
+	logging.FromContext(ctx).Debug("Lookup graph created successfully")
 
 	return nil
 }
@@ -248,27 +259,26 @@ func MakeRelationshipFromAttributeMetadata(metadata *AttributeMetadata) *pb.Rela
 
 // GetAttributeMetadata retrieves metadata for an attribute
 func (g *GraphMetadataManager) GetAttribute(ctx context.Context, entityID string, attributeName string, startTime time.Time) (*AttributeMetadata, error) {
-	fmt.Printf("Getting attribute metadata: EntityID=%s, AttributeName=%s\n", entityID, attributeName)
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.GetAttribute", tracing.EntityID(entityID), tracing.AttributeName(attributeName))
+	defer span.End()
 
-	neo4jRepository, err := dbcommons.GetNeo4jRepository(ctx)
-	if err != nil {
-		log.Printf("[GraphMetadataManager.GetAttribute] Error getting Neo4j repository: %v", err)
-		return nil, err
-	}
+	logging.FromContext(ctx).Debug("Getting attribute metadata", "entity_id", entityID, "attribute", attributeName)
+
+	neo4jRepository := g.repositories.Neo4j
 
 	// Get all IS_ATTRIBUTE relationships for the entity
 	filteredRelationships, err := neo4jRepository.ReadFilteredRelationships(ctx, entityID, map[string]interface{}{"name": IS_ATTRIBUTE_RELATIONSHIP, "direction": IS_ATTRIBUTE_RELATIONSHIP_DIRECTION, "startTime": startTime.Format(time.RFC3339)}, "")
 	if err != nil {
-		log.Printf("[GraphMetadataManager.GetAttribute] Error getting relationships: %v", err)
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
 		return nil, err
 	}
 
 	if len(filteredRelationships) == 0 {
-		log.Printf("[GraphMetadataManager.GetAttribute] No attributes found for entity %s", entityID)
-		return nil, fmt.Errorf("no attributes found for entity %s", entityID)
+		logging.FromContext(ctx).Debug("No attributes found for entity", "entity_id", entityID)
+		return nil, apperrors.NotFoundf("no attributes found for entity %s", entityID).WithEntity(entityID)
 	}
 
-	fmt.Printf("Number of related entities: %v\n", len(filteredRelationships))
+	logging.FromContext(ctx).Debug("Found attribute relationships", "relationships", len(filteredRelationships))
 
 	// Find the specific attribute by name
 	var targetAttributeID string
@@ -283,12 +293,12 @@ func (g *GraphMetadataManager) GetAttribute(ctx context.Context, entityID string
 		// Get the attribute entity from Neo4j to check its name
 		_, attributeNameTimeBased, _, _, err := neo4jRepository.GetGraphEntity(ctx, attributeID)
 		if err != nil {
-			log.Printf("[GraphMetadataManager.GetAttribute] Error getting attribute entity %s: %v", attributeID, err)
+			logging.FromContext(ctx).Error("Error getting attribute entity", "attribute_id", attributeID, "error", err)
 			continue
 		}
 
 		attributeNameStr := commons.ExtractStringFromAny(attributeNameTimeBased.Value)
-		fmt.Printf("Search name: '%s' vs Entity name: '%s'\n", attributeName, attributeNameStr)
+		logging.FromContext(ctx).Debug("Comparing attribute name", "attribute", attributeName, "candidate", attributeNameStr)
 
 		// Check if this entity has the target attribute name
 		if attributeNameStr == attributeName {
@@ -299,15 +309,15 @@ func (g *GraphMetadataManager) GetAttribute(ctx context.Context, entityID string
 	}
 
 	if !found {
-		log.Printf("[GraphMetadataManager.GetAttribute] Attribute '%s' not found for entity %s", attributeName, entityID)
-		return nil, fmt.Errorf("attribute '%s' not found for entity %s", attributeName, entityID)
+		logging.FromContext(ctx).Debug("Attribute not found for entity", "attribute", attributeName, "entity_id", entityID)
+		return nil, apperrors.NotFoundf("attribute '%s' not found for entity %s", attributeName, entityID).WithEntity(entityID).WithAttribute(attributeName)
 	}
 
 	// Get the attribute metadata from MongoDB
-	mongoRepository := dbcommons.GetMongoRepository(ctx)
+	mongoRepository := g.repositories.Mongo
 	attributeMetadataEntity, err := mongoRepository.ReadEntity(ctx, targetAttributeID)
 	if err != nil {
-		log.Printf("[GraphMetadataManager.GetAttribute] Error getting attribute metadata from MongoDB for attribute %s (entity %s): %v", targetAttributeID, entityID, err)
+		logging.FromContext(ctx).Error("Error getting attribute metadata from MongoDB for attribute", "attribute_id", targetAttributeID, "entity_id", entityID, "error", err)
 		return nil, fmt.Errorf("failed to get attribute metadata from MongoDB for attribute %s (entity %s): %w", targetAttributeID, entityID, err)
 	}
 
@@ -316,12 +326,12 @@ func (g *GraphMetadataManager) GetAttribute(ctx context.Context, entityID string
 
 	// Convert storage type string to StorageType enum
 	storageType := commons.ConvertStorageTypeStringToEnum(storageTypeStr)
-	log.Printf("[GraphMetadataManager.GetAttribute] storageType: %s", storageType)
+	logging.FromContext(ctx).Debug("Found storage type of attribute", "storage_type", storageType)
 
 	// Get creation time from the attribute entity
 	_, _, createdTimeStr, _, err := neo4jRepository.GetGraphEntity(ctx, targetAttributeID)
 	if err != nil {
-		log.Printf("[GraphMetadataManager.GetAttribute] Error getting creation time for attribute %s: %v", targetAttributeID, err)
+		logging.FromContext(ctx).Error("Error getting creation time for attribute", "attribute_id", targetAttributeID, "error", err)
 		createdTimeStr = ""
 	}
 
@@ -342,17 +352,16 @@ func (g *GraphMetadataManager) GetAttribute(ctx context.Context, entityID string
 
 // ListEntityAttributes lists all attributes for an entity
 func (g *GraphMetadataManager) ListAttributes(ctx context.Context, entityID string) ([]*AttributeMetadata, error) {
-	fmt.Printf("Listing attributes for entity: %s\n", entityID)
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.ListAttributes", tracing.EntityID(entityID))
+	defer span.End()
 
-	neo4jRepository, err := dbcommons.GetNeo4jRepository(ctx)
-	if err != nil {
-		log.Printf("[GraphMetadataManager.ListAttributes] Error getting Neo4j repository: %v", err)
-		return nil, err
-	}
+	logging.FromContext(ctx).Debug("Listing attributes for entity", "entity_id", entityID)
+
+	neo4jRepository := g.repositories.Neo4j
 
 	filteredRelationships, err := neo4jRepository.ReadFilteredRelationships(ctx, entityID, map[string]interface{}{"name": IS_ATTRIBUTE_RELATIONSHIP, "direction": IS_ATTRIBUTE_RELATIONSHIP_DIRECTION}, "")
 	if err != nil {
-		log.Printf("[GraphMetadataManager.ListAttributes] Error getting relationships: %v", err)
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
 		return nil, err
 	}
 
@@ -369,17 +378,17 @@ func (g *GraphMetadataManager) ListAttributes(ctx context.Context, entityID stri
 		// TODO: determine if an attribute needs to be teriminated based on various conditions.
 		_, attributeName, createdTimeStr, _, err := neo4jRepository.GetGraphEntity(ctx, attributeID)
 		if err != nil {
-			log.Printf("[GraphMetadataManager.ListAttributes] Error verifying attribute %s in graph for entity %s: %v", attributeID, entityID, err)
+			logging.FromContext(ctx).Error("Error verifying attribute in graph for entity", "attribute_id", attributeID, "entity_id", entityID, "error", err)
 			return nil, fmt.Errorf("failed to verify attribute %s in graph for entity %s: %w", attributeID, entityID, err)
 		}
 
 		attributeNameStr := commons.ExtractStringFromAny(attributeName.Value)
 
 		// Get the attribute metadata from the mongo database
-		mongoRepository := dbcommons.GetMongoRepository(ctx)
+		mongoRepository := g.repositories.Mongo
 		attributeMetadataEntity, err := mongoRepository.ReadEntity(ctx, attributeID)
 		if err != nil {
-			log.Printf("[GraphMetadataManager.ListAttributes] Error getting attribute metadata from MongoDB for attribute %s (entity %s): %v", attributeID, entityID, err)
+			logging.FromContext(ctx).Error("Error getting attribute metadata from MongoDB for attribute", "attribute_id", attributeID, "entity_id", entityID, "error", err)
 			return nil, fmt.Errorf("failed to get attribute metadata from MongoDB for attribute %s (entity %s): %w", attributeID, entityID, err)
 		}
 
@@ -411,20 +420,132 @@ func (g *GraphMetadataManager) ListAttributes(ctx context.Context, entityID stri
 
 // UpdateAttributeMetadata updates metadata for an attribute
 func (g *GraphMetadataManager) UpdateAttribute(ctx context.Context, metadata *AttributeMetadata) error {
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.UpdateAttribute", tracing.EntityID(metadata.EntityID), tracing.AttributeName(metadata.AttributeName))
+	defer span.End()
+
 	// TODO: Implement Neo4j or graph database connection
 	// This would update the attribute node properties
-	fmt.Printf("Updating attribute metadata: Entity=%s, Attribute=%s\n", metadata.EntityID, metadata.AttributeName)
+	logging.FromContext(ctx).Debug("Updating attribute metadata", "entity_id", metadata.EntityID, "attribute", metadata.AttributeName)
 
 	return nil
 }
 
-// DeleteAttributeNode deletes an attribute node and its relationships
+// DeleteAttribute deletes the attribute nodes with the given name, their IS_ATTRIBUTE relationships
+// and the attribute metadata documents. A time-based attribute has one node per value, so all of them are removed.
+// The attribute values themselves live in the storage system named by the storage type and are not touched here.
 func (g *GraphMetadataManager) DeleteAttribute(ctx context.Context, entityID, attributeName string) error {
-	// TODO: Implement Neo4j or graph database connection
-	// This would delete the attribute node and its IS_ATTRIBUTE relationship
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.DeleteAttribute", tracing.EntityID(entityID), tracing.AttributeName(attributeName))
+	defer span.End()
+
+	logging.FromContext(ctx).Debug("Deleting attribute node", "entity_id", entityID, "attribute", attributeName)
+
+	neo4jRepository := g.repositories.Neo4j
+
+	filteredRelationships, err := neo4jRepository.ReadFilteredRelationships(ctx, entityID, map[string]interface{}{"name": IS_ATTRIBUTE_RELATIONSHIP, "direction": IS_ATTRIBUTE_RELATIONSHIP_DIRECTION}, "")
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
+		return err
+	}
+
+	mongoRepository := g.repositories.Mongo
+
+	for _, relationship := range filteredRelationships {
+		relationshipID, _ := relationship["id"].(string)
+		attributeID, ok := relationship["relatedEntityId"].(string)
+		if !ok {
+			continue
+		}
 
-	fmt.Printf("Deleting attribute node: Entity=%s, Attribute=%s\n", entityID, attributeName)
+		_, attributeNameTimeBased, _, _, err := neo4jRepository.GetGraphEntity(ctx, attributeID)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error getting attribute entity", "attribute_id", attributeID, "error", err)
+			return fmt.Errorf("failed to read attribute %s of entity %s: %w", attributeID, entityID, err)
+		}
+		if commons.ExtractStringFromAny(attributeNameTimeBased.Value) != attributeName {
+			continue
+		}
 
+		if err := deleteAttributeNode(ctx, neo4jRepository, mongoRepository, relationshipID, attributeID); err != nil {
+			logging.FromContext(ctx).Error("Error deleting attribute of entity", "attribute", attributeName, "attribute_id", attributeID, "entity_id", entityID, "error", err)
+			return err
+		}
+		logging.FromContext(ctx).Debug("Deleted attribute of entity", "attribute", attributeName, "attribute_id", attributeID, "entity_id", entityID)
+	}
+
+	return nil
+}
+
+// ListAttributeIDs lists the ids of the attribute nodes of an entity.
+// Unlike ListAttributes it only reads the graph, so it also sees attributes whose metadata was never written.
+func (g *GraphMetadataManager) ListAttributeIDs(ctx context.Context, entityID string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.ListAttributeIDs", tracing.EntityID(entityID))
+	defer span.End()
+
+	neo4jRepository := g.repositories.Neo4j
+
+	filteredRelationships, err := neo4jRepository.ReadFilteredRelationships(ctx, entityID, map[string]interface{}{"name": IS_ATTRIBUTE_RELATIONSHIP, "direction": IS_ATTRIBUTE_RELATIONSHIP_DIRECTION}, "")
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
+		return nil, err
+	}
+
+	attributeIDs := []string{}
+	for _, relationship := range filteredRelationships {
+		if attributeID, ok := relationship["relatedEntityId"].(string); ok {
+			attributeIDs = append(attributeIDs, attributeID)
+		}
+	}
+
+	return attributeIDs, nil
+}
+
+// DeleteAttributeNodes deletes the given attribute nodes of an entity, their IS_ATTRIBUTE relationships
+// and the attribute metadata documents. Ids that are not attributes of the entity are ignored.
+func (g *GraphMetadataManager) DeleteAttributeNodes(ctx context.Context, entityID string, attributeIDs []string) error {
+	ctx, span := tracing.Start(ctx, "GraphMetadataManager.DeleteAttributeNodes", tracing.EntityID(entityID))
+	defer span.End()
+
+	if len(attributeIDs) == 0 {
+		return nil
+	}
+
+	neo4jRepository := g.repositories.Neo4j
+
+	filteredRelationships, err := neo4jRepository.ReadFilteredRelationships(ctx, entityID, map[string]interface{}{"name": IS_ATTRIBUTE_RELATIONSHIP, "direction": IS_ATTRIBUTE_RELATIONSHIP_DIRECTION}, "")
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting relationships", "error", err)
+		return err
+	}
+
+	mongoRepository := g.repositories.Mongo
+
+	for _, relationship := range filteredRelationships {
+		relationshipID, _ := relationship["id"].(string)
+		attributeID, ok := relationship["relatedEntityId"].(string)
+		if !ok || !slices.Contains(attributeIDs, attributeID) {
+			continue
+		}
+		if err := deleteAttributeNode(ctx, neo4jRepository, mongoRepository, relationshipID, attributeID); err != nil {
+			logging.FromContext(ctx).Error("Error deleting attribute of entity", "attribute_id", attributeID, "entity_id", entityID, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAttributeNode deletes a single attribute node, the IS_ATTRIBUTE relationship pointing at it and its metadata
+func deleteAttributeNode(ctx context.Context, neo4jRepository *neo4jrepository.Neo4jRepository, mongoRepository *mongorepository.MongoRepository, relationshipID, attributeID string) error {
+	// The IS_ATTRIBUTE relationship has to go first, a node with relationships cannot be deleted
+	if err := neo4jRepository.DeleteRelationship(ctx, relationshipID); err != nil {
+		return fmt.Errorf("failed to delete relationship %s of attribute %s: %w", relationshipID, attributeID, err)
+	}
+	if err := neo4jRepository.DeleteGraphEntity(ctx, attributeID); err != nil {
+		return fmt.Errorf("failed to delete attribute node %s: %w", attributeID, err)
+	}
+	if _, err := mongoRepository.DeleteEntity(ctx, attributeID); err != nil {
+		return fmt.Errorf("failed to delete attribute metadata %s: %w", attributeID, err)
+	}
 	return nil
 }
 
@@ -15,7 +15,7 @@ import (
 
 // TestGraphMetadataManager tests the graph metadata manager functionality
 func TestGraphMetadataManager(t *testing.T) {
-	manager := NewGraphMetadataManager()
+	manager := NewGraphMetadataManager(testRepositories)
 	assert.NotNil(t, manager)
 
 	ctx := context.Background()
@@ -134,7 +134,7 @@ func TestGraphMetadataIntegration(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	processor := NewEntityAttributeProcessor()
+	processor := NewEntityAttributeProcessor(testRepositories)
 	ctx := context.Background()
 
 	// save the parent entity in the database
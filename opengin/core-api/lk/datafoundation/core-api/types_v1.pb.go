@@ -142,8 +142,14 @@ type Relationship struct {
 	StartTime       string                 `protobuf:"bytes,4,opt,name=startTime,proto3" json:"startTime,omitempty"`
 	EndTime         string                 `protobuf:"bytes,5,opt,name=endTime,proto3" json:"endTime,omitempty"`
 	Direction       string                 `protobuf:"bytes,6,opt,name=direction,proto3" json:"direction,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Typed values recorded on the relationship, e.g. a role title or a share of ownership. Values are
+	// StringValue, BoolValue, Int32Value, Int64Value, UInt32Value, FloatValue or DoubleValue wrappers,
+	// integers are returned as Int64Value and floating-point numbers as DoubleValue.
+	Properties map[string]*anypb.Any `protobuf:"bytes,7,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Read-only versions an update replaced, oldest first. Only read with the relationshipVersions output.
+	Versions      []*RelationshipVersion `protobuf:"bytes,8,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Relationship) Reset() {
@@ -218,6 +224,97 @@ func (x *Relationship) GetDirection() string {
 	return ""
 }
 
+func (x *Relationship) GetProperties() map[string]*anypb.Any {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Relationship) GetVersions() []*RelationshipVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// A version of a relationship that an update replaced
+type RelationshipVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     string                 `protobuf:"bytes,1,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime       string                 `protobuf:"bytes,2,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Properties    map[string]*anypb.Any  `protobuf:"bytes,3,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RecordedAt    string                 `protobuf:"bytes,4,opt,name=recordedAt,proto3" json:"recordedAt,omitempty"`     // When the version was written, empty for the version the relationship was created with
+	SupersededAt  string                 `protobuf:"bytes,5,opt,name=supersededAt,proto3" json:"supersededAt,omitempty"` // When the update replacing the version was written
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipVersion) Reset() {
+	*x = RelationshipVersion{}
+	mi := &file_types_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipVersion) ProtoMessage() {}
+
+func (x *RelationshipVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipVersion.ProtoReflect.Descriptor instead.
+func (*RelationshipVersion) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RelationshipVersion) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *RelationshipVersion) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *RelationshipVersion) GetProperties() map[string]*anypb.Any {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *RelationshipVersion) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+func (x *RelationshipVersion) GetSupersededAt() string {
+	if x != nil {
+		return x.SupersededAt
+	}
+	return ""
+}
+
 type Entity struct {
 	state         protoimpl.MessageState         `protogen:"open.v1"`
 	Id            string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // Read-only unique identifier
@@ -228,13 +325,14 @@ type Entity struct {
 	Metadata      map[string]*anypb.Any          `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`           // Metadata as a flexible key-value map
 	Attributes    map[string]*TimeBasedValueList `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`       // Attributes as a time-based list
 	Relationships map[string]*Relationship       `protobuf:"bytes,8,rep,name=relationships,proto3" json:"relationships,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Relationships to other entities
+	Version       int64                          `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`                                                                                      // Read-only version, 1 on creation and incremented by every UpdateEntity
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Entity) Reset() {
 	*x = Entity{}
-	mi := &file_types_v1_proto_msgTypes[3]
+	mi := &file_types_v1_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +344,7 @@ func (x *Entity) String() string {
 func (*Entity) ProtoMessage() {}
 
 func (x *Entity) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[3]
+	mi := &file_types_v1_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +357,7 @@ func (x *Entity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Entity.ProtoReflect.Descriptor instead.
 func (*Entity) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{3}
+	return file_types_v1_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Entity) GetId() string {
@@ -318,6 +416,13 @@ func (x *Entity) GetRelationships() map[string]*Relationship {
 	return nil
 }
 
+func (x *Entity) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Wrapper for a repeated TimeBasedValue (since Protobuf does not support nested lists in maps)
 type TimeBasedValueList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -328,7 +433,7 @@ type TimeBasedValueList struct {
 
 func (x *TimeBasedValueList) Reset() {
 	*x = TimeBasedValueList{}
-	mi := &file_types_v1_proto_msgTypes[4]
+	mi := &file_types_v1_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -340,7 +445,7 @@ func (x *TimeBasedValueList) String() string {
 func (*TimeBasedValueList) ProtoMessage() {}
 
 func (x *TimeBasedValueList) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[4]
+	mi := &file_types_v1_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -353,7 +458,7 @@ func (x *TimeBasedValueList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TimeBasedValueList.ProtoReflect.Descriptor instead.
 func (*TimeBasedValueList) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{4}
+	return file_types_v1_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TimeBasedValueList) GetValues() []*TimeBasedValue {
@@ -365,17 +470,22 @@ func (x *TimeBasedValueList) GetValues() []*TimeBasedValue {
 
 // Request message for reading an entity
 type ReadEntityRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entity        *Entity                `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	Output        []string               `protobuf:"bytes,2,rep,name=output,proto3" json:"output,omitempty"`
-	ActiveAt      string                 `protobuf:"bytes,3,opt,name=activeAt,proto3" json:"activeAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Entity   *Entity                `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Output   []string               `protobuf:"bytes,2,rep,name=output,proto3" json:"output,omitempty"`
+	ActiveAt string                 `protobuf:"bytes,3,opt,name=activeAt,proto3" json:"activeAt,omitempty"` // Reads the name, metadata, relationships and attributes as they were at this time
+	// Paging for ReadEntities, ignored by ReadEntity
+	PageSize          int32  `protobuf:"varint,4,opt,name=pageSize,proto3" json:"pageSize,omitempty"`                   // Maximum number of entities per page, defaults to 100, at most 1000
+	PageToken         string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`                  // nextPageToken of the previous page
+	OrderBy           string `protobuf:"bytes,6,opt,name=orderBy,proto3" json:"orderBy,omitempty"`                      // name, created or terminated, optionally followed by " desc". Entities are ordered by id by default
+	IncludeTotalCount bool   `protobuf:"varint,7,opt,name=includeTotalCount,proto3" json:"includeTotalCount,omitempty"` // Also count every match, which costs an extra query
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ReadEntityRequest) Reset() {
 	*x = ReadEntityRequest{}
-	mi := &file_types_v1_proto_msgTypes[5]
+	mi := &file_types_v1_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -387,7 +497,7 @@ func (x *ReadEntityRequest) String() string {
 func (*ReadEntityRequest) ProtoMessage() {}
 
 func (x *ReadEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[5]
+	mi := &file_types_v1_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,7 +510,7 @@ func (x *ReadEntityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadEntityRequest.ProtoReflect.Descriptor instead.
 func (*ReadEntityRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{5}
+	return file_types_v1_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ReadEntityRequest) GetEntity() *Entity {
@@ -424,6 +534,34 @@ func (x *ReadEntityRequest) GetActiveAt() string {
 	return ""
 }
 
+func (x *ReadEntityRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ReadEntityRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ReadEntityRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ReadEntityRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
 // Request message for deleting an entity by ID
 type EntityId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -434,7 +572,7 @@ type EntityId struct {
 
 func (x *EntityId) Reset() {
 	*x = EntityId{}
-	mi := &file_types_v1_proto_msgTypes[6]
+	mi := &file_types_v1_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +584,7 @@ func (x *EntityId) String() string {
 func (*EntityId) ProtoMessage() {}
 
 func (x *EntityId) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[6]
+	mi := &file_types_v1_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +597,7 @@ func (x *EntityId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EntityId.ProtoReflect.Descriptor instead.
 func (*EntityId) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{6}
+	return file_types_v1_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *EntityId) GetId() string {
@@ -469,30 +607,34 @@ func (x *EntityId) GetId() string {
 	return ""
 }
 
-// Request message for updating an entity
-type UpdateEntityRequest struct {
+// Request message for deleting an entity and everything it owns.
+// Outgoing relationships, attributes and metadata always belong to the entity.
+// Incoming relationships belong to other entities and are only removed when cascade is set.
+// Field numbers are compatible with EntityId so existing clients keep working.
+type DeleteEntityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Entity        *Entity                `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	Cascade       bool                   `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"` // Also remove relationships other entities hold to this entity
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dryRun,proto3" json:"dryRun,omitempty"`   // Report what would be removed without deleting anything
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateEntityRequest) Reset() {
-	*x = UpdateEntityRequest{}
-	mi := &file_types_v1_proto_msgTypes[7]
+func (x *DeleteEntityRequest) Reset() {
+	*x = DeleteEntityRequest{}
+	mi := &file_types_v1_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateEntityRequest) String() string {
+func (x *DeleteEntityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateEntityRequest) ProtoMessage() {}
+func (*DeleteEntityRequest) ProtoMessage() {}
 
-func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[7]
+func (x *DeleteEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -503,47 +645,143 @@ func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
-func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DeleteEntityRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEntityRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateEntityRequest) GetId() string {
+func (x *DeleteEntityRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateEntityRequest) GetEntity() *Entity {
+func (x *DeleteEntityRequest) GetCascade() bool {
 	if x != nil {
-		return x.Entity
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteEntityRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Response message listing what was removed (or would be removed on a dry run)
+type DeleteEntityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DryRun          bool                   `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	RelationshipIds []string               `protobuf:"bytes,3,rep,name=relationshipIds,proto3" json:"relationshipIds,omitempty"` // Relationships to and from other entities
+	Attributes      []string               `protobuf:"bytes,4,rep,name=attributes,proto3" json:"attributes,omitempty"`           // Attribute names
+	AttributeTables []string               `protobuf:"bytes,5,rep,name=attributeTables,proto3" json:"attributeTables,omitempty"` // PostgreSQL tables holding tabular attribute values
+	Metadata        bool                   `protobuf:"varint,6,opt,name=metadata,proto3" json:"metadata,omitempty"`              // Whether a metadata document was found
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteEntityResponse) Reset() {
+	*x = DeleteEntityResponse{}
+	mi := &file_types_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEntityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEntityResponse) ProtoMessage() {}
+
+func (x *DeleteEntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEntityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEntityResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteEntityResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteEntityResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeleteEntityResponse) GetRelationshipIds() []string {
+	if x != nil {
+		return x.RelationshipIds
 	}
 	return nil
 }
 
-// Empty message response
-type Empty struct {
+func (x *DeleteEntityResponse) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *DeleteEntityResponse) GetAttributeTables() []string {
+	if x != nil {
+		return x.AttributeTables
+	}
+	return nil
+}
+
+func (x *DeleteEntityResponse) GetMetadata() bool {
+	if x != nil {
+		return x.Metadata
+	}
+	return false
+}
+
+// Outcome of creating a single entity of a BatchCreateEntities stream
+type BatchCreateEntityResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the entity in the stream, starting at 0
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"` // Reason the entity was not created, including the rollback outcome
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	mi := &file_types_v1_proto_msgTypes[8]
+func (x *BatchCreateEntityResult) Reset() {
+	*x = BatchCreateEntityResult{}
+	mi := &file_types_v1_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *BatchCreateEntityResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*BatchCreateEntityResult) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[8]
+func (x *BatchCreateEntityResult) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,34 +792,64 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use BatchCreateEntityResult.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntityResult) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{10}
 }
 
-// EntityList represents a list of entities
-type EntityList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entities      []*Entity              `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+func (x *BatchCreateEntityResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchCreateEntityResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchCreateEntityResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchCreateEntityResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Response message with one result per streamed entity
+type BatchCreateEntitiesResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Results       []*BatchCreateEntityResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                      `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Failed        int32                      `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntityList) Reset() {
-	*x = EntityList{}
-	mi := &file_types_v1_proto_msgTypes[9]
+func (x *BatchCreateEntitiesResponse) Reset() {
+	*x = BatchCreateEntitiesResponse{}
+	mi := &file_types_v1_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EntityList) String() string {
+func (x *BatchCreateEntitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntityList) ProtoMessage() {}
+func (*BatchCreateEntitiesResponse) ProtoMessage() {}
 
-func (x *EntityList) ProtoReflect() protoreflect.Message {
-	mi := &file_types_v1_proto_msgTypes[9]
+func (x *BatchCreateEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,37 +860,2402 @@ func (x *EntityList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntityList.ProtoReflect.Descriptor instead.
-func (*EntityList) Descriptor() ([]byte, []int) {
-	return file_types_v1_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use BatchCreateEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *EntityList) GetEntities() []*Entity {
+func (x *BatchCreateEntitiesResponse) GetResults() []*BatchCreateEntityResult {
 	if x != nil {
-		return x.Entities
+		return x.Results
 	}
 	return nil
 }
 
-var File_types_v1_proto protoreflect.FileDescriptor
+func (x *BatchCreateEntitiesResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
 
-const file_types_v1_proto_rawDesc = "" +
-	"\n" +
-	"\x0etypes_v1.proto\x12\x04core\x1a\x19google/protobuf/any.proto\"2\n" +
+func (x *BatchCreateEntitiesResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// Request message for updating an entity
+type UpdateEntityRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Entity          *Entity                `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	ExpectedVersion int64                  `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"` // Fail with ABORTED unless the entity is at this version, 0 updates any version
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateEntityRequest) Reset() {
+	*x = UpdateEntityRequest{}
+	mi := &file_types_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntityRequest) ProtoMessage() {}
+
+func (x *UpdateEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEntityRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateEntityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEntityRequest) GetEntity() *Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *UpdateEntityRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+// Empty message response
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_types_v1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{13}
+}
+
+// EntityList represents a list of entities
+type EntityList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entities      []*Entity              `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty on the last page
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=totalCount,proto3" json:"totalCount,omitempty"`      // Only set when includeTotalCount was requested
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityList) Reset() {
+	*x = EntityList{}
+	mi := &file_types_v1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityList) ProtoMessage() {}
+
+func (x *EntityList) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityList.ProtoReflect.Descriptor instead.
+func (*EntityList) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EntityList) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *EntityList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *EntityList) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Request message for watching the changes of entities. The filters are combined, an empty filter matches every event.
+type WatchEntitiesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Kinds             []*Kind                `protobuf:"bytes,1,rep,name=kinds,proto3" json:"kinds,omitempty"`                         // Kinds of the entities, an empty minor matches every minor kind
+	EntityIds         []string               `protobuf:"bytes,2,rep,name=entityIds,proto3" json:"entityIds,omitempty"`                 // Entities changed, or at the other end of a changed relationship
+	RelationshipNames []string               `protobuf:"bytes,3,rep,name=relationshipNames,proto3" json:"relationshipNames,omitempty"` // Only relationship events of relationships with these names
+	AfterSequence     uint64                 `protobuf:"varint,4,opt,name=afterSequence,proto3" json:"afterSequence,omitempty"`        // Resume after the event with this sequence, 0 starts with the next change
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *WatchEntitiesRequest) Reset() {
+	*x = WatchEntitiesRequest{}
+	mi := &file_types_v1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEntitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEntitiesRequest) ProtoMessage() {}
+
+func (x *WatchEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*WatchEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchEntitiesRequest) GetKinds() []*Kind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *WatchEntitiesRequest) GetEntityIds() []string {
+	if x != nil {
+		return x.EntityIds
+	}
+	return nil
+}
+
+func (x *WatchEntitiesRequest) GetRelationshipNames() []string {
+	if x != nil {
+		return x.RelationshipNames
+	}
+	return nil
+}
+
+func (x *WatchEntitiesRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+// A change of an entity or of one of its relationships
+type EntityEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // Increases with every event
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`          // ENTITY_CREATED, ENTITY_UPDATED, ENTITY_DELETED, RELATIONSHIP_CREATED, RELATIONSHIP_UPDATED or RELATIONSHIP_DELETED
+	EntityId      string                 `protobuf:"bytes,3,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	Kind          *Kind                  `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`          // Version of the entity after the change, 0 once it was deleted
+	Relationship  *Relationship          `protobuf:"bytes,6,opt,name=relationship,proto3" json:"relationship,omitempty"` // The changed relationship, with its direction seen from the entity
+	Time          string                 `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`                 // When the change was made
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityEvent) Reset() {
+	*x = EntityEvent{}
+	mi := &file_types_v1_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityEvent) ProtoMessage() {}
+
+func (x *EntityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityEvent.ProtoReflect.Descriptor instead.
+func (*EntityEvent) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EntityEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *EntityEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EntityEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EntityEvent) GetKind() *Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *EntityEvent) GetRelationship() *Relationship {
+	if x != nil {
+		return x.Relationship
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+// Request message for listing the dead-lettered webhook deliveries
+type ListFailedWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // Name of the endpoint, empty for every endpoint
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`      // Defaults to 100, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFailedWebhookDeliveriesRequest) Reset() {
+	*x = ListFailedWebhookDeliveriesRequest{}
+	mi := &file_types_v1_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFailedWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFailedWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListFailedWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFailedWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListFailedWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListFailedWebhookDeliveriesRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ListFailedWebhookDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// The delivery of an event to a webhook endpoint
+type WebhookDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=eventId,proto3" json:"eventId,omitempty"` // Sent to the endpoint, the same for every endpoint and every attempt
+	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Event         *EntityEvent           `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	Attempts      int32                  `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,6,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_types_v1_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEvent() *EntityEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Response message listing webhook deliveries, the most recent first
+type WebhookDeliveryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookDeliveryList) Reset() {
+	*x = WebhookDeliveryList{}
+	mi := &file_types_v1_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveryList) ProtoMessage() {}
+
+func (x *WebhookDeliveryList) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDeliveryList.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryList) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WebhookDeliveryList) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+// Request message for replaying dead-lettered webhook deliveries. Empty ids replay every dead-lettered delivery of the endpoint.
+type ReplayWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Endpoint      string                 `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // Name of the endpoint, empty for every endpoint
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveriesRequest) Reset() {
+	*x = ReplayWebhookDeliveriesRequest{}
+	mi := &file_types_v1_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReplayWebhookDeliveriesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReplayWebhookDeliveriesRequest) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+// Response message for replaying webhook deliveries
+type ReplayWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replayed      int64                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"` // Number of deliveries that will be attempted again
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookDeliveriesResponse) Reset() {
+	*x = ReplayWebhookDeliveriesResponse{}
+	mi := &file_types_v1_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ReplayWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReplayWebhookDeliveriesResponse) GetReplayed() int64 {
+	if x != nil {
+		return x.Replayed
+	}
+	return 0
+}
+
+// Request message for reading the audit log. The filters are combined, empty filters match every record.
+type ReadAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityId      string                 `protobuf:"bytes,1,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`         // Name of the API key or subject of the JWT that made the change
+	StartTime     string                 `protobuf:"bytes,3,opt,name=startTime,proto3" json:"startTime,omitempty"` // RFC 3339, inclusive
+	EndTime       string                 `protobuf:"bytes,4,opt,name=endTime,proto3" json:"endTime,omitempty"`     // RFC 3339, exclusive
+	PageSize      int32                  `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // Defaults to 100, at most 1000
+	PageToken     string                 `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadAuditLogRequest) Reset() {
+	*x = ReadAuditLogRequest{}
+	mi := &file_types_v1_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadAuditLogRequest) ProtoMessage() {}
+
+func (x *ReadAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*ReadAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReadAuditLogRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ReadAuditLogRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ReadAuditLogRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *ReadAuditLogRequest) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *ReadAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ReadAuditLogRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// A write to an entity
+type AuditRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time          string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`           // Empty when authentication is disabled
+	AuthMethod    string                 `protobuf:"bytes,4,opt,name=authMethod,proto3" json:"authMethod,omitempty"` // api-key or jwt
+	Method        string                 `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"`         // Full name of the RPC
+	RequestId     string                 `protobuf:"bytes,6,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	EntityId      string                 `protobuf:"bytes,7,opt,name=entityId,proto3" json:"entityId,omitempty"`
+	Changes       []*AuditChange         `protobuf:"bytes,8,rep,name=changes,proto3" json:"changes,omitempty"`
+	Attributes    []*AuditAttributeWrite `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	mi := &file_types_v1_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AuditRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetAuthMethod() string {
+	if x != nil {
+		return x.AuthMethod
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetChanges() []*AuditChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetAttributes() []*AuditAttributeWrite {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// A field of an entity changed by a write
+type AuditChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`   // kind, name, created, terminated, metadata.<key> or relationships.<id>
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"` // Empty when the field was added
+	After         string                 `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`   // Empty when the field was removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditChange) Reset() {
+	*x = AuditChange{}
+	mi := &file_types_v1_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChange) ProtoMessage() {}
+
+func (x *AuditChange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChange.ProtoReflect.Descriptor instead.
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AuditChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AuditChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// The values of an attribute stored or deleted by a write
+type AuditAttributeWrite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        int32                  `protobuf:"varint,2,opt,name=values,proto3" json:"values,omitempty"`
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditAttributeWrite) Reset() {
+	*x = AuditAttributeWrite{}
+	mi := &file_types_v1_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditAttributeWrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditAttributeWrite) ProtoMessage() {}
+
+func (x *AuditAttributeWrite) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditAttributeWrite.ProtoReflect.Descriptor instead.
+func (*AuditAttributeWrite) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AuditAttributeWrite) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuditAttributeWrite) GetValues() int32 {
+	if x != nil {
+		return x.Values
+	}
+	return 0
+}
+
+func (x *AuditAttributeWrite) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// Response message of ReadAuditLog, the most recent record first
+type AuditLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AuditRecord         `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	mi := &file_types_v1_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLog) ProtoMessage() {}
+
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AuditLog) GetRecords() []*AuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *AuditLog) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request message of ReadEntityHistory. The window keeps the values that held at some time within it.
+type ReadEntityHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime     string                 `protobuf:"bytes,2,opt,name=startTime,proto3" json:"startTime,omitempty"` // RFC 3339, inclusive, the beginning of time when empty
+	EndTime       string                 `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`     // RFC 3339, exclusive, until now when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadEntityHistoryRequest) Reset() {
+	*x = ReadEntityHistoryRequest{}
+	mi := &file_types_v1_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadEntityHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadEntityHistoryRequest) ProtoMessage() {}
+
+func (x *ReadEntityHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadEntityHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ReadEntityHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReadEntityHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReadEntityHistoryRequest) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *ReadEntityHistoryRequest) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// The metadata of an entity between two updates
+type MetadataRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     string                 `protobuf:"bytes,1,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime       string                 `protobuf:"bytes,2,opt,name=endTime,proto3" json:"endTime,omitempty"` // Empty while the metadata holds
+	Metadata      map[string]*anypb.Any  `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataRevision) Reset() {
+	*x = MetadataRevision{}
+	mi := &file_types_v1_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataRevision) ProtoMessage() {}
+
+func (x *MetadataRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataRevision.ProtoReflect.Descriptor instead.
+func (*MetadataRevision) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MetadataRevision) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *MetadataRevision) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *MetadataRevision) GetMetadata() map[string]*anypb.Any {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Response message of ReadEntityHistory, every list ordered by start time
+type EntityHistory struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Id            string                         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          *Kind                          `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Created       string                         `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	Terminated    string                         `protobuf:"bytes,4,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	Names         []*TimeBasedValue              `protobuf:"bytes,5,rep,name=names,proto3" json:"names,omitempty"`
+	Metadata      []*MetadataRevision            `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	Relationships []*Relationship                `protobuf:"bytes,7,rep,name=relationships,proto3" json:"relationships,omitempty"` // Both directions, seen from the entity
+	Attributes    map[string]*TimeBasedValueList `protobuf:"bytes,8,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityHistory) Reset() {
+	*x = EntityHistory{}
+	mi := &file_types_v1_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityHistory) ProtoMessage() {}
+
+func (x *EntityHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityHistory.ProtoReflect.Descriptor instead.
+func (*EntityHistory) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EntityHistory) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntityHistory) GetKind() *Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *EntityHistory) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *EntityHistory) GetTerminated() string {
+	if x != nil {
+		return x.Terminated
+	}
+	return ""
+}
+
+func (x *EntityHistory) GetNames() []*TimeBasedValue {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *EntityHistory) GetMetadata() []*MetadataRevision {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *EntityHistory) GetRelationships() []*Relationship {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *EntityHistory) GetAttributes() map[string]*TimeBasedValueList {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Request message of DiffEntity, comparing the entity as it was at two times
+type DiffEntityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromTime      string                 `protobuf:"bytes,2,opt,name=fromTime,proto3" json:"fromTime,omitempty"` // RFC 3339
+	ToTime        string                 `protobuf:"bytes,3,opt,name=toTime,proto3" json:"toTime,omitempty"`     // RFC 3339, now when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffEntityRequest) Reset() {
+	*x = DiffEntityRequest{}
+	mi := &file_types_v1_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffEntityRequest) ProtoMessage() {}
+
+func (x *DiffEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffEntityRequest.ProtoReflect.Descriptor instead.
+func (*DiffEntityRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DiffEntityRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiffEntityRequest) GetFromTime() string {
+	if x != nil {
+		return x.FromTime
+	}
+	return ""
+}
+
+func (x *DiffEntityRequest) GetToTime() string {
+	if x != nil {
+		return x.ToTime
+	}
+	return ""
+}
+
+// The name of an entity at the two times of a diff
+type NameChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Before        string                 `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameChange) Reset() {
+	*x = NameChange{}
+	mi := &file_types_v1_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameChange) ProtoMessage() {}
+
+func (x *NameChange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameChange.ProtoReflect.Descriptor instead.
+func (*NameChange) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NameChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *NameChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// A metadata key that differs between the two times of a diff
+type MetadataChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // ADDED, REMOVED or CHANGED
+	Before        *anypb.Any             `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"` // Unset when the key was added
+	After         *anypb.Any             `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`   // Unset when the key was removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataChange) Reset() {
+	*x = MetadataChange{}
+	mi := &file_types_v1_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataChange) ProtoMessage() {}
+
+func (x *MetadataChange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataChange.ProtoReflect.Descriptor instead.
+func (*MetadataChange) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MetadataChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MetadataChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MetadataChange) GetBefore() *anypb.Any {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *MetadataChange) GetAfter() *anypb.Any {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// A relationship that differs between the two times of a diff. A relationship replaced by one with the
+// same name, direction and related entity is CHANGED.
+type RelationshipChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // ADDED, REMOVED or CHANGED
+	Before        *Relationship          `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"` // Unset when the relationship was added
+	After         *Relationship          `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`   // Unset when the relationship was removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationshipChange) Reset() {
+	*x = RelationshipChange{}
+	mi := &file_types_v1_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationshipChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationshipChange) ProtoMessage() {}
+
+func (x *RelationshipChange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationshipChange.ProtoReflect.Descriptor instead.
+func (*RelationshipChange) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RelationshipChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RelationshipChange) GetBefore() *Relationship {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *RelationshipChange) GetAfter() *Relationship {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// A tabular attribute that differs between the two times of a diff
+type AttributeChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`               // ADDED, REMOVED or CHANGED
+	AddedRows     *anypb.Any             `protobuf:"bytes,3,opt,name=addedRows,proto3" json:"addedRows,omitempty"`     // Rows only held at toTime, in the format of the attribute values
+	RemovedRows   *anypb.Any             `protobuf:"bytes,4,opt,name=removedRows,proto3" json:"removedRows,omitempty"` // Rows only held at fromTime
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeChange) Reset() {
+	*x = AttributeChange{}
+	mi := &file_types_v1_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeChange) ProtoMessage() {}
+
+func (x *AttributeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeChange.ProtoReflect.Descriptor instead.
+func (*AttributeChange) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AttributeChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AttributeChange) GetAddedRows() *anypb.Any {
+	if x != nil {
+		return x.AddedRows
+	}
+	return nil
+}
+
+func (x *AttributeChange) GetRemovedRows() *anypb.Any {
+	if x != nil {
+		return x.RemovedRows
+	}
+	return nil
+}
+
+// Response message of DiffEntity. Only the changes are listed.
+type EntityDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromTime      string                 `protobuf:"bytes,2,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime        string                 `protobuf:"bytes,3,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Name          *NameChange            `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"` // Unset when the name did not change
+	Metadata      []*MetadataChange      `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	Relationships []*RelationshipChange  `protobuf:"bytes,6,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	Attributes    []*AttributeChange     `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityDiff) Reset() {
+	*x = EntityDiff{}
+	mi := &file_types_v1_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityDiff) ProtoMessage() {}
+
+func (x *EntityDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityDiff.ProtoReflect.Descriptor instead.
+func (*EntityDiff) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EntityDiff) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntityDiff) GetFromTime() string {
+	if x != nil {
+		return x.FromTime
+	}
+	return ""
+}
+
+func (x *EntityDiff) GetToTime() string {
+	if x != nil {
+		return x.ToTime
+	}
+	return ""
+}
+
+func (x *EntityDiff) GetName() *NameChange {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *EntityDiff) GetMetadata() []*MetadataChange {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *EntityDiff) GetRelationships() []*RelationshipChange {
+	if x != nil {
+		return x.Relationships
+	}
+	return nil
+}
+
+func (x *EntityDiff) GetAttributes() []*AttributeChange {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Request message of Traverse, following relationships from an entity over several hops
+type TraverseRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                               // Entity the traversal starts from
+	RelationshipNames []string               `protobuf:"bytes,2,rep,name=relationshipNames,proto3" json:"relationshipNames,omitempty"` // Every relationship except the attribute ones when empty
+	Direction         string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`                 // OUTGOING, INCOMING, or both when empty
+	MinDepth          int32                  `protobuf:"varint,4,opt,name=minDepth,proto3" json:"minDepth,omitempty"`                  // Defaults to 1
+	MaxDepth          int32                  `protobuf:"varint,5,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`                  // Defaults to minDepth, at most 10
+	ActiveAt          string                 `protobuf:"bytes,6,opt,name=activeAt,proto3" json:"activeAt,omitempty"`                   // RFC 3339, only follows entities and relationships active at the time when set
+	Kinds             []*Kind                `protobuf:"bytes,7,rep,name=kinds,proto3" json:"kinds,omitempty"`                         // Kinds of the visited entities, an empty minor matches every minor kind. Every kind when empty.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TraverseRequest) Reset() {
+	*x = TraverseRequest{}
+	mi := &file_types_v1_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraverseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraverseRequest) ProtoMessage() {}
+
+func (x *TraverseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraverseRequest.ProtoReflect.Descriptor instead.
+func (*TraverseRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TraverseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TraverseRequest) GetRelationshipNames() []string {
+	if x != nil {
+		return x.RelationshipNames
+	}
+	return nil
+}
+
+func (x *TraverseRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *TraverseRequest) GetMinDepth() int32 {
+	if x != nil {
+		return x.MinDepth
+	}
+	return 0
+}
+
+func (x *TraverseRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *TraverseRequest) GetActiveAt() string {
+	if x != nil {
+		return x.ActiveAt
+	}
+	return ""
+}
+
+func (x *TraverseRequest) GetKinds() []*Kind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+// An entity of a graph
+type GraphNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          *Kind                  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Depth         int32                  `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"` // Fewest relationships between the entity and the start
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphNode) Reset() {
+	*x = GraphNode{}
+	mi := &file_types_v1_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphNode) ProtoMessage() {}
+
+func (x *GraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
+func (*GraphNode) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GraphNode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GraphNode) GetKind() *Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *GraphNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GraphNode) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// A relationship of a graph, from its source to its target entity
+type GraphEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SourceId      string                 `protobuf:"bytes,3,opt,name=sourceId,proto3" json:"sourceId,omitempty"`
+	TargetId      string                 `protobuf:"bytes,4,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	StartTime     string                 `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime       string                 `protobuf:"bytes,6,opt,name=endTime,proto3" json:"endTime,omitempty"` // Empty while the relationship holds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphEdge) Reset() {
+	*x = GraphEdge{}
+	mi := &file_types_v1_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphEdge) ProtoMessage() {}
+
+func (x *GraphEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphEdge.ProtoReflect.Descriptor instead.
+func (*GraphEdge) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GraphEdge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *GraphEdge) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// The entities and relationships of the paths found by a traversal, the start first
+type Graph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*GraphNode           `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*GraphEdge           `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	Truncated     bool                   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"` // More paths matched than the traversal returns
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Graph) Reset() {
+	*x = Graph{}
+	mi := &file_types_v1_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Graph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Graph) ProtoMessage() {}
+
+func (x *Graph) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
+func (*Graph) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Graph) GetNodes() []*GraphNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Graph) GetEdges() []*GraphEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *Graph) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// Request message of FindPaths, looking for the shortest paths between two entities in either direction
+type FindPathsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	FromId            string                 `protobuf:"bytes,1,opt,name=fromId,proto3" json:"fromId,omitempty"`
+	ToId              string                 `protobuf:"bytes,2,opt,name=toId,proto3" json:"toId,omitempty"`
+	RelationshipNames []string               `protobuf:"bytes,3,rep,name=relationshipNames,proto3" json:"relationshipNames,omitempty"` // Every relationship except the attribute ones when empty
+	ActiveAt          string                 `protobuf:"bytes,4,opt,name=activeAt,proto3" json:"activeAt,omitempty"`                   // RFC 3339, only follows relationships active at the time when set
+	MaxLength         int32                  `protobuf:"varint,5,opt,name=maxLength,proto3" json:"maxLength,omitempty"`                // Most relationships in a path, defaults to 6, at most 15
+	All               bool                   `protobuf:"varint,6,opt,name=all,proto3" json:"all,omitempty"`                            // Every shortest path rather than one
+	MaxPaths          int32                  `protobuf:"varint,7,opt,name=maxPaths,proto3" json:"maxPaths,omitempty"`                  // Most paths returned when all is set, defaults to 10, at most 100
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *FindPathsRequest) Reset() {
+	*x = FindPathsRequest{}
+	mi := &file_types_v1_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPathsRequest) ProtoMessage() {}
+
+func (x *FindPathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPathsRequest.ProtoReflect.Descriptor instead.
+func (*FindPathsRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FindPathsRequest) GetFromId() string {
+	if x != nil {
+		return x.FromId
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetToId() string {
+	if x != nil {
+		return x.ToId
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetRelationshipNames() []string {
+	if x != nil {
+		return x.RelationshipNames
+	}
+	return nil
+}
+
+func (x *FindPathsRequest) GetActiveAt() string {
+	if x != nil {
+		return x.ActiveAt
+	}
+	return ""
+}
+
+func (x *FindPathsRequest) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *FindPathsRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *FindPathsRequest) GetMaxPaths() int32 {
+	if x != nil {
+		return x.MaxPaths
+	}
+	return 0
+}
+
+// A path between two entities, from the first to the last entity
+type Path struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	EntityIds       []string               `protobuf:"bytes,1,rep,name=entityIds,proto3" json:"entityIds,omitempty"`
+	RelationshipIds []string               `protobuf:"bytes,2,rep,name=relationshipIds,proto3" json:"relationshipIds,omitempty"` // relationshipIds[i] connects entityIds[i] and entityIds[i+1], in either direction
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	mi := &file_types_v1_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Path) GetEntityIds() []string {
+	if x != nil {
+		return x.EntityIds
+	}
+	return nil
+}
+
+func (x *Path) GetRelationshipIds() []string {
+	if x != nil {
+		return x.RelationshipIds
+	}
+	return nil
+}
+
+// Response message of FindPaths, empty when the entities are not connected
+type PathList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*Path                `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathList) Reset() {
+	*x = PathList{}
+	mi := &file_types_v1_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathList) ProtoMessage() {}
+
+func (x *PathList) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathList.ProtoReflect.Descriptor instead.
+func (*PathList) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PathList) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// Request message of ReadHierarchy, reading the tree of entities below a root
+type ReadHierarchyRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                               // Root of the tree
+	RelationshipNames []string               `protobuf:"bytes,2,rep,name=relationshipNames,proto3" json:"relationshipNames,omitempty"` // Relationships from a parent to its children, at least one
+	Direction         string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`                 // OUTGOING when the relationships point from the parent to the child, the default, or INCOMING
+	ActiveAt          string                 `protobuf:"bytes,4,opt,name=activeAt,proto3" json:"activeAt,omitempty"`                   // RFC 3339, only entities and relationships active at the time when set
+	MaxDepth          int32                  `protobuf:"varint,5,opt,name=maxDepth,proto3" json:"maxDepth,omitempty"`                  // Levels below the root, defaults to 3, at most 10
+	IncludeChildCount bool                   `protobuf:"varint,6,opt,name=includeChildCount,proto3" json:"includeChildCount,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReadHierarchyRequest) Reset() {
+	*x = ReadHierarchyRequest{}
+	mi := &file_types_v1_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadHierarchyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadHierarchyRequest) ProtoMessage() {}
+
+func (x *ReadHierarchyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadHierarchyRequest.ProtoReflect.Descriptor instead.
+func (*ReadHierarchyRequest) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReadHierarchyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReadHierarchyRequest) GetRelationshipNames() []string {
+	if x != nil {
+		return x.RelationshipNames
+	}
+	return nil
+}
+
+func (x *ReadHierarchyRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ReadHierarchyRequest) GetActiveAt() string {
+	if x != nil {
+		return x.ActiveAt
+	}
+	return ""
+}
+
+func (x *ReadHierarchyRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *ReadHierarchyRequest) GetIncludeChildCount() bool {
+	if x != nil {
+		return x.IncludeChildCount
+	}
+	return false
+}
+
+// An entity of a hierarchy with the entities below it
+type HierarchyNode struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind           *Kind                  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	RelationshipId string                 `protobuf:"bytes,4,opt,name=relationshipId,proto3" json:"relationshipId,omitempty"` // Relationship from the parent, empty for the root
+	ChildCount     int32                  `protobuf:"varint,5,opt,name=childCount,proto3" json:"childCount,omitempty"`        // Children of the entity, including those below maxDepth. Only set when includeChildCount was requested.
+	Children       []*HierarchyNode       `protobuf:"bytes,6,rep,name=children,proto3" json:"children,omitempty"`             // Ordered by name and id
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HierarchyNode) Reset() {
+	*x = HierarchyNode{}
+	mi := &file_types_v1_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HierarchyNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HierarchyNode) ProtoMessage() {}
+
+func (x *HierarchyNode) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HierarchyNode.ProtoReflect.Descriptor instead.
+func (*HierarchyNode) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *HierarchyNode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HierarchyNode) GetKind() *Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *HierarchyNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HierarchyNode) GetRelationshipId() string {
+	if x != nil {
+		return x.RelationshipId
+	}
+	return ""
+}
+
+func (x *HierarchyNode) GetChildCount() int32 {
+	if x != nil {
+		return x.ChildCount
+	}
+	return 0
+}
+
+func (x *HierarchyNode) GetChildren() []*HierarchyNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Response message of ReadHierarchy. An entity with several parents appears below each of them.
+type Hierarchy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          *HierarchyNode         `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Truncated     bool                   `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // The tree has more entities than are returned
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hierarchy) Reset() {
+	*x = Hierarchy{}
+	mi := &file_types_v1_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hierarchy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hierarchy) ProtoMessage() {}
+
+func (x *Hierarchy) ProtoReflect() protoreflect.Message {
+	mi := &file_types_v1_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hierarchy.ProtoReflect.Descriptor instead.
+func (*Hierarchy) Descriptor() ([]byte, []int) {
+	return file_types_v1_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Hierarchy) GetRoot() *HierarchyNode {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *Hierarchy) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+var File_types_v1_proto protoreflect.FileDescriptor
+
+const file_types_v1_proto_rawDesc = "" +
+	"\n" +
+	"\x0etypes_v1.proto\x12\x04core\x1a\x19google/protobuf/any.proto\"2\n" +
 	"\x04Kind\x12\x14\n" +
 	"\x05major\x18\x01 \x01(\tR\x05major\x12\x14\n" +
 	"\x05minor\x18\x02 \x01(\tR\x05minor\"t\n" +
 	"\x0eTimeBasedValue\x12\x1c\n" +
 	"\tstartTime\x18\x01 \x01(\tR\tstartTime\x12\x18\n" +
 	"\aendTime\x18\x02 \x01(\tR\aendTime\x12*\n" +
-	"\x05value\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\x05value\"\xb2\x01\n" +
+	"\x05value\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\x05value\"\x82\x03\n" +
 	"\fRelationship\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
 	"\x0frelatedEntityId\x18\x02 \x01(\tR\x0frelatedEntityId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1c\n" +
 	"\tstartTime\x18\x04 \x01(\tR\tstartTime\x12\x18\n" +
 	"\aendTime\x18\x05 \x01(\tR\aendTime\x12\x1c\n" +
-	"\tdirection\x18\x06 \x01(\tR\tdirection\"\xdb\x04\n" +
+	"\tdirection\x18\x06 \x01(\tR\tdirection\x12B\n" +
+	"\n" +
+	"properties\x18\a \x03(\v2\".core.Relationship.PropertiesEntryR\n" +
+	"properties\x125\n" +
+	"\bversions\x18\b \x03(\v2\x19.core.RelationshipVersionR\bversions\x1aS\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xb1\x02\n" +
+	"\x13RelationshipVersion\x12\x1c\n" +
+	"\tstartTime\x18\x01 \x01(\tR\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x02 \x01(\tR\aendTime\x12I\n" +
+	"\n" +
+	"properties\x18\x03 \x03(\v2).core.RelationshipVersion.PropertiesEntryR\n" +
+	"properties\x12\x1e\n" +
+	"\n" +
+	"recordedAt\x18\x04 \x01(\tR\n" +
+	"recordedAt\x12\"\n" +
+	"\fsupersededAt\x18\x05 \x01(\tR\fsupersededAt\x1aS\n" +
+	"\x0fPropertiesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xf5\x04\n" +
 	"\x06Entity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
 	"\x04kind\x18\x02 \x01(\v2\n" +
@@ -636,7 +3269,8 @@ const file_types_v1_proto_rawDesc = "" +
 	"\n" +
 	"attributes\x18\a \x03(\v2\x1c.core.Entity.AttributesEntryR\n" +
 	"attributes\x12E\n" +
-	"\rrelationships\x18\b \x03(\v2\x1f.core.Entity.RelationshipsEntryR\rrelationships\x1aQ\n" +
+	"\rrelationships\x18\b \x03(\v2\x1f.core.Entity.RelationshipsEntryR\rrelationships\x12\x18\n" +
+	"\aversion\x18\t \x01(\x03R\aversion\x1aQ\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
 	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\x1aW\n" +
@@ -647,27 +3281,257 @@ const file_types_v1_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12(\n" +
 	"\x05value\x18\x02 \x01(\v2\x12.core.RelationshipR\x05value:\x028\x01\"B\n" +
 	"\x12TimeBasedValueList\x12,\n" +
-	"\x06values\x18\x01 \x03(\v2\x14.core.TimeBasedValueR\x06values\"m\n" +
+	"\x06values\x18\x01 \x03(\v2\x14.core.TimeBasedValueR\x06values\"\xef\x01\n" +
 	"\x11ReadEntityRequest\x12$\n" +
 	"\x06entity\x18\x01 \x01(\v2\f.core.EntityR\x06entity\x12\x16\n" +
 	"\x06output\x18\x02 \x03(\tR\x06output\x12\x1a\n" +
-	"\bactiveAt\x18\x03 \x01(\tR\bactiveAt\"\x1a\n" +
+	"\bactiveAt\x18\x03 \x01(\tR\bactiveAt\x12\x1a\n" +
+	"\bpageSize\x18\x04 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x05 \x01(\tR\tpageToken\x12\x18\n" +
+	"\aorderBy\x18\x06 \x01(\tR\aorderBy\x12,\n" +
+	"\x11includeTotalCount\x18\a \x01(\bR\x11includeTotalCount\"\x1a\n" +
 	"\bEntityId\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"W\n" +
+	"\x13DeleteEntityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\acascade\x18\x02 \x01(\bR\acascade\x12\x16\n" +
+	"\x06dryRun\x18\x03 \x01(\bR\x06dryRun\"\xce\x01\n" +
+	"\x14DeleteEntityResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06dryRun\x18\x02 \x01(\bR\x06dryRun\x12(\n" +
+	"\x0frelationshipIds\x18\x03 \x03(\tR\x0frelationshipIds\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x04 \x03(\tR\n" +
+	"attributes\x12(\n" +
+	"\x0fattributeTables\x18\x05 \x03(\tR\x0fattributeTables\x12\x1a\n" +
+	"\bmetadata\x18\x06 \x01(\bR\bmetadata\"o\n" +
+	"\x17BatchCreateEntityResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x88\x01\n" +
+	"\x1bBatchCreateEntitiesResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.core.BatchCreateEntityResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"u\n" +
 	"\x13UpdateEntityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
-	"\x06entity\x18\x02 \x01(\v2\f.core.EntityR\x06entity\"\a\n" +
-	"\x05Empty\"6\n" +
+	"\x06entity\x18\x02 \x01(\v2\f.core.EntityR\x06entity\x12(\n" +
+	"\x0fexpectedVersion\x18\x03 \x01(\x03R\x0fexpectedVersion\"\a\n" +
+	"\x05Empty\"|\n" +
 	"\n" +
 	"EntityList\x12(\n" +
-	"\bentities\x18\x01 \x03(\v2\f.core.EntityR\bentities2\x8f\x02\n" +
+	"\bentities\x18\x01 \x03(\v2\f.core.EntityR\bentities\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x03 \x01(\x03R\n" +
+	"totalCount\"\xaa\x01\n" +
+	"\x14WatchEntitiesRequest\x12 \n" +
+	"\x05kinds\x18\x01 \x03(\v2\n" +
+	".core.KindR\x05kinds\x12\x1c\n" +
+	"\tentityIds\x18\x02 \x03(\tR\tentityIds\x12,\n" +
+	"\x11relationshipNames\x18\x03 \x03(\tR\x11relationshipNames\x12$\n" +
+	"\rafterSequence\x18\x04 \x01(\x04R\rafterSequence\"\xdf\x01\n" +
+	"\vEntityEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bentityId\x18\x03 \x01(\tR\bentityId\x12\x1e\n" +
+	"\x04kind\x18\x04 \x01(\v2\n" +
+	".core.KindR\x04kind\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x126\n" +
+	"\frelationship\x18\x06 \x01(\v2\x12.core.RelationshipR\frelationship\x12\x12\n" +
+	"\x04time\x18\a \x01(\tR\x04time\"V\n" +
+	"\"ListFailedWebhookDeliveriesRequest\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xd8\x01\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aeventId\x18\x02 \x01(\tR\aeventId\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12'\n" +
+	"\x05event\x18\x04 \x01(\v2\x11.core.EntityEventR\x05event\x12\x1a\n" +
+	"\battempts\x18\x05 \x01(\x05R\battempts\x12\x1c\n" +
+	"\tlastError\x18\x06 \x01(\tR\tlastError\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\tR\tcreatedAt\"L\n" +
+	"\x13WebhookDeliveryList\x125\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x15.core.WebhookDeliveryR\n" +
+	"deliveries\"N\n" +
+	"\x1eReplayWebhookDeliveriesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\"=\n" +
+	"\x1fReplayWebhookDeliveriesResponse\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x03R\breplayed\"\xb9\x01\n" +
+	"\x13ReadAuditLogRequest\x12\x1a\n" +
+	"\bentityId\x18\x01 \x01(\tR\bentityId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x1c\n" +
+	"\tstartTime\x18\x03 \x01(\tR\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x04 \x01(\tR\aendTime\x12\x1a\n" +
+	"\bpageSize\x18\x05 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x06 \x01(\tR\tpageToken\"\xa1\x02\n" +
+	"\vAuditRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1e\n" +
+	"\n" +
+	"authMethod\x18\x04 \x01(\tR\n" +
+	"authMethod\x12\x16\n" +
+	"\x06method\x18\x05 \x01(\tR\x06method\x12\x1c\n" +
+	"\trequestId\x18\x06 \x01(\tR\trequestId\x12\x1a\n" +
+	"\bentityId\x18\a \x01(\tR\bentityId\x12+\n" +
+	"\achanges\x18\b \x03(\v2\x11.core.AuditChangeR\achanges\x129\n" +
+	"\n" +
+	"attributes\x18\t \x03(\v2\x19.core.AuditAttributeWriteR\n" +
+	"attributes\"Q\n" +
+	"\vAuditChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"[\n" +
+	"\x13AuditAttributeWrite\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x01(\x05R\x06values\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"]\n" +
+	"\bAuditLog\x12+\n" +
+	"\arecords\x18\x01 \x03(\v2\x11.core.AuditRecordR\arecords\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"b\n" +
+	"\x18ReadEntityHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tstartTime\x18\x02 \x01(\tR\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x03 \x01(\tR\aendTime\"\xdf\x01\n" +
+	"\x10MetadataRevision\x12\x1c\n" +
+	"\tstartTime\x18\x01 \x01(\tR\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x02 \x01(\tR\aendTime\x12@\n" +
+	"\bmetadata\x18\x03 \x03(\v2$.core.MetadataRevision.MetadataEntryR\bmetadata\x1aQ\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\x05value:\x028\x01\"\xb1\x03\n" +
+	"\rEntityHistory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\x04kind\x18\x02 \x01(\v2\n" +
+	".core.KindR\x04kind\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\tR\acreated\x12\x1e\n" +
+	"\n" +
+	"terminated\x18\x04 \x01(\tR\n" +
+	"terminated\x12*\n" +
+	"\x05names\x18\x05 \x03(\v2\x14.core.TimeBasedValueR\x05names\x122\n" +
+	"\bmetadata\x18\x06 \x03(\v2\x16.core.MetadataRevisionR\bmetadata\x128\n" +
+	"\rrelationships\x18\a \x03(\v2\x12.core.RelationshipR\rrelationships\x12C\n" +
+	"\n" +
+	"attributes\x18\b \x03(\v2#.core.EntityHistory.AttributesEntryR\n" +
+	"attributes\x1aW\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.core.TimeBasedValueListR\x05value:\x028\x01\"W\n" +
+	"\x11DiffEntityRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfromTime\x18\x02 \x01(\tR\bfromTime\x12\x16\n" +
+	"\x06toTime\x18\x03 \x01(\tR\x06toTime\":\n" +
+	"\n" +
+	"NameChange\x12\x16\n" +
+	"\x06before\x18\x01 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x02 \x01(\tR\x05after\"\x90\x01\n" +
+	"\x0eMetadataChange\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12,\n" +
+	"\x06before\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\x06before\x12*\n" +
+	"\x05after\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\x05after\"~\n" +
+	"\x12RelationshipChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12*\n" +
+	"\x06before\x18\x02 \x01(\v2\x12.core.RelationshipR\x06before\x12(\n" +
+	"\x05after\x18\x03 \x01(\v2\x12.core.RelationshipR\x05after\"\xa5\x01\n" +
+	"\x0fAttributeChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x122\n" +
+	"\taddedRows\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\taddedRows\x126\n" +
+	"\vremovedRows\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\vremovedRows\"\x9f\x02\n" +
+	"\n" +
+	"EntityDiff\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfromTime\x18\x02 \x01(\tR\bfromTime\x12\x16\n" +
+	"\x06toTime\x18\x03 \x01(\tR\x06toTime\x12$\n" +
+	"\x04name\x18\x04 \x01(\v2\x10.core.NameChangeR\x04name\x120\n" +
+	"\bmetadata\x18\x05 \x03(\v2\x14.core.MetadataChangeR\bmetadata\x12>\n" +
+	"\rrelationships\x18\x06 \x03(\v2\x18.core.RelationshipChangeR\rrelationships\x125\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2\x15.core.AttributeChangeR\n" +
+	"attributes\"\xe3\x01\n" +
+	"\x0fTraverseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x11relationshipNames\x18\x02 \x03(\tR\x11relationshipNames\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x1a\n" +
+	"\bminDepth\x18\x04 \x01(\x05R\bminDepth\x12\x1a\n" +
+	"\bmaxDepth\x18\x05 \x01(\x05R\bmaxDepth\x12\x1a\n" +
+	"\bactiveAt\x18\x06 \x01(\tR\bactiveAt\x12 \n" +
+	"\x05kinds\x18\a \x03(\v2\n" +
+	".core.KindR\x05kinds\"e\n" +
+	"\tGraphNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\x04kind\x18\x02 \x01(\v2\n" +
+	".core.KindR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05depth\x18\x04 \x01(\x05R\x05depth\"\x9f\x01\n" +
+	"\tGraphEdge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bsourceId\x18\x03 \x01(\tR\bsourceId\x12\x1a\n" +
+	"\btargetId\x18\x04 \x01(\tR\btargetId\x12\x1c\n" +
+	"\tstartTime\x18\x05 \x01(\tR\tstartTime\x12\x18\n" +
+	"\aendTime\x18\x06 \x01(\tR\aendTime\"s\n" +
+	"\x05Graph\x12%\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x0f.core.GraphNodeR\x05nodes\x12%\n" +
+	"\x05edges\x18\x02 \x03(\v2\x0f.core.GraphEdgeR\x05edges\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"\xd4\x01\n" +
+	"\x10FindPathsRequest\x12\x16\n" +
+	"\x06fromId\x18\x01 \x01(\tR\x06fromId\x12\x12\n" +
+	"\x04toId\x18\x02 \x01(\tR\x04toId\x12,\n" +
+	"\x11relationshipNames\x18\x03 \x03(\tR\x11relationshipNames\x12\x1a\n" +
+	"\bactiveAt\x18\x04 \x01(\tR\bactiveAt\x12\x1c\n" +
+	"\tmaxLength\x18\x05 \x01(\x05R\tmaxLength\x12\x10\n" +
+	"\x03all\x18\x06 \x01(\bR\x03all\x12\x1a\n" +
+	"\bmaxPaths\x18\a \x01(\x05R\bmaxPaths\"N\n" +
+	"\x04Path\x12\x1c\n" +
+	"\tentityIds\x18\x01 \x03(\tR\tentityIds\x12(\n" +
+	"\x0frelationshipIds\x18\x02 \x03(\tR\x0frelationshipIds\",\n" +
+	"\bPathList\x12 \n" +
+	"\x05paths\x18\x01 \x03(\v2\n" +
+	".core.PathR\x05paths\"\xd8\x01\n" +
+	"\x14ReadHierarchyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x11relationshipNames\x18\x02 \x03(\tR\x11relationshipNames\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x1a\n" +
+	"\bactiveAt\x18\x04 \x01(\tR\bactiveAt\x12\x1a\n" +
+	"\bmaxDepth\x18\x05 \x01(\x05R\bmaxDepth\x12,\n" +
+	"\x11includeChildCount\x18\x06 \x01(\bR\x11includeChildCount\"\xcc\x01\n" +
+	"\rHierarchyNode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1e\n" +
+	"\x04kind\x18\x02 \x01(\v2\n" +
+	".core.KindR\x04kind\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12&\n" +
+	"\x0erelationshipId\x18\x04 \x01(\tR\x0erelationshipId\x12\x1e\n" +
+	"\n" +
+	"childCount\x18\x05 \x01(\x05R\n" +
+	"childCount\x12/\n" +
+	"\bchildren\x18\x06 \x03(\v2\x13.core.HierarchyNodeR\bchildren\"R\n" +
+	"\tHierarchy\x12'\n" +
+	"\x04root\x18\x01 \x01(\v2\x13.core.HierarchyNodeR\x04root\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated2\xe2\a\n" +
 	"\vCOREService\x12*\n" +
 	"\fCreateEntity\x12\f.core.Entity\x1a\f.core.Entity\x123\n" +
 	"\n" +
 	"ReadEntity\x12\x17.core.ReadEntityRequest\x1a\f.core.Entity\x129\n" +
 	"\fReadEntities\x12\x17.core.ReadEntityRequest\x1a\x10.core.EntityList\x127\n" +
-	"\fUpdateEntity\x12\x19.core.UpdateEntityRequest\x1a\f.core.Entity\x12+\n" +
-	"\fDeleteEntity\x12\x0e.core.EntityId\x1a\v.core.EmptyB\x1cZ\x1alk/datafoundation/core-apib\x06proto3"
+	"\fUpdateEntity\x12\x19.core.UpdateEntityRequest\x1a\f.core.Entity\x12E\n" +
+	"\fDeleteEntity\x12\x19.core.DeleteEntityRequest\x1a\x1a.core.DeleteEntityResponse\x12H\n" +
+	"\x13BatchCreateEntities\x12\f.core.Entity\x1a!.core.BatchCreateEntitiesResponse(\x01\x12@\n" +
+	"\rWatchEntities\x12\x1a.core.WatchEntitiesRequest\x1a\x11.core.EntityEvent0\x01\x12b\n" +
+	"\x1bListFailedWebhookDeliveries\x12(.core.ListFailedWebhookDeliveriesRequest\x1a\x19.core.WebhookDeliveryList\x12f\n" +
+	"\x17ReplayWebhookDeliveries\x12$.core.ReplayWebhookDeliveriesRequest\x1a%.core.ReplayWebhookDeliveriesResponse\x129\n" +
+	"\fReadAuditLog\x12\x19.core.ReadAuditLogRequest\x1a\x0e.core.AuditLog\x12H\n" +
+	"\x11ReadEntityHistory\x12\x1e.core.ReadEntityHistoryRequest\x1a\x13.core.EntityHistory\x127\n" +
+	"\n" +
+	"DiffEntity\x12\x17.core.DiffEntityRequest\x1a\x10.core.EntityDiff\x12.\n" +
+	"\bTraverse\x12\x15.core.TraverseRequest\x1a\v.core.Graph\x123\n" +
+	"\tFindPaths\x12\x16.core.FindPathsRequest\x1a\x0e.core.PathList\x12<\n" +
+	"\rReadHierarchy\x12\x1a.core.ReadHierarchyRequest\x1a\x0f.core.HierarchyB\x1cZ\x1alk/datafoundation/core-apib\x06proto3"
 
 var (
 	file_types_v1_proto_rawDescOnce sync.Once
@@ -681,52 +3545,152 @@ func file_types_v1_proto_rawDescGZIP() []byte {
 	return file_types_v1_proto_rawDescData
 }
 
-var file_types_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_types_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_types_v1_proto_goTypes = []any{
-	(*Kind)(nil),                // 0: core.Kind
-	(*TimeBasedValue)(nil),      // 1: core.TimeBasedValue
-	(*Relationship)(nil),        // 2: core.Relationship
-	(*Entity)(nil),              // 3: core.Entity
-	(*TimeBasedValueList)(nil),  // 4: core.TimeBasedValueList
-	(*ReadEntityRequest)(nil),   // 5: core.ReadEntityRequest
-	(*EntityId)(nil),            // 6: core.EntityId
-	(*UpdateEntityRequest)(nil), // 7: core.UpdateEntityRequest
-	(*Empty)(nil),               // 8: core.Empty
-	(*EntityList)(nil),          // 9: core.EntityList
-	nil,                         // 10: core.Entity.MetadataEntry
-	nil,                         // 11: core.Entity.AttributesEntry
-	nil,                         // 12: core.Entity.RelationshipsEntry
-	(*anypb.Any)(nil),           // 13: google.protobuf.Any
+	(*Kind)(nil),                               // 0: core.Kind
+	(*TimeBasedValue)(nil),                     // 1: core.TimeBasedValue
+	(*Relationship)(nil),                       // 2: core.Relationship
+	(*RelationshipVersion)(nil),                // 3: core.RelationshipVersion
+	(*Entity)(nil),                             // 4: core.Entity
+	(*TimeBasedValueList)(nil),                 // 5: core.TimeBasedValueList
+	(*ReadEntityRequest)(nil),                  // 6: core.ReadEntityRequest
+	(*EntityId)(nil),                           // 7: core.EntityId
+	(*DeleteEntityRequest)(nil),                // 8: core.DeleteEntityRequest
+	(*DeleteEntityResponse)(nil),               // 9: core.DeleteEntityResponse
+	(*BatchCreateEntityResult)(nil),            // 10: core.BatchCreateEntityResult
+	(*BatchCreateEntitiesResponse)(nil),        // 11: core.BatchCreateEntitiesResponse
+	(*UpdateEntityRequest)(nil),                // 12: core.UpdateEntityRequest
+	(*Empty)(nil),                              // 13: core.Empty
+	(*EntityList)(nil),                         // 14: core.EntityList
+	(*WatchEntitiesRequest)(nil),               // 15: core.WatchEntitiesRequest
+	(*EntityEvent)(nil),                        // 16: core.EntityEvent
+	(*ListFailedWebhookDeliveriesRequest)(nil), // 17: core.ListFailedWebhookDeliveriesRequest
+	(*WebhookDelivery)(nil),                    // 18: core.WebhookDelivery
+	(*WebhookDeliveryList)(nil),                // 19: core.WebhookDeliveryList
+	(*ReplayWebhookDeliveriesRequest)(nil),     // 20: core.ReplayWebhookDeliveriesRequest
+	(*ReplayWebhookDeliveriesResponse)(nil),    // 21: core.ReplayWebhookDeliveriesResponse
+	(*ReadAuditLogRequest)(nil),                // 22: core.ReadAuditLogRequest
+	(*AuditRecord)(nil),                        // 23: core.AuditRecord
+	(*AuditChange)(nil),                        // 24: core.AuditChange
+	(*AuditAttributeWrite)(nil),                // 25: core.AuditAttributeWrite
+	(*AuditLog)(nil),                           // 26: core.AuditLog
+	(*ReadEntityHistoryRequest)(nil),           // 27: core.ReadEntityHistoryRequest
+	(*MetadataRevision)(nil),                   // 28: core.MetadataRevision
+	(*EntityHistory)(nil),                      // 29: core.EntityHistory
+	(*DiffEntityRequest)(nil),                  // 30: core.DiffEntityRequest
+	(*NameChange)(nil),                         // 31: core.NameChange
+	(*MetadataChange)(nil),                     // 32: core.MetadataChange
+	(*RelationshipChange)(nil),                 // 33: core.RelationshipChange
+	(*AttributeChange)(nil),                    // 34: core.AttributeChange
+	(*EntityDiff)(nil),                         // 35: core.EntityDiff
+	(*TraverseRequest)(nil),                    // 36: core.TraverseRequest
+	(*GraphNode)(nil),                          // 37: core.GraphNode
+	(*GraphEdge)(nil),                          // 38: core.GraphEdge
+	(*Graph)(nil),                              // 39: core.Graph
+	(*FindPathsRequest)(nil),                   // 40: core.FindPathsRequest
+	(*Path)(nil),                               // 41: core.Path
+	(*PathList)(nil),                           // 42: core.PathList
+	(*ReadHierarchyRequest)(nil),               // 43: core.ReadHierarchyRequest
+	(*HierarchyNode)(nil),                      // 44: core.HierarchyNode
+	(*Hierarchy)(nil),                          // 45: core.Hierarchy
+	nil,                                        // 46: core.Relationship.PropertiesEntry
+	nil,                                        // 47: core.RelationshipVersion.PropertiesEntry
+	nil,                                        // 48: core.Entity.MetadataEntry
+	nil,                                        // 49: core.Entity.AttributesEntry
+	nil,                                        // 50: core.Entity.RelationshipsEntry
+	nil,                                        // 51: core.MetadataRevision.MetadataEntry
+	nil,                                        // 52: core.EntityHistory.AttributesEntry
+	(*anypb.Any)(nil),                          // 53: google.protobuf.Any
 }
 var file_types_v1_proto_depIdxs = []int32{
-	13, // 0: core.TimeBasedValue.value:type_name -> google.protobuf.Any
-	0,  // 1: core.Entity.kind:type_name -> core.Kind
-	1,  // 2: core.Entity.name:type_name -> core.TimeBasedValue
-	10, // 3: core.Entity.metadata:type_name -> core.Entity.MetadataEntry
-	11, // 4: core.Entity.attributes:type_name -> core.Entity.AttributesEntry
-	12, // 5: core.Entity.relationships:type_name -> core.Entity.RelationshipsEntry
-	1,  // 6: core.TimeBasedValueList.values:type_name -> core.TimeBasedValue
-	3,  // 7: core.ReadEntityRequest.entity:type_name -> core.Entity
-	3,  // 8: core.UpdateEntityRequest.entity:type_name -> core.Entity
-	3,  // 9: core.EntityList.entities:type_name -> core.Entity
-	13, // 10: core.Entity.MetadataEntry.value:type_name -> google.protobuf.Any
-	4,  // 11: core.Entity.AttributesEntry.value:type_name -> core.TimeBasedValueList
-	2,  // 12: core.Entity.RelationshipsEntry.value:type_name -> core.Relationship
-	3,  // 13: core.COREService.CreateEntity:input_type -> core.Entity
-	5,  // 14: core.COREService.ReadEntity:input_type -> core.ReadEntityRequest
-	5,  // 15: core.COREService.ReadEntities:input_type -> core.ReadEntityRequest
-	7,  // 16: core.COREService.UpdateEntity:input_type -> core.UpdateEntityRequest
-	6,  // 17: core.COREService.DeleteEntity:input_type -> core.EntityId
-	3,  // 18: core.COREService.CreateEntity:output_type -> core.Entity
-	3,  // 19: core.COREService.ReadEntity:output_type -> core.Entity
-	9,  // 20: core.COREService.ReadEntities:output_type -> core.EntityList
-	3,  // 21: core.COREService.UpdateEntity:output_type -> core.Entity
-	8,  // 22: core.COREService.DeleteEntity:output_type -> core.Empty
-	18, // [18:23] is the sub-list for method output_type
-	13, // [13:18] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	53, // 0: core.TimeBasedValue.value:type_name -> google.protobuf.Any
+	46, // 1: core.Relationship.properties:type_name -> core.Relationship.PropertiesEntry
+	3,  // 2: core.Relationship.versions:type_name -> core.RelationshipVersion
+	47, // 3: core.RelationshipVersion.properties:type_name -> core.RelationshipVersion.PropertiesEntry
+	0,  // 4: core.Entity.kind:type_name -> core.Kind
+	1,  // 5: core.Entity.name:type_name -> core.TimeBasedValue
+	48, // 6: core.Entity.metadata:type_name -> core.Entity.MetadataEntry
+	49, // 7: core.Entity.attributes:type_name -> core.Entity.AttributesEntry
+	50, // 8: core.Entity.relationships:type_name -> core.Entity.RelationshipsEntry
+	1,  // 9: core.TimeBasedValueList.values:type_name -> core.TimeBasedValue
+	4,  // 10: core.ReadEntityRequest.entity:type_name -> core.Entity
+	10, // 11: core.BatchCreateEntitiesResponse.results:type_name -> core.BatchCreateEntityResult
+	4,  // 12: core.UpdateEntityRequest.entity:type_name -> core.Entity
+	4,  // 13: core.EntityList.entities:type_name -> core.Entity
+	0,  // 14: core.WatchEntitiesRequest.kinds:type_name -> core.Kind
+	0,  // 15: core.EntityEvent.kind:type_name -> core.Kind
+	2,  // 16: core.EntityEvent.relationship:type_name -> core.Relationship
+	16, // 17: core.WebhookDelivery.event:type_name -> core.EntityEvent
+	18, // 18: core.WebhookDeliveryList.deliveries:type_name -> core.WebhookDelivery
+	24, // 19: core.AuditRecord.changes:type_name -> core.AuditChange
+	25, // 20: core.AuditRecord.attributes:type_name -> core.AuditAttributeWrite
+	23, // 21: core.AuditLog.records:type_name -> core.AuditRecord
+	51, // 22: core.MetadataRevision.metadata:type_name -> core.MetadataRevision.MetadataEntry
+	0,  // 23: core.EntityHistory.kind:type_name -> core.Kind
+	1,  // 24: core.EntityHistory.names:type_name -> core.TimeBasedValue
+	28, // 25: core.EntityHistory.metadata:type_name -> core.MetadataRevision
+	2,  // 26: core.EntityHistory.relationships:type_name -> core.Relationship
+	52, // 27: core.EntityHistory.attributes:type_name -> core.EntityHistory.AttributesEntry
+	53, // 28: core.MetadataChange.before:type_name -> google.protobuf.Any
+	53, // 29: core.MetadataChange.after:type_name -> google.protobuf.Any
+	2,  // 30: core.RelationshipChange.before:type_name -> core.Relationship
+	2,  // 31: core.RelationshipChange.after:type_name -> core.Relationship
+	53, // 32: core.AttributeChange.addedRows:type_name -> google.protobuf.Any
+	53, // 33: core.AttributeChange.removedRows:type_name -> google.protobuf.Any
+	31, // 34: core.EntityDiff.name:type_name -> core.NameChange
+	32, // 35: core.EntityDiff.metadata:type_name -> core.MetadataChange
+	33, // 36: core.EntityDiff.relationships:type_name -> core.RelationshipChange
+	34, // 37: core.EntityDiff.attributes:type_name -> core.AttributeChange
+	0,  // 38: core.TraverseRequest.kinds:type_name -> core.Kind
+	0,  // 39: core.GraphNode.kind:type_name -> core.Kind
+	37, // 40: core.Graph.nodes:type_name -> core.GraphNode
+	38, // 41: core.Graph.edges:type_name -> core.GraphEdge
+	41, // 42: core.PathList.paths:type_name -> core.Path
+	0,  // 43: core.HierarchyNode.kind:type_name -> core.Kind
+	44, // 44: core.HierarchyNode.children:type_name -> core.HierarchyNode
+	44, // 45: core.Hierarchy.root:type_name -> core.HierarchyNode
+	53, // 46: core.Relationship.PropertiesEntry.value:type_name -> google.protobuf.Any
+	53, // 47: core.RelationshipVersion.PropertiesEntry.value:type_name -> google.protobuf.Any
+	53, // 48: core.Entity.MetadataEntry.value:type_name -> google.protobuf.Any
+	5,  // 49: core.Entity.AttributesEntry.value:type_name -> core.TimeBasedValueList
+	2,  // 50: core.Entity.RelationshipsEntry.value:type_name -> core.Relationship
+	53, // 51: core.MetadataRevision.MetadataEntry.value:type_name -> google.protobuf.Any
+	5,  // 52: core.EntityHistory.AttributesEntry.value:type_name -> core.TimeBasedValueList
+	4,  // 53: core.COREService.CreateEntity:input_type -> core.Entity
+	6,  // 54: core.COREService.ReadEntity:input_type -> core.ReadEntityRequest
+	6,  // 55: core.COREService.ReadEntities:input_type -> core.ReadEntityRequest
+	12, // 56: core.COREService.UpdateEntity:input_type -> core.UpdateEntityRequest
+	8,  // 57: core.COREService.DeleteEntity:input_type -> core.DeleteEntityRequest
+	4,  // 58: core.COREService.BatchCreateEntities:input_type -> core.Entity
+	15, // 59: core.COREService.WatchEntities:input_type -> core.WatchEntitiesRequest
+	17, // 60: core.COREService.ListFailedWebhookDeliveries:input_type -> core.ListFailedWebhookDeliveriesRequest
+	20, // 61: core.COREService.ReplayWebhookDeliveries:input_type -> core.ReplayWebhookDeliveriesRequest
+	22, // 62: core.COREService.ReadAuditLog:input_type -> core.ReadAuditLogRequest
+	27, // 63: core.COREService.ReadEntityHistory:input_type -> core.ReadEntityHistoryRequest
+	30, // 64: core.COREService.DiffEntity:input_type -> core.DiffEntityRequest
+	36, // 65: core.COREService.Traverse:input_type -> core.TraverseRequest
+	40, // 66: core.COREService.FindPaths:input_type -> core.FindPathsRequest
+	43, // 67: core.COREService.ReadHierarchy:input_type -> core.ReadHierarchyRequest
+	4,  // 68: core.COREService.CreateEntity:output_type -> core.Entity
+	4,  // 69: core.COREService.ReadEntity:output_type -> core.Entity
+	14, // 70: core.COREService.ReadEntities:output_type -> core.EntityList
+	4,  // 71: core.COREService.UpdateEntity:output_type -> core.Entity
+	9,  // 72: core.COREService.DeleteEntity:output_type -> core.DeleteEntityResponse
+	11, // 73: core.COREService.BatchCreateEntities:output_type -> core.BatchCreateEntitiesResponse
+	16, // 74: core.COREService.WatchEntities:output_type -> core.EntityEvent
+	19, // 75: core.COREService.ListFailedWebhookDeliveries:output_type -> core.WebhookDeliveryList
+	21, // 76: core.COREService.ReplayWebhookDeliveries:output_type -> core.ReplayWebhookDeliveriesResponse
+	26, // 77: core.COREService.ReadAuditLog:output_type -> core.AuditLog
+	29, // 78: core.COREService.ReadEntityHistory:output_type -> core.EntityHistory
+	35, // 79: core.COREService.DiffEntity:output_type -> core.EntityDiff
+	39, // 80: core.COREService.Traverse:output_type -> core.Graph
+	42, // 81: core.COREService.FindPaths:output_type -> core.PathList
+	45, // 82: core.COREService.ReadHierarchy:output_type -> core.Hierarchy
+	68, // [68:83] is the sub-list for method output_type
+	53, // [53:68] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_types_v1_proto_init() }
@@ -740,7 +3704,7 @@ func file_types_v1_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_v1_proto_rawDesc), len(file_types_v1_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
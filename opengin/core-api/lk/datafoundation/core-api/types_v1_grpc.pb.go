@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	COREService_CreateEntity_FullMethodName = "/core.COREService/CreateEntity"
-	COREService_ReadEntity_FullMethodName   = "/core.COREService/ReadEntity"
-	COREService_ReadEntities_FullMethodName = "/core.COREService/ReadEntities"
-	COREService_UpdateEntity_FullMethodName = "/core.COREService/UpdateEntity"
-	COREService_DeleteEntity_FullMethodName = "/core.COREService/DeleteEntity"
+	COREService_CreateEntity_FullMethodName                = "/core.COREService/CreateEntity"
+	COREService_ReadEntity_FullMethodName                  = "/core.COREService/ReadEntity"
+	COREService_ReadEntities_FullMethodName                = "/core.COREService/ReadEntities"
+	COREService_UpdateEntity_FullMethodName                = "/core.COREService/UpdateEntity"
+	COREService_DeleteEntity_FullMethodName                = "/core.COREService/DeleteEntity"
+	COREService_BatchCreateEntities_FullMethodName         = "/core.COREService/BatchCreateEntities"
+	COREService_WatchEntities_FullMethodName               = "/core.COREService/WatchEntities"
+	COREService_ListFailedWebhookDeliveries_FullMethodName = "/core.COREService/ListFailedWebhookDeliveries"
+	COREService_ReplayWebhookDeliveries_FullMethodName     = "/core.COREService/ReplayWebhookDeliveries"
+	COREService_ReadAuditLog_FullMethodName                = "/core.COREService/ReadAuditLog"
+	COREService_ReadEntityHistory_FullMethodName           = "/core.COREService/ReadEntityHistory"
+	COREService_DiffEntity_FullMethodName                  = "/core.COREService/DiffEntity"
+	COREService_Traverse_FullMethodName                    = "/core.COREService/Traverse"
+	COREService_FindPaths_FullMethodName                   = "/core.COREService/FindPaths"
+	COREService_ReadHierarchy_FullMethodName               = "/core.COREService/ReadHierarchy"
 )
 
 // COREServiceClient is the client API for COREService service.
@@ -36,7 +46,17 @@ type COREServiceClient interface {
 	ReadEntity(ctx context.Context, in *ReadEntityRequest, opts ...grpc.CallOption) (*Entity, error)
 	ReadEntities(ctx context.Context, in *ReadEntityRequest, opts ...grpc.CallOption) (*EntityList, error)
 	UpdateEntity(ctx context.Context, in *UpdateEntityRequest, opts ...grpc.CallOption) (*Entity, error)
-	DeleteEntity(ctx context.Context, in *EntityId, opts ...grpc.CallOption) (*Empty, error)
+	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*DeleteEntityResponse, error)
+	BatchCreateEntities(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entity, BatchCreateEntitiesResponse], error)
+	WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EntityEvent], error)
+	ListFailedWebhookDeliveries(ctx context.Context, in *ListFailedWebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveryList, error)
+	ReplayWebhookDeliveries(ctx context.Context, in *ReplayWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveriesResponse, error)
+	ReadAuditLog(ctx context.Context, in *ReadAuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error)
+	ReadEntityHistory(ctx context.Context, in *ReadEntityHistoryRequest, opts ...grpc.CallOption) (*EntityHistory, error)
+	DiffEntity(ctx context.Context, in *DiffEntityRequest, opts ...grpc.CallOption) (*EntityDiff, error)
+	Traverse(ctx context.Context, in *TraverseRequest, opts ...grpc.CallOption) (*Graph, error)
+	FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*PathList, error)
+	ReadHierarchy(ctx context.Context, in *ReadHierarchyRequest, opts ...grpc.CallOption) (*Hierarchy, error)
 }
 
 type cOREServiceClient struct {
@@ -87,9 +107,9 @@ func (c *cOREServiceClient) UpdateEntity(ctx context.Context, in *UpdateEntityRe
 	return out, nil
 }
 
-func (c *cOREServiceClient) DeleteEntity(ctx context.Context, in *EntityId, opts ...grpc.CallOption) (*Empty, error) {
+func (c *cOREServiceClient) DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*DeleteEntityResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(DeleteEntityResponse)
 	err := c.cc.Invoke(ctx, COREService_DeleteEntity_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -97,6 +117,118 @@ func (c *cOREServiceClient) DeleteEntity(ctx context.Context, in *EntityId, opts
 	return out, nil
 }
 
+func (c *cOREServiceClient) BatchCreateEntities(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Entity, BatchCreateEntitiesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &COREService_ServiceDesc.Streams[0], COREService_BatchCreateEntities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Entity, BatchCreateEntitiesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type COREService_BatchCreateEntitiesClient = grpc.ClientStreamingClient[Entity, BatchCreateEntitiesResponse]
+
+func (c *cOREServiceClient) WatchEntities(ctx context.Context, in *WatchEntitiesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EntityEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &COREService_ServiceDesc.Streams[1], COREService_WatchEntities_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEntitiesRequest, EntityEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type COREService_WatchEntitiesClient = grpc.ServerStreamingClient[EntityEvent]
+
+func (c *cOREServiceClient) ListFailedWebhookDeliveries(ctx context.Context, in *ListFailedWebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveryList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveryList)
+	err := c.cc.Invoke(ctx, COREService_ListFailedWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) ReplayWebhookDeliveries(ctx context.Context, in *ReplayWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ReplayWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, COREService_ReplayWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) ReadAuditLog(ctx context.Context, in *ReadAuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditLog)
+	err := c.cc.Invoke(ctx, COREService_ReadAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) ReadEntityHistory(ctx context.Context, in *ReadEntityHistoryRequest, opts ...grpc.CallOption) (*EntityHistory, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EntityHistory)
+	err := c.cc.Invoke(ctx, COREService_ReadEntityHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) DiffEntity(ctx context.Context, in *DiffEntityRequest, opts ...grpc.CallOption) (*EntityDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EntityDiff)
+	err := c.cc.Invoke(ctx, COREService_DiffEntity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) Traverse(ctx context.Context, in *TraverseRequest, opts ...grpc.CallOption) (*Graph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Graph)
+	err := c.cc.Invoke(ctx, COREService_Traverse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) FindPaths(ctx context.Context, in *FindPathsRequest, opts ...grpc.CallOption) (*PathList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PathList)
+	err := c.cc.Invoke(ctx, COREService_FindPaths_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cOREServiceClient) ReadHierarchy(ctx context.Context, in *ReadHierarchyRequest, opts ...grpc.CallOption) (*Hierarchy, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hierarchy)
+	err := c.cc.Invoke(ctx, COREService_ReadHierarchy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // COREServiceServer is the server API for COREService service.
 // All implementations must embed UnimplementedCOREServiceServer
 // for forward compatibility.
@@ -107,7 +239,17 @@ type COREServiceServer interface {
 	ReadEntity(context.Context, *ReadEntityRequest) (*Entity, error)
 	ReadEntities(context.Context, *ReadEntityRequest) (*EntityList, error)
 	UpdateEntity(context.Context, *UpdateEntityRequest) (*Entity, error)
-	DeleteEntity(context.Context, *EntityId) (*Empty, error)
+	DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error)
+	BatchCreateEntities(grpc.ClientStreamingServer[Entity, BatchCreateEntitiesResponse]) error
+	WatchEntities(*WatchEntitiesRequest, grpc.ServerStreamingServer[EntityEvent]) error
+	ListFailedWebhookDeliveries(context.Context, *ListFailedWebhookDeliveriesRequest) (*WebhookDeliveryList, error)
+	ReplayWebhookDeliveries(context.Context, *ReplayWebhookDeliveriesRequest) (*ReplayWebhookDeliveriesResponse, error)
+	ReadAuditLog(context.Context, *ReadAuditLogRequest) (*AuditLog, error)
+	ReadEntityHistory(context.Context, *ReadEntityHistoryRequest) (*EntityHistory, error)
+	DiffEntity(context.Context, *DiffEntityRequest) (*EntityDiff, error)
+	Traverse(context.Context, *TraverseRequest) (*Graph, error)
+	FindPaths(context.Context, *FindPathsRequest) (*PathList, error)
+	ReadHierarchy(context.Context, *ReadHierarchyRequest) (*Hierarchy, error)
 	mustEmbedUnimplementedCOREServiceServer()
 }
 
@@ -130,9 +272,39 @@ func (UnimplementedCOREServiceServer) ReadEntities(context.Context, *ReadEntityR
 func (UnimplementedCOREServiceServer) UpdateEntity(context.Context, *UpdateEntityRequest) (*Entity, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntity not implemented")
 }
-func (UnimplementedCOREServiceServer) DeleteEntity(context.Context, *EntityId) (*Empty, error) {
+func (UnimplementedCOREServiceServer) DeleteEntity(context.Context, *DeleteEntityRequest) (*DeleteEntityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntity not implemented")
 }
+func (UnimplementedCOREServiceServer) BatchCreateEntities(grpc.ClientStreamingServer[Entity, BatchCreateEntitiesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchCreateEntities not implemented")
+}
+func (UnimplementedCOREServiceServer) WatchEntities(*WatchEntitiesRequest, grpc.ServerStreamingServer[EntityEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEntities not implemented")
+}
+func (UnimplementedCOREServiceServer) ListFailedWebhookDeliveries(context.Context, *ListFailedWebhookDeliveriesRequest) (*WebhookDeliveryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFailedWebhookDeliveries not implemented")
+}
+func (UnimplementedCOREServiceServer) ReplayWebhookDeliveries(context.Context, *ReplayWebhookDeliveriesRequest) (*ReplayWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDeliveries not implemented")
+}
+func (UnimplementedCOREServiceServer) ReadAuditLog(context.Context, *ReadAuditLogRequest) (*AuditLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadAuditLog not implemented")
+}
+func (UnimplementedCOREServiceServer) ReadEntityHistory(context.Context, *ReadEntityHistoryRequest) (*EntityHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadEntityHistory not implemented")
+}
+func (UnimplementedCOREServiceServer) DiffEntity(context.Context, *DiffEntityRequest) (*EntityDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffEntity not implemented")
+}
+func (UnimplementedCOREServiceServer) Traverse(context.Context, *TraverseRequest) (*Graph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Traverse not implemented")
+}
+func (UnimplementedCOREServiceServer) FindPaths(context.Context, *FindPathsRequest) (*PathList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPaths not implemented")
+}
+func (UnimplementedCOREServiceServer) ReadHierarchy(context.Context, *ReadHierarchyRequest) (*Hierarchy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadHierarchy not implemented")
+}
 func (UnimplementedCOREServiceServer) mustEmbedUnimplementedCOREServiceServer() {}
 func (UnimplementedCOREServiceServer) testEmbeddedByValue()                     {}
 
@@ -227,7 +399,7 @@ func _COREService_UpdateEntity_Handler(srv interface{}, ctx context.Context, dec
 }
 
 func _COREService_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EntityId)
+	in := new(DeleteEntityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -239,7 +411,169 @@ func _COREService_DeleteEntity_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: COREService_DeleteEntity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(COREServiceServer).DeleteEntity(ctx, req.(*EntityId))
+		return srv.(COREServiceServer).DeleteEntity(ctx, req.(*DeleteEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_BatchCreateEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(COREServiceServer).BatchCreateEntities(&grpc.GenericServerStream[Entity, BatchCreateEntitiesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type COREService_BatchCreateEntitiesServer = grpc.ClientStreamingServer[Entity, BatchCreateEntitiesResponse]
+
+func _COREService_WatchEntities_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEntitiesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(COREServiceServer).WatchEntities(m, &grpc.GenericServerStream[WatchEntitiesRequest, EntityEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type COREService_WatchEntitiesServer = grpc.ServerStreamingServer[EntityEvent]
+
+func _COREService_ListFailedWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFailedWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).ListFailedWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_ListFailedWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).ListFailedWebhookDeliveries(ctx, req.(*ListFailedWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_ReplayWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).ReplayWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_ReplayWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).ReplayWebhookDeliveries(ctx, req.(*ReplayWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_ReadAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).ReadAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_ReadAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).ReadAuditLog(ctx, req.(*ReadAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_ReadEntityHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadEntityHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).ReadEntityHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_ReadEntityHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).ReadEntityHistory(ctx, req.(*ReadEntityHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_DiffEntity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffEntityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).DiffEntity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_DiffEntity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).DiffEntity(ctx, req.(*DiffEntityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_Traverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TraverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).Traverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_Traverse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).Traverse(ctx, req.(*TraverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_FindPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).FindPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_FindPaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).FindPaths(ctx, req.(*FindPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _COREService_ReadHierarchy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadHierarchyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(COREServiceServer).ReadHierarchy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: COREService_ReadHierarchy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(COREServiceServer).ReadHierarchy(ctx, req.(*ReadHierarchyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -271,7 +605,50 @@ var COREService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteEntity",
 			Handler:    _COREService_DeleteEntity_Handler,
 		},
+		{
+			MethodName: "ListFailedWebhookDeliveries",
+			Handler:    _COREService_ListFailedWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDeliveries",
+			Handler:    _COREService_ReplayWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReadAuditLog",
+			Handler:    _COREService_ReadAuditLog_Handler,
+		},
+		{
+			MethodName: "ReadEntityHistory",
+			Handler:    _COREService_ReadEntityHistory_Handler,
+		},
+		{
+			MethodName: "DiffEntity",
+			Handler:    _COREService_DiffEntity_Handler,
+		},
+		{
+			MethodName: "Traverse",
+			Handler:    _COREService_Traverse_Handler,
+		},
+		{
+			MethodName: "FindPaths",
+			Handler:    _COREService_FindPaths_Handler,
+		},
+		{
+			MethodName: "ReadHierarchy",
+			Handler:    _COREService_ReadHierarchy_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchCreateEntities",
+			Handler:       _COREService_BatchCreateEntities_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchEntities",
+			Handler:       _COREService_WatchEntities_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "types_v1.proto",
 }
@@ -0,0 +1,153 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apperrors defines the typed errors returned by the repositories and the engine.
+// An Error carries a Code that the gRPC server turns into a status code, and optionally the
+// entity, relationship, field or attribute it is about, which are sent to the client as error details.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code classifies an Error
+type Code int
+
+const (
+	Unknown            Code = iota
+	NotFound                // The entity, relationship or attribute does not exist
+	AlreadyExists           // The entity or relationship to create exists already
+	InvalidArgument         // The request is malformed, independent of what is stored
+	FailedPrecondition      // The request is valid but conflicts with what is stored
+	Unavailable             // A database could not be reached, retrying may succeed
+	Aborted                 // The request conflicts with a concurrent one, retrying may succeed
+)
+
+// String returns the name of the code
+func (c Code) String() string {
+	switch c {
+	case NotFound:
+		return "NOT_FOUND"
+	case AlreadyExists:
+		return "ALREADY_EXISTS"
+	case InvalidArgument:
+		return "INVALID_ARGUMENT"
+	case FailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case Unavailable:
+		return "UNAVAILABLE"
+	case Aborted:
+		return "ABORTED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Error is an error with a Code and the ids it is about
+type Error struct {
+	Code           Code
+	Message        string
+	EntityID       string
+	RelationshipID string
+	Field          string // Request field that is invalid
+	Attribute      string // Name of the attribute the error is about
+	Err            error  // Underlying error, if any
+}
+
+// Error returns the message followed by the underlying error
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an Error with a formatted message
+func New(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap creates an Error around an underlying error
+func Wrap(code Code, err error, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// NotFoundf creates a NotFound error
+func NotFoundf(format string, args ...interface{}) *Error {
+	return New(NotFound, format, args...)
+}
+
+// AlreadyExistsf creates an AlreadyExists error
+func AlreadyExistsf(format string, args ...interface{}) *Error {
+	return New(AlreadyExists, format, args...)
+}
+
+// InvalidArgumentf creates an InvalidArgument error
+func InvalidArgumentf(format string, args ...interface{}) *Error {
+	return New(InvalidArgument, format, args...)
+}
+
+// FailedPreconditionf creates a FailedPrecondition error
+func FailedPreconditionf(format string, args ...interface{}) *Error {
+	return New(FailedPrecondition, format, args...)
+}
+
+// Abortedf creates an Aborted error
+func Abortedf(format string, args ...interface{}) *Error {
+	return New(Aborted, format, args...)
+}
+
+// WithEntity sets the entity the error is about
+func (e *Error) WithEntity(entityID string) *Error {
+	e.EntityID = entityID
+	return e
+}
+
+// WithRelationship sets the relationship the error is about
+func (e *Error) WithRelationship(relationshipID string) *Error {
+	e.RelationshipID = relationshipID
+	return e
+}
+
+// WithField sets the request field the error is about
+func (e *Error) WithField(field string) *Error {
+	e.Field = field
+	return e
+}
+
+// WithAttribute sets the attribute the error is about
+func (e *Error) WithAttribute(attribute string) *Error {
+	e.Attribute = attribute
+	return e
+}
+
+// As returns the first Error in the chain of err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of the first Error in the chain of err, or Unknown
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return Unknown
+}
+
+// Is reports whether err has the given code
+func Is(err error, code Code) bool {
+	return CodeOf(err) == code
+}
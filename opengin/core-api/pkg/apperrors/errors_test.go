@@ -0,0 +1,106 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCodeOfWrappedError(t *testing.T) {
+	err := NotFoundf("entity with Id %s not found", "entity-1").WithEntity("entity-1")
+	wrapped := fmt.Errorf("error fetching entity info: %w", err)
+
+	assert.Equal(t, NotFound, CodeOf(wrapped))
+	assert.True(t, Is(wrapped, NotFound))
+	assert.Equal(t, "error fetching entity info: entity with Id entity-1 not found", wrapped.Error())
+	assert.Equal(t, Unknown, CodeOf(errors.New("plain error")))
+	assert.Equal(t, Unknown, CodeOf(nil))
+}
+
+func TestWrapKeepsCause(t *testing.T) {
+	cause := errors.New("not a tabular value")
+	err := Wrap(InvalidArgument, cause, "error determining storage type for attribute %s", "population")
+
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "error determining storage type for attribute population: not a tabular value", err.Error())
+}
+
+func TestStatusDetails(t *testing.T) {
+	err := fmt.Errorf("error creating entity: %w", InvalidArgumentf("missing Name for relationship rel-1").
+		WithEntity("entity-1").WithRelationship("rel-1").WithField("relationships.name"))
+
+	st := Status(err, nil)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, err.Error(), st.Message())
+
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "INVALID_ARGUMENT", info.Reason)
+		assert.Equal(t, ErrorDomain, info.Domain)
+		assert.Equal(t, map[string]string{"entityId": "entity-1", "relationshipId": "rel-1", "field": "relationships.name"}, info.Metadata)
+	}
+	if assert.NotNil(t, badRequest) {
+		assert.Equal(t, "relationships.name", badRequest.FieldViolations[0].Field)
+	}
+}
+
+func TestStatusClassifiesOtherErrors(t *testing.T) {
+	driverErr := errors.New("no reachable servers")
+	classify := func(err error) Code {
+		if errors.Is(err, driverErr) {
+			return Unavailable
+		}
+		return Unknown
+	}
+
+	assert.Equal(t, codes.Unavailable, Status(fmt.Errorf("error reading entity: %w", driverErr), classify).Code())
+	assert.Equal(t, codes.Unavailable, Status(&net.OpError{Op: "dial", Err: errors.New("connection refused")}, nil).Code())
+	assert.Equal(t, codes.Unknown, Status(errors.New("plain error"), classify).Code())
+
+	// Errors that already carry a status are passed through
+	assert.Equal(t, codes.PermissionDenied, Status(status.Error(codes.PermissionDenied, "denied"), classify).Code())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.COREService/ReadEntity"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, AlreadyExistsf("entity with Id %s already exists", "entity-1").WithEntity("entity-1")
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// A cancelled request is reported as cancelled rather than by the error it caused
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, fmt.Errorf("error reading entity: %w", ctx.Err())
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
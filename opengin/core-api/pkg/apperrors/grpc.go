@@ -0,0 +1,134 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package apperrors
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details sent with an Error
+const ErrorDomain = "core.datafoundation.lk"
+
+// Classifier returns the code of an error that is not an Error, for example a database driver error,
+// or Unknown if it does not recognise it
+type Classifier func(err error) Code
+
+// grpcCodes maps the codes to gRPC status codes
+var grpcCodes = map[Code]codes.Code{
+	Unknown:            codes.Unknown,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.AlreadyExists,
+	InvalidArgument:    codes.InvalidArgument,
+	FailedPrecondition: codes.FailedPrecondition,
+	Unavailable:        codes.Unavailable,
+	Aborted:            codes.Aborted,
+}
+
+// Status converts an error into a gRPC status. Errors that already carry a status are kept,
+// an Error is mapped by its code and sent with ErrorInfo and BadRequest details, and any other
+// error is mapped with the classifier, which may be nil.
+func Status(err error, classify Classifier) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return status.Convert(err)
+	}
+
+	appErr, ok := As(err)
+	if !ok {
+		return status.New(grpcCodes[classifyCode(err, classify)], err.Error())
+	}
+
+	code := appErr.Code
+	if code == Unknown {
+		code = classifyCode(err, classify)
+	}
+	st := status.New(grpcCodes[code], err.Error())
+
+	metadata := map[string]string{}
+	if appErr.EntityID != "" {
+		metadata["entityId"] = appErr.EntityID
+	}
+	if appErr.RelationshipID != "" {
+		metadata["relationshipId"] = appErr.RelationshipID
+	}
+	if appErr.Field != "" {
+		metadata["field"] = appErr.Field
+	}
+	if appErr.Attribute != "" {
+		metadata["attribute"] = appErr.Attribute
+	}
+	withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: code.String(), Domain: ErrorDomain, Metadata: metadata})
+	if detailsErr != nil {
+		return st
+	}
+	if code == InvalidArgument && appErr.Field != "" {
+		withDetails, detailsErr = withDetails.WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: appErr.Field, Description: appErr.Message}},
+		})
+		if detailsErr != nil {
+			return st
+		}
+	}
+	return withDetails
+}
+
+// classifyCode returns the code of an error that is not an Error
+func classifyCode(err error, classify Classifier) Code {
+	if classify != nil {
+		if code := classify(err); code != Unknown {
+			return code
+		}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+		return Unavailable
+	}
+	return Unknown
+}
+
+// UnaryServerInterceptor converts the errors of unary handlers into gRPC statuses
+func UnaryServerInterceptor(classify Classifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(ctx, err, classify)
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor converts the errors of streaming handlers into gRPC statuses
+func StreamServerInterceptor(classify Classifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, stream); err != nil {
+			return toStatusError(stream.Context(), err, classify)
+		}
+		return nil
+	}
+}
+
+// toStatusError converts an error into a status error, reporting a cancelled or
+// expired request as such instead of the error it caused
+func toStatusError(ctx context.Context, err error, classify Classifier) error {
+	if _, ok := As(err); !ok {
+		switch {
+		case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+			return status.Error(codes.Canceled, err.Error())
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		}
+	}
+	return Status(err, classify).Err()
+}
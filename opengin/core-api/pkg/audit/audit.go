@@ -0,0 +1,199 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit keeps an append-only log of the changes made to entities, to answer who changed what and when.
+//
+// Every write to an entity appends a Record with the caller, the RPC and request id, the changes of the
+// kind, name, dates, metadata and relationships of the entity, as values before and after the write, and
+// a summary of the attribute values written. Records are never updated or deleted: a Store only appends
+// and finds them.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/auth"
+	"lk/datafoundation/core-api/pkg/logging"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Record is a write to an entity
+type Record struct {
+	ID         string // Ordered by time, see Query.After
+	Time       time.Time
+	Actor      string // Subject of the caller, empty when authentication is disabled
+	AuthMethod string // How the caller authenticated, "api-key" or "jwt"
+	Method     string // Full name of the RPC
+	RequestID  string
+	EntityID   string
+	Changes    []Change
+	Attributes []AttributeWrite
+}
+
+// Change is a field of an entity that a write changed
+type Change struct {
+	Field  string // kind, name, created, terminated, metadata.<key> or relationships.<id>
+	Before string // Empty if the write added the field
+	After  string // Empty if the write removed the field
+}
+
+// AttributeWrite summarises the values of an attribute a write stored or deleted
+type AttributeWrite struct {
+	Name    string
+	Values  int // Number of values written
+	Deleted bool
+}
+
+// Query selects records, the most recent first. Empty fields select every record.
+type Query struct {
+	EntityID string
+	Actor    string
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	Limit    int
+	After    string // Id of the last record of the previous page
+}
+
+// Store keeps the records. It has no way to change a record once it was appended.
+type Store interface {
+	// Append stores a new record
+	Append(ctx context.Context, record *Record) error
+	// Find returns the records matching the query, the most recent first
+	Find(ctx context.Context, query Query) ([]*Record, error)
+}
+
+// Log appends the records of the writes. A nil Log records nothing, for when auditing is disabled.
+type Log struct {
+	store Store
+}
+
+// New creates the log of the store
+func New(store Store) *Log {
+	return &Log{store: store}
+}
+
+// Append records the changes a write made to the entity, attributing them to the caller and RPC of the context.
+// A write that changed nothing is not recorded.
+func (l *Log) Append(ctx context.Context, entityID string, changes []Change, attributes []AttributeWrite) error {
+	if l == nil || (len(changes) == 0 && len(attributes) == 0) {
+		return nil
+	}
+	record := &Record{
+		ID:         uuid.Must(uuid.NewV7()).String(),
+		Time:       time.Now().UTC(),
+		RequestID:  logging.RequestID(ctx),
+		EntityID:   entityID,
+		Changes:    changes,
+		Attributes: attributes,
+	}
+	record.Method, _ = grpc.Method(ctx)
+	if id, ok := auth.FromContext(ctx); ok {
+		record.Actor = id.Subject
+		record.AuthMethod = id.Method
+	}
+	if err := l.store.Append(ctx, record); err != nil {
+		return fmt.Errorf("error appending audit record of entity %s: %w", entityID, err)
+	}
+	return nil
+}
+
+// Find returns the records matching the query, see Store.Find
+func (l *Log) Find(ctx context.Context, query Query) ([]*Record, error) {
+	return l.store.Find(ctx, query)
+}
+
+// Snapshot holds the audited fields of an entity as readable values, keyed like Change.Field
+type Snapshot map[string]string
+
+// SnapshotOf returns the audited fields of the entity. Attribute values are not part of it,
+// see AttributeWrites; relationships are those given, which are the outgoing ones.
+func SnapshotOf(entity *pb.Entity) Snapshot {
+	snapshot := Snapshot{}
+	set := func(field, value string) {
+		if value != "" {
+			snapshot[field] = value
+		}
+	}
+	if kind := entity.GetKind(); kind != nil {
+		set("kind", strings.TrimSuffix(kind.Major+"/"+kind.Minor, "/"))
+	}
+	set("name", Describe(entity.GetName().GetValue()))
+	set("created", entity.Created)
+	set("terminated", entity.Terminated)
+	for key, value := range entity.Metadata {
+		set("metadata."+key, Describe(value))
+	}
+	for id, relationship := range entity.Relationships {
+		if relationship.Id != "" {
+			id = relationship.Id
+		}
+		set("relationships."+id, describeRelationship(relationship))
+	}
+	return snapshot
+}
+
+// Diff returns the fields that differ between the snapshots, sorted by field.
+// A nil before is a created entity, a nil after a deleted one.
+func Diff(before, after Snapshot) []Change {
+	var changes []Change
+	for field, value := range after {
+		if before[field] != value {
+			changes = append(changes, Change{Field: field, Before: before[field], After: value})
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			changes = append(changes, Change{Field: field, Before: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// AttributeWrites summarises the attribute values of a request, sorted by name
+func AttributeWrites(attributes map[string]*pb.TimeBasedValueList) []AttributeWrite {
+	var writes []AttributeWrite
+	for name, values := range attributes {
+		writes = append(writes, AttributeWrite{Name: name, Values: len(values.GetValues())})
+	}
+	sort.Slice(writes, func(i, j int) bool { return writes[i].Name < writes[j].Name })
+	return writes
+}
+
+// Describe returns a readable form of a value: the text of a string and JSON otherwise
+func Describe(value *anypb.Any) string {
+	if value == nil {
+		return ""
+	}
+	message, err := value.UnmarshalNew()
+	if err != nil {
+		return value.TypeUrl
+	}
+	if text, ok := message.(*wrapperspb.StringValue); ok {
+		return text.Value
+	}
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return value.TypeUrl
+	}
+	return string(data)
+}
+
+// describeRelationship returns a relationship like "HAS_MINISTER -> e2 [2020-01-01T00:00:00Z, 2024-01-01T00:00:00Z)"
+func describeRelationship(relationship *pb.Relationship) string {
+	arrow := "->"
+	if relationship.Direction == "INCOMING" {
+		arrow = "<-"
+	}
+	return fmt.Sprintf("%s %s %s [%s, %s)", relationship.Name, arrow, relationship.RelatedEntityId, relationship.StartTime, relationship.EndTime)
+}
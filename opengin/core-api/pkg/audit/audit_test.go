@@ -0,0 +1,108 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// memoryStore is a Store in memory
+type memoryStore struct {
+	records []*Record
+}
+
+func (s *memoryStore) Append(ctx context.Context, record *Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memoryStore) Find(ctx context.Context, query Query) ([]*Record, error) {
+	return s.records, nil
+}
+
+func value(t *testing.T, text string) *anypb.Any {
+	v, err := anypb.New(wrapperspb.String(text))
+	require.NoError(t, err)
+	return v
+}
+
+func TestDiff(t *testing.T) {
+	before := SnapshotOf(&pb.Entity{
+		Id:       "minister-1",
+		Kind:     &pb.Kind{Major: "Person", Minor: "Minister"},
+		Name:     &pb.TimeBasedValue{Value: value(t, "A. Perera")},
+		Created:  "2020-01-01T00:00:00Z",
+		Metadata: map[string]*anypb.Any{"party": value(t, "X"), "seat": value(t, "Colombo")},
+		Relationships: map[string]*pb.Relationship{
+			"r1": {Id: "r1", Name: "HAS_PORTFOLIO", RelatedEntityId: "health", StartTime: "2020-01-01T00:00:00Z", Direction: "OUTGOING"},
+		},
+	})
+	after := SnapshotOf(&pb.Entity{
+		Id:       "minister-1",
+		Kind:     &pb.Kind{Major: "Person", Minor: "Minister"},
+		Name:     &pb.TimeBasedValue{Value: value(t, "A. B. Perera")},
+		Created:  "2020-01-01T00:00:00Z",
+		Metadata: map[string]*anypb.Any{"party": value(t, "X")},
+		Relationships: map[string]*pb.Relationship{
+			"r1": {Id: "r1", Name: "HAS_PORTFOLIO", RelatedEntityId: "health", StartTime: "2020-01-01T00:00:00Z", EndTime: "2024-01-01T00:00:00Z", Direction: "OUTGOING"},
+		},
+	})
+
+	assert.Equal(t, []Change{
+		{Field: "metadata.seat", Before: "Colombo"},
+		{Field: "name", Before: "A. Perera", After: "A. B. Perera"},
+		{Field: "relationships.r1", Before: "HAS_PORTFOLIO -> health [2020-01-01T00:00:00Z, )", After: "HAS_PORTFOLIO -> health [2020-01-01T00:00:00Z, 2024-01-01T00:00:00Z)"},
+	}, Diff(before, after))
+
+	created := Diff(nil, after)
+	assert.Len(t, created, 5, "Expected every field of a created entity")
+	for _, change := range created {
+		assert.Empty(t, change.Before)
+	}
+	assert.Empty(t, Diff(after, after))
+}
+
+func TestAppendAttributesCaller(t *testing.T) {
+	store := &memoryStore{}
+	log := New(store)
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Subject: "loader", Method: "api-key", Roles: []auth.Role{auth.RoleWriter}})
+
+	writes := AttributeWrites(map[string]*pb.TimeBasedValueList{"budget": {Values: []*pb.TimeBasedValue{{}, {}}}})
+	assert.NoError(t, log.Append(ctx, "minister-1", []Change{{Field: "name", After: "A. Perera"}}, writes))
+	assert.NoError(t, log.Append(ctx, "minister-1", nil, nil))
+
+	require.Len(t, store.records, 1, "Expected a write that changed nothing not to be recorded")
+	record := store.records[0]
+	assert.NotEmpty(t, record.ID)
+	assert.False(t, record.Time.IsZero())
+	assert.Equal(t, "loader", record.Actor)
+	assert.Equal(t, "api-key", record.AuthMethod)
+	assert.Equal(t, "minister-1", record.EntityID)
+	assert.Equal(t, []AttributeWrite{{Name: "budget", Values: 2}}, record.Attributes)
+
+	var disabled *Log
+	assert.NoError(t, disabled.Append(ctx, "minister-1", []Change{{Field: "name"}}, nil))
+}
+
+func TestDescribe(t *testing.T) {
+	structValue, err := structpb.NewStruct(map[string]interface{}{"seats": 225})
+	require.NoError(t, err)
+	number, err := anypb.New(structValue)
+	require.NoError(t, err)
+
+	assert.Equal(t, "A. Perera", Describe(value(t, "A. Perera")))
+	assert.JSONEq(t, `{"seats": 225}`, Describe(number))
+	assert.Equal(t, "type.googleapis.com/unknown.Type", Describe(&anypb.Any{TypeUrl: "type.googleapis.com/unknown.Type"}))
+	assert.Empty(t, Describe(nil))
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package auth authenticates gRPC callers and authorizes them by role.
+//
+// Callers send either an API key in the x-api-key metadata or a signed JWT in the authorization
+// metadata as "Bearer <token>". API keys are listed, as SHA-256 hashes, in a YAML file together with
+// their roles. JWTs are verified with an HMAC secret or an RSA public key read from files, and carry
+// their roles in the "roles" claim. The identity of the caller is stored in the request context,
+// see FromContext.
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Role grants permission to call a set of methods, see Policy
+type Role string
+
+const (
+	// RoleReader may read entities
+	RoleReader Role = "reader"
+	// RoleWriter may create, update and delete entities, and read them
+	RoleWriter Role = "writer"
+	// RoleAdmin may operate the server, such as replaying failed webhook deliveries
+	RoleAdmin Role = "admin"
+	// RoleAuditor may read the audit log
+	RoleAuditor Role = "auditor"
+)
+
+// Identity is an authenticated caller
+type Identity struct {
+	Subject string // Name of the API key or subject of the JWT
+	Roles   []Role
+	Method  string // How the caller authenticated, "api-key" or "jwt"
+}
+
+// HasRole reports whether the identity has one of the roles
+func (id *Identity) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if slices.Contains(id.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context that carries the identity
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity of the caller, if the request was authenticated
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(*Identity)
+	return id, ok
+}
+
+// Policy decides which callers may call which methods. Methods are full gRPC method names
+// like "/core.COREService/ReadEntity"; methods that are neither public nor listed are denied.
+type Policy struct {
+	// Public lists the methods, or services when ending with "/", that need no credentials
+	Public []string
+	// Roles lists the roles allowed to call each method
+	Roles map[string][]Role
+}
+
+// IsPublic reports whether the method can be called without credentials
+func (p Policy) IsPublic(method string) bool {
+	for _, public := range p.Public {
+		if method == public || (strings.HasSuffix(public, "/") && strings.HasPrefix(method, public)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the identity may call the method
+func (p Policy) Allows(id *Identity, method string) bool {
+	roles, ok := p.Roles[method]
+	return ok && id.HasRole(roles...)
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testAPIKey     = "dashboard-key"
+	testHMACSecret = "0123456789abcdef0123456789abcdef"
+)
+
+var testPolicy = Policy{
+	Public: []string{"/grpc.health.v1.Health/"},
+	Roles: map[string][]Role{
+		"/core.COREService/ReadEntity":   {RoleReader, RoleWriter},
+		"/core.COREService/CreateEntity": {RoleWriter},
+	},
+}
+
+// writeFile writes a file to a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// newTestAuthenticator accepts testAPIKey as a reader and HS256 tokens signed with testHMACSecret
+func newTestAuthenticator(t *testing.T) *Authenticator {
+	hash := sha256.Sum256([]byte(testAPIKey))
+	keys := "keys:\n  - name: dashboard\n    sha256: " + hex.EncodeToString(hash[:]) + "\n    roles: [reader]\n"
+	a, err := NewAuthenticator(Config{
+		APIKeysFile:       writeFile(t, "keys.yaml", keys),
+		JWTHMACSecretFile: writeFile(t, "secret", testHMACSecret+"\n"),
+		JWTIssuer:         "ingestion",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+	return a
+}
+
+// signedToken returns a JWT for the subject with the roles
+func signedToken(t *testing.T, method jwt.SigningMethod, key interface{}, subject string, expiresIn time.Duration, roles ...Role) string {
+	token, err := jwt.NewWithClaims(method, jwtClaims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    "ingestion",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		},
+	}).SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func incoming(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	id, err := a.Authenticate(incoming(APIKeyHeader, testAPIKey))
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard", id.Subject)
+	assert.Equal(t, []Role{RoleReader}, id.Roles)
+	assert.Equal(t, "api-key", id.Method)
+
+	_, err = a.Authenticate(incoming(APIKeyHeader, "wrong-key"))
+	assert.ErrorContains(t, err, "invalid API key")
+
+	_, err = a.Authenticate(context.Background())
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestAuthenticateHMACToken(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	token := signedToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "loader", time.Minute, RoleWriter)
+	id, err := a.Authenticate(incoming(AuthorizationHeader, "Bearer "+token))
+	assert.NoError(t, err)
+	assert.Equal(t, "loader", id.Subject)
+	assert.Equal(t, []Role{RoleWriter}, id.Roles)
+	assert.Equal(t, "jwt", id.Method)
+
+	expired := signedToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), "loader", -time.Minute, RoleWriter)
+	_, err = a.Authenticate(incoming(AuthorizationHeader, "Bearer "+expired))
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	forged := signedToken(t, jwt.SigningMethodHS256, []byte("another-secret-of-at-least-32-bytes"), "loader", time.Minute, RoleWriter)
+	_, err = a.Authenticate(incoming(AuthorizationHeader, "Bearer "+forged))
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+
+	_, err = a.Authenticate(incoming(AuthorizationHeader, "Basic dXNlcjpwYXNz"))
+	assert.ErrorContains(t, err, "bearer token")
+}
+
+func TestAuthenticateRSAToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+	keyFile := writeFile(t, "public.pem", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})))
+
+	a, err := NewAuthenticator(Config{JWTRSAPublicKeyFile: keyFile})
+	assert.NoError(t, err)
+
+	token := signedToken(t, jwt.SigningMethodRS256, privateKey, "loader", time.Minute, RoleWriter)
+	id, err := a.Authenticate(incoming(AuthorizationHeader, "Bearer "+token))
+	assert.NoError(t, err)
+	assert.Equal(t, "loader", id.Subject)
+
+	// A token signed with the public key as HMAC secret must not be accepted
+	hmacToken := signedToken(t, jwt.SigningMethodHS256, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), "loader", time.Minute, RoleWriter)
+	_, err = a.Authenticate(incoming(AuthorizationHeader, "Bearer "+hmacToken))
+	assert.Error(t, err)
+}
+
+func TestNewAuthenticatorRejectsBadFiles(t *testing.T) {
+	_, err := NewAuthenticator(Config{})
+	assert.ErrorContains(t, err, "no API keys or JWT keys configured")
+
+	_, err = NewAuthenticator(Config{JWTHMACSecretFile: writeFile(t, "secret", "short")})
+	assert.ErrorContains(t, err, "at least 32 bytes")
+
+	_, err = NewAuthenticator(Config{APIKeysFile: writeFile(t, "keys.yaml", "keys:\n  - name: dashboard\n    sha256: abc\n    roles: [reader]\n")})
+	assert.ErrorContains(t, err, "hex encoded SHA-256 hash")
+}
+
+func TestPolicy(t *testing.T) {
+	reader := &Identity{Subject: "dashboard", Roles: []Role{RoleReader}}
+	writer := &Identity{Subject: "loader", Roles: []Role{RoleWriter}}
+
+	assert.True(t, testPolicy.IsPublic("/grpc.health.v1.Health/Check"))
+	assert.False(t, testPolicy.IsPublic("/core.COREService/ReadEntity"))
+	assert.True(t, testPolicy.Allows(reader, "/core.COREService/ReadEntity"))
+	assert.False(t, testPolicy.Allows(reader, "/core.COREService/CreateEntity"))
+	assert.True(t, testPolicy.Allows(writer, "/core.COREService/CreateEntity"))
+	assert.False(t, testPolicy.Allows(writer, "/core.COREService/Unlisted"), "Expected methods missing from the policy to be denied")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newTestAuthenticator(t), testPolicy)
+	var caller *Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = FromContext(ctx)
+		return "ok", nil
+	}
+	call := func(ctx context.Context, method string) error {
+		caller = nil
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	err := call(incoming(APIKeyHeader, testAPIKey), "/core.COREService/ReadEntity")
+	assert.NoError(t, err)
+	if assert.NotNil(t, caller, "Expected the identity in the handler's context") {
+		assert.Equal(t, "dashboard", caller.Subject)
+	}
+
+	err = call(incoming(APIKeyHeader, testAPIKey), "/core.COREService/CreateEntity")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Nil(t, caller, "Expected the handler not to run")
+
+	err = call(context.Background(), "/core.COREService/ReadEntity")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = call(context.Background(), "/grpc.health.v1.Health/Check")
+	assert.NoError(t, err, "Expected public methods to need no credentials")
+	assert.Nil(t, caller)
+}
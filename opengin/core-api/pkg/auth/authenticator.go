@@ -0,0 +1,191 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// APIKeyHeader is the metadata key of API keys
+	APIKeyHeader = "x-api-key"
+	// AuthorizationHeader is the metadata key of "Bearer <token>" JWTs
+	AuthorizationHeader = "authorization"
+)
+
+// ErrNoCredentials is returned when a request carries neither an API key nor a JWT
+var ErrNoCredentials = errors.New("missing credentials, send an x-api-key or a bearer token")
+
+// Config configures the authentication of callers. The files are read once, on startup.
+type Config struct {
+	Enabled             bool   `yaml:"enabled" env:"CORE_AUTH_ENABLED"`
+	APIKeysFile         string `yaml:"apiKeysFile" env:"CORE_AUTH_API_KEYS_FILE"`                   // YAML file listing the API keys, see APIKeysFile
+	JWTHMACSecretFile   string `yaml:"jwtHmacSecretFile" env:"CORE_AUTH_JWT_HMAC_SECRET_FILE"`      // Secret of HS256, HS384 and HS512 tokens
+	JWTRSAPublicKeyFile string `yaml:"jwtRsaPublicKeyFile" env:"CORE_AUTH_JWT_RSA_PUBLIC_KEY_FILE"` // PEM public key of RS256, RS384 and RS512 tokens
+	JWTIssuer           string `yaml:"jwtIssuer" env:"CORE_AUTH_JWT_ISSUER"`                        // Required "iss" claim, if set
+	JWTAudience         string `yaml:"jwtAudience" env:"CORE_AUTH_JWT_AUDIENCE"`                    // Required "aud" claim, if set
+}
+
+// APIKeysFile is the format of the API keys file. Only the SHA-256 hashes of the keys are stored,
+// e.g. from `printf %s "$KEY" | sha256sum`.
+type APIKeysFile struct {
+	Keys []struct {
+		Name   string `yaml:"name"`
+		SHA256 string `yaml:"sha256"`
+		Roles  []Role `yaml:"roles"`
+	} `yaml:"keys"`
+}
+
+// Authenticator verifies the credentials of callers
+type Authenticator struct {
+	apiKeys    map[[sha256.Size]byte]*Identity
+	hmacSecret []byte
+	rsaKey     *rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+// jwtClaims are the claims read from a JWT
+type jwtClaims struct {
+	Roles []Role `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// NewAuthenticator reads the key files of the configuration
+func NewAuthenticator(cfg Config) (*Authenticator, error) {
+	a := &Authenticator{apiKeys: map[[sha256.Size]byte]*Identity{}}
+
+	if cfg.APIKeysFile != "" {
+		if err := a.loadAPIKeys(cfg.APIKeysFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var methods []string
+	if cfg.JWTHMACSecretFile != "" {
+		secret, err := os.ReadFile(cfg.JWTHMACSecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT HMAC secret: %w", err)
+		}
+		a.hmacSecret = bytes.TrimSpace(secret)
+		if len(a.hmacSecret) < 32 {
+			return nil, fmt.Errorf("JWT HMAC secret in %s must be at least 32 bytes", cfg.JWTHMACSecretFile)
+		}
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if cfg.JWTRSAPublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.JWTRSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT RSA public key: %w", err)
+		}
+		a.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing JWT RSA public key %s: %w", cfg.JWTRSAPublicKeyFile, err)
+		}
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+
+	if len(a.apiKeys) == 0 && len(methods) == 0 {
+		return nil, fmt.Errorf("no API keys or JWT keys configured, no caller could authenticate")
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if cfg.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWTAudience))
+	}
+	a.parser = jwt.NewParser(options...)
+	return a, nil
+}
+
+// loadAPIKeys reads the API keys file
+func (a *Authenticator) loadAPIKeys(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading API keys file: %w", err)
+	}
+	var file APIKeysFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return fmt.Errorf("error parsing API keys file %s: %w", path, err)
+	}
+
+	for i, key := range file.Keys {
+		if key.Name == "" {
+			return fmt.Errorf("API key %d in %s has no name", i+1, path)
+		}
+		hash, err := hex.DecodeString(key.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("API key %s in %s must have a hex encoded SHA-256 hash", key.Name, path)
+		}
+		if len(key.Roles) == 0 {
+			return fmt.Errorf("API key %s in %s has no roles", key.Name, path)
+		}
+		a.apiKeys[[sha256.Size]byte(hash)] = &Identity{Subject: key.Name, Roles: key.Roles, Method: "api-key"}
+	}
+	return nil
+}
+
+// Authenticate returns the identity of the caller from the request metadata
+func (a *Authenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(APIKeyHeader); len(keys) > 0 {
+		// Keys are looked up by hash, so the lookup does not leak how much of a key matched
+		id, ok := a.apiKeys[sha256.Sum256([]byte(keys[0]))]
+		if !ok {
+			return nil, fmt.Errorf("invalid API key")
+		}
+		return id, nil
+	}
+
+	if values := md.Get(AuthorizationHeader); len(values) > 0 {
+		token, found := strings.CutPrefix(values[0], "Bearer ")
+		if !found {
+			return nil, fmt.Errorf("authorization must be a bearer token")
+		}
+		return a.verifyJWT(strings.TrimSpace(token))
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// verifyJWT checks the signature and the claims of a JWT
+func (a *Authenticator) verifyJWT(token string) (*Identity, error) {
+	claims := &jwtClaims{}
+	_, err := a.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if a.hmacSecret != nil {
+				return a.hmacSecret, nil
+			}
+		case *jwt.SigningMethodRSA:
+			if a.rsaKey != nil {
+				return a.rsaKey, nil
+			}
+		}
+		return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid token: missing subject")
+	}
+	return &Identity{Subject: claims.Subject, Roles: claims.Roles, Method: "jwt"}, nil
+}
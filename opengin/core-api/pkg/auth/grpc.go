@@ -0,0 +1,66 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+
+	"lk/datafoundation/core-api/pkg/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor rejects unary calls the policy does not allow and stores the identity
+// of the caller in the context of the others
+func UnaryServerInterceptor(a *Authenticator, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorize(ctx, a, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of streaming calls
+func StreamServerInterceptor(a *Authenticator, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), a, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize authenticates the caller and checks the policy, returning Unauthenticated
+// or PermissionDenied statuses
+func authorize(ctx context.Context, a *Authenticator, policy Policy, method string) (context.Context, error) {
+	if policy.IsPublic(method) {
+		return ctx, nil
+	}
+
+	id, err := a.Authenticate(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Rejected unauthenticated call", "method", method, "error", err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !policy.Allows(id, method) {
+		logging.FromContext(ctx).Warn("Denied call", "method", method, "subject", id.Subject, "roles", id.Roles)
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", id.Subject, method)
+	}
+	return NewContext(ctx, id), nil
+}
+
+// serverStream replaces the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
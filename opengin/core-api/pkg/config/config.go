@@ -0,0 +1,395 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package config loads the configuration of the core API.
+//
+// The configuration starts from the defaults, is then read from an optional YAML file and finally
+// overridden by environment variables, so that the variables of existing deployments keep working.
+// Every setting that can be overridden names its variable in an env tag.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	dbconfig "lk/datafoundation/core-api/db/config"
+	"lk/datafoundation/core-api/pkg/auth"
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/outbox"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the core API
+type Config struct {
+	Server      ServerConfig            `yaml:"server"`
+	Neo4j       dbconfig.Neo4jConfig    `yaml:"neo4j"`
+	Mongo       dbconfig.MongoConfig    `yaml:"mongo"`
+	Postgres    dbconfig.PostgresConfig `yaml:"postgres"`
+	Health      HealthConfig            `yaml:"health"`
+	Metrics     MetricsConfig           `yaml:"metrics"`
+	Auth        auth.Config             `yaml:"auth"`
+	Tracing     tracing.Config          `yaml:"tracing"`
+	Logging     logging.Config          `yaml:"logging"`
+	Idempotency IdempotencyConfig       `yaml:"idempotency"`
+	Watch       WatchConfig             `yaml:"watch"`
+	Webhooks    outbox.Config           `yaml:"webhooks"`
+	Features    FeatureConfig           `yaml:"features"`
+}
+
+// ServerConfig configures the gRPC server
+type ServerConfig struct {
+	Host            string        `yaml:"host" env:"CORE_SERVICE_HOST"`
+	Port            string        `yaml:"port" env:"CORE_SERVICE_PORT"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"` // How long running RPCs may take to finish after SIGTERM
+}
+
+// HealthConfig configures the health checks of the databases
+type HealthConfig struct {
+	Interval time.Duration `yaml:"interval" env:"HEALTH_CHECK_INTERVAL"`
+	Timeout  time.Duration `yaml:"timeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+// MetricsConfig configures the HTTP server of the Prometheus metrics
+type MetricsConfig struct {
+	Address string `yaml:"address" env:"METRICS_ADDRESS"` // Serves /metrics, e.g. ":9090"
+}
+
+// IdempotencyConfig configures the idempotency keys of CreateEntity and UpdateEntity
+type IdempotencyConfig struct {
+	Window time.Duration `yaml:"window" env:"IDEMPOTENCY_WINDOW"` // How long a response is kept for the retries of a call
+}
+
+// WatchConfig configures the change events of WatchEntities
+type WatchConfig struct {
+	BufferSize int `yaml:"bufferSize" env:"WATCH_BUFFER_SIZE"` // Number of recent events kept for watchers resuming after a disconnect
+}
+
+// FeatureConfig turns optional features on or off
+type FeatureConfig struct {
+	Reflection   bool `yaml:"reflection" env:"CORE_REFLECTION_ENABLED"`      // Register the gRPC reflection service
+	HealthChecks bool `yaml:"healthChecks" env:"CORE_HEALTH_CHECKS_ENABLED"` // Register the gRPC health service
+	Metrics      bool `yaml:"metrics" env:"CORE_METRICS_ENABLED"`            // Serve the Prometheus metrics
+	Idempotency  bool `yaml:"idempotency" env:"CORE_IDEMPOTENCY_ENABLED"`    // Honour the idempotency keys of CreateEntity and UpdateEntity
+	Webhooks     bool `yaml:"webhooks" env:"CORE_WEBHOOKS_ENABLED"`          // Deliver the changes of entities to the webhook endpoints
+	Audit        bool `yaml:"audit" env:"CORE_AUDIT_ENABLED"`                // Append every write to the audit log
+}
+
+// Default returns the configuration used for settings that are neither in the file nor in the environment
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Host:            "0.0.0.0",
+			Port:            "50051",
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Postgres: dbconfig.PostgresConfig{
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		Health: HealthConfig{
+			Interval: 10 * time.Second,
+			Timeout:  3 * time.Second,
+		},
+		Metrics: MetricsConfig{
+			Address: ":9090",
+		},
+		Tracing: tracing.Config{
+			Exporter:    tracing.ExporterNone,
+			ServiceName: "core-api",
+			SampleRatio: 1,
+		},
+		Logging: logging.Config{
+			Level:  "info",
+			Format: logging.FormatJSON,
+		},
+		Idempotency: IdempotencyConfig{
+			Window: 24 * time.Hour,
+		},
+		Watch: WatchConfig{
+			BufferSize: 10000,
+		},
+		Webhooks: outbox.Config{
+			Workers:        4,
+			Timeout:        10 * time.Second,
+			MaxAttempts:    10,
+			InitialBackoff: 10 * time.Second,
+			MaxBackoff:     time.Hour,
+			PollInterval:   time.Second,
+			Retention:      7 * 24 * time.Hour,
+		},
+		Features: FeatureConfig{
+			Reflection:   true,
+			HealthChecks: true,
+			Metrics:      true,
+			Idempotency:  true,
+			Audit:        true,
+		},
+	}
+}
+
+// Load reads the configuration from the YAML file at path, if path is not empty,
+// applies the environment variables and validates the result
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true) // A misspelt setting is an error rather than silently ignored
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides the fields with an env tag whose variable is set
+func applyEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := v.Type().Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+
+		switch {
+		case field.Type() == reflect.TypeOf(time.Duration(0)):
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q, expected a duration like 10s", name, value)
+			}
+			field.SetInt(int64(duration))
+		case field.Kind() == reflect.String:
+			field.SetString(value)
+		case field.Kind() == reflect.Int:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q, expected an integer", name, value)
+			}
+			field.SetInt(int64(number))
+		case field.Kind() == reflect.Uint64:
+			number, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q, expected a non-negative integer", name, value)
+			}
+			field.SetUint(number)
+		case field.Kind() == reflect.Float64:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q, expected a number", name, value)
+			}
+			field.SetFloat(number)
+		case field.Kind() == reflect.Bool:
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q, expected true or false", name, value)
+			}
+			field.SetBool(enabled)
+		default:
+			return fmt.Errorf("unsupported type %s for %s", field.Type(), name)
+		}
+	}
+	return nil
+}
+
+// Validate checks the configuration and reports every problem at once
+func (c *Config) Validate() error {
+	var problems []string
+	require := func(value, setting, env string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, fmt.Sprintf("%s is required (set it in the config file or %s)", setting, env))
+		}
+	}
+	positive := func(value time.Duration, setting string) {
+		if value <= 0 {
+			problems = append(problems, fmt.Sprintf("%s must be a positive duration, got %v", setting, value))
+		}
+	}
+	notNegative := func(value int64, setting string) {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative, got %d", setting, value))
+		}
+	}
+	notNegativeDuration := func(value time.Duration, setting string) {
+		if value < 0 {
+			problems = append(problems, fmt.Sprintf("%s must not be negative, got %v", setting, value))
+		}
+	}
+
+	// Server
+	require(c.Server.Host, "server.host", "CORE_SERVICE_HOST")
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("server.port must be a port number between 1 and 65535, got %q", c.Server.Port))
+	}
+	positive(c.Server.ShutdownTimeout, "server.shutdownTimeout")
+
+	// Neo4j
+	require(c.Neo4j.URI, "neo4j.uri", "NEO4J_URI")
+	if c.Neo4j.URI != "" && !hasScheme(c.Neo4j.URI, "neo4j", "neo4j+s", "neo4j+ssc", "bolt", "bolt+s", "bolt+ssc") {
+		problems = append(problems, fmt.Sprintf("neo4j.uri %q must start with neo4j:// or bolt://", c.Neo4j.URI))
+	}
+	require(c.Neo4j.Username, "neo4j.username", "NEO4J_USER")
+	notNegative(int64(c.Neo4j.MaxConnectionPoolSize), "neo4j.maxConnectionPoolSize")
+	notNegativeDuration(c.Neo4j.ConnectionAcquisitionTimeout, "neo4j.connectionAcquisitionTimeout")
+	notNegativeDuration(c.Neo4j.ConnectTimeout, "neo4j.connectTimeout")
+
+	// MongoDB
+	require(c.Mongo.URI, "mongo.uri", "MONGO_URI")
+	if c.Mongo.URI != "" && !hasScheme(c.Mongo.URI, "mongodb", "mongodb+srv") {
+		problems = append(problems, "mongo.uri must start with mongodb:// or mongodb+srv://")
+	}
+	require(c.Mongo.DBName, "mongo.dbName", "MONGO_DB_NAME")
+	require(c.Mongo.Collection, "mongo.collection", "MONGO_COLLECTION")
+	if c.Mongo.MaxPoolSize > 0 && c.Mongo.MinPoolSize > c.Mongo.MaxPoolSize {
+		problems = append(problems, fmt.Sprintf("mongo.minPoolSize %d must not exceed mongo.maxPoolSize %d", c.Mongo.MinPoolSize, c.Mongo.MaxPoolSize))
+	}
+	notNegativeDuration(c.Mongo.ConnectTimeout, "mongo.connectTimeout")
+	notNegativeDuration(c.Mongo.ServerSelectionTimeout, "mongo.serverSelectionTimeout")
+
+	// PostgreSQL
+	require(c.Postgres.Host, "postgres.host", "POSTGRES_HOST")
+	if port, err := strconv.Atoi(c.Postgres.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("postgres.port must be a port number between 1 and 65535, got %q", c.Postgres.Port))
+	}
+	require(c.Postgres.User, "postgres.user", "POSTGRES_USER")
+	require(c.Postgres.DBName, "postgres.dbName", "POSTGRES_DB")
+	switch c.Postgres.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		problems = append(problems, fmt.Sprintf("postgres.sslMode must be one of disable, allow, prefer, require, verify-ca or verify-full, got %q", c.Postgres.SSLMode))
+	}
+	notNegative(int64(c.Postgres.MaxOpenConns), "postgres.maxOpenConns")
+	notNegative(int64(c.Postgres.MaxIdleConns), "postgres.maxIdleConns")
+	if c.Postgres.MaxOpenConns > 0 && c.Postgres.MaxIdleConns > c.Postgres.MaxOpenConns {
+		problems = append(problems, fmt.Sprintf("postgres.maxIdleConns %d must not exceed postgres.maxOpenConns %d", c.Postgres.MaxIdleConns, c.Postgres.MaxOpenConns))
+	}
+	notNegativeDuration(c.Postgres.ConnMaxLifetime, "postgres.connMaxLifetime")
+	notNegativeDuration(c.Postgres.ConnectTimeout, "postgres.connectTimeout")
+
+	// Health checks
+	if c.Features.HealthChecks {
+		positive(c.Health.Interval, "health.interval")
+		positive(c.Health.Timeout, "health.timeout")
+		if c.Health.Timeout > c.Health.Interval {
+			problems = append(problems, fmt.Sprintf("health.timeout %v must not exceed health.interval %v", c.Health.Timeout, c.Health.Interval))
+		}
+	}
+
+	// Metrics
+	if c.Features.Metrics {
+		if _, port, err := net.SplitHostPort(c.Metrics.Address); err != nil || port == "" {
+			problems = append(problems, fmt.Sprintf("metrics.address must be host:port or :port, got %q", c.Metrics.Address))
+		} else if port == c.Server.Port {
+			problems = append(problems, fmt.Sprintf("metrics.address must not use the gRPC port %s", port))
+		}
+	}
+
+	// Authentication
+	if c.Auth.Enabled && c.Auth.APIKeysFile == "" && c.Auth.JWTHMACSecretFile == "" && c.Auth.JWTRSAPublicKeyFile == "" {
+		problems = append(problems, "auth.enabled requires auth.apiKeysFile, auth.jwtHmacSecretFile or auth.jwtRsaPublicKeyFile")
+	}
+
+	// Tracing
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter must be one of none, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, fmt.Sprintf("tracing.sampleRatio must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+	if c.Tracing.Exporter != tracing.ExporterNone {
+		require(c.Tracing.ServiceName, "tracing.serviceName", "OTEL_SERVICE_NAME")
+	}
+
+	// Logging
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		problems = append(problems, fmt.Sprintf("logging.level must be one of debug, info, warn or error, got %q", c.Logging.Level))
+	}
+	switch c.Logging.Format {
+	case logging.FormatJSON, logging.FormatText:
+	default:
+		problems = append(problems, fmt.Sprintf("logging.format must be json or text, got %q", c.Logging.Format))
+	}
+
+	// Idempotency
+	if c.Features.Idempotency {
+		positive(c.Idempotency.Window, "idempotency.window")
+	}
+
+	// Watch
+	if c.Watch.BufferSize < 1 {
+		problems = append(problems, fmt.Sprintf("watch.bufferSize must be at least 1, got %d", c.Watch.BufferSize))
+	}
+
+	// Webhooks
+	if c.Features.Webhooks {
+		require(c.Webhooks.EndpointsFile, "webhooks.endpointsFile", "WEBHOOK_ENDPOINTS_FILE")
+		if c.Webhooks.Workers < 1 {
+			problems = append(problems, fmt.Sprintf("webhooks.workers must be at least 1, got %d", c.Webhooks.Workers))
+		}
+		if c.Webhooks.MaxAttempts < 1 {
+			problems = append(problems, fmt.Sprintf("webhooks.maxAttempts must be at least 1, got %d", c.Webhooks.MaxAttempts))
+		}
+		positive(c.Webhooks.Timeout, "webhooks.timeout")
+		positive(c.Webhooks.InitialBackoff, "webhooks.initialBackoff")
+		positive(c.Webhooks.MaxBackoff, "webhooks.maxBackoff")
+		positive(c.Webhooks.PollInterval, "webhooks.pollInterval")
+		positive(c.Webhooks.Retention, "webhooks.retention")
+		if c.Webhooks.InitialBackoff > c.Webhooks.MaxBackoff {
+			problems = append(problems, fmt.Sprintf("webhooks.initialBackoff %v must not exceed webhooks.maxBackoff %v", c.Webhooks.InitialBackoff, c.Webhooks.MaxBackoff))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// Address returns the address the server listens on
+func (c *Config) Address() string {
+	return c.Server.Host + ":" + c.Server.Port
+}
+
+// hasScheme reports whether the URI starts with one of the schemes followed by ://
+func hasScheme(uri string, schemes ...string) bool {
+	for _, scheme := range schemes {
+		if strings.HasPrefix(uri, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
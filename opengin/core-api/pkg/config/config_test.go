@@ -0,0 +1,295 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validYAML = `
+server:
+  port: "6000"
+  shutdownTimeout: 45s
+neo4j:
+  uri: bolt://localhost:7687
+  username: neo4j
+  password: secret
+  maxConnectionPoolSize: 50
+mongo:
+  uri: mongodb://localhost:27017
+  dbName: nexoan
+  collection: metadata
+  maxPoolSize: 20
+  minPoolSize: 2
+postgres:
+  host: localhost
+  user: postgres
+  dbName: nexoan
+  maxOpenConns: 10
+  maxIdleConns: 5
+features:
+  reflection: false
+`
+
+// writeConfig writes the YAML to a temporary file and returns its path
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+// clearEnv unsets the variables of the environment the tests run in, restoring them afterwards
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		"CORE_SERVICE_HOST", "CORE_SERVICE_PORT", "SHUTDOWN_TIMEOUT",
+		"NEO4J_URI", "NEO4J_USER", "NEO4J_PASSWORD",
+		"MONGO_URI", "MONGO_DB_NAME", "MONGO_COLLECTION",
+		"POSTGRES_HOST", "POSTGRES_PORT", "POSTGRES_USER", "POSTGRES_PASSWORD", "POSTGRES_DB", "POSTGRES_SSL_MODE",
+		"HEALTH_CHECK_INTERVAL", "HEALTH_CHECK_TIMEOUT",
+		"LOG_LEVEL", "LOG_FORMAT", "LOG_PAYLOADS",
+		"IDEMPOTENCY_WINDOW", "CORE_IDEMPOTENCY_ENABLED", "WATCH_BUFFER_SIZE",
+		"CORE_WEBHOOKS_ENABLED", "WEBHOOK_ENDPOINTS_FILE", "WEBHOOK_WORKERS", "WEBHOOK_TIMEOUT", "WEBHOOK_MAX_ATTEMPTS",
+		"WEBHOOK_INITIAL_BACKOFF", "WEBHOOK_MAX_BACKOFF", "WEBHOOK_POLL_INTERVAL", "WEBHOOK_RETENTION", "CORE_AUDIT_ENABLED",
+		"OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_SERVICE_NAME", "OTEL_TRACES_SAMPLER_ARG",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:6000", cfg.Address(), "Expected the default host with the port from the file")
+	assert.Equal(t, 45*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, "bolt://localhost:7687", cfg.Neo4j.URI)
+	assert.Equal(t, 50, cfg.Neo4j.MaxConnectionPoolSize)
+	assert.Equal(t, uint64(20), cfg.Mongo.MaxPoolSize)
+	assert.Equal(t, "5432", cfg.Postgres.Port, "Expected the default PostgreSQL port")
+	assert.Equal(t, "disable", cfg.Postgres.SSLMode, "Expected the default SSL mode")
+	assert.Equal(t, 10*time.Second, cfg.Health.Interval, "Expected the default health check interval")
+	assert.False(t, cfg.Features.Reflection)
+	assert.True(t, cfg.Features.HealthChecks)
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CORE_SERVICE_PORT", "7000")
+	t.Setenv("NEO4J_URI", "neo4j://graph:7687")
+	t.Setenv("MONGO_MAX_POOL_SIZE", "40")
+	t.Setenv("HEALTH_CHECK_INTERVAL", "1m")
+	t.Setenv("CORE_REFLECTION_ENABLED", "true")
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "7000", cfg.Server.Port)
+	assert.Equal(t, "neo4j://graph:7687", cfg.Neo4j.URI)
+	assert.Equal(t, uint64(40), cfg.Mongo.MaxPoolSize)
+	assert.Equal(t, time.Minute, cfg.Health.Interval)
+	assert.True(t, cfg.Features.Reflection)
+	assert.Equal(t, "neo4j", cfg.Neo4j.Username, "Expected settings without a variable to keep the file's value")
+}
+
+func TestLoadEnvOnly(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("NEO4J_URI", "bolt://localhost:7687")
+	t.Setenv("NEO4J_USER", "neo4j")
+	t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+	t.Setenv("MONGO_DB_NAME", "nexoan")
+	t.Setenv("MONGO_COLLECTION", "metadata")
+	t.Setenv("POSTGRES_HOST", "localhost")
+	t.Setenv("POSTGRES_USER", "postgres")
+	t.Setenv("POSTGRES_DB", "nexoan")
+
+	cfg, err := Load("")
+	assert.NoError(t, err, "Expected the environment alone to be a valid configuration")
+	assert.Equal(t, "0.0.0.0:50051", cfg.Address())
+}
+
+func TestLoadRejectsUnknownSetting(t *testing.T) {
+	clearEnv(t)
+
+	_, err := Load(writeConfig(t, "server:\n  prot: \"6000\"\n"))
+	assert.ErrorContains(t, err, "prot", "Expected the misspelt setting to be reported")
+}
+
+func TestLoadRejectsInvalidEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("SHUTDOWN_TIMEOUT", "30")
+
+	_, err := Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "invalid SHUTDOWN_TIMEOUT \"30\"")
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	clearEnv(t)
+
+	_, err := Load(writeConfig(t, `
+server:
+  port: "70000"
+neo4j:
+  uri: http://localhost:7474
+mongo:
+  uri: mongodb://localhost:27017
+  dbName: nexoan
+  collection: metadata
+  maxPoolSize: 2
+  minPoolSize: 5
+postgres:
+  host: localhost
+  user: postgres
+  dbName: nexoan
+  sslMode: sometimes
+health:
+  interval: 1s
+  timeout: 5s
+`))
+	assert.Error(t, err)
+	for _, problem := range []string{
+		"server.port must be a port number between 1 and 65535",
+		"neo4j.uri \"http://localhost:7474\" must start with neo4j:// or bolt://",
+		"neo4j.username is required",
+		"mongo.minPoolSize 5 must not exceed mongo.maxPoolSize 2",
+		"postgres.sslMode must be one of",
+		"health.timeout 5s must not exceed health.interval 1s",
+	} {
+		assert.ErrorContains(t, err, problem)
+	}
+}
+
+func TestValidateSkipsDisabledHealthChecks(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML+"\nhealth:\n  interval: 1s\n  timeout: 5s\n"))
+	assert.Error(t, err, "Expected the health check timeout to be validated while health checks are enabled")
+	assert.Nil(t, cfg)
+
+	cfg, err = Load(writeConfig(t, `
+neo4j:
+  uri: bolt://localhost:7687
+  username: neo4j
+mongo:
+  uri: mongodb://localhost:27017
+  dbName: nexoan
+  collection: metadata
+postgres:
+  host: localhost
+  user: postgres
+  dbName: nexoan
+health:
+  interval: 1s
+  timeout: 5s
+features:
+  healthChecks: false
+`))
+	assert.NoError(t, err)
+	assert.False(t, cfg.Features.HealthChecks)
+}
+
+func TestLoadTracing(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+
+	cfg, err := Load(writeConfig(t, validYAML+"tracing:\n  exporter: otlp\n  endpoint: collector:4317\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "otlp", cfg.Tracing.Exporter)
+	assert.Equal(t, "collector:4317", cfg.Tracing.Endpoint)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+	assert.Equal(t, "core-api", cfg.Tracing.ServiceName, "Expected the default service name")
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "jaeger")
+	t.Setenv("OTEL_TRACES_SAMPLER_ARG", "2")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "tracing.exporter must be one of none, stdout or otlp, got \"jaeger\"")
+	assert.ErrorContains(t, err, "tracing.sampleRatio must be between 0 and 1, got 2")
+}
+
+func TestLoadLogging(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_PAYLOADS", "true")
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+	assert.Equal(t, "json", cfg.Logging.Format, "Expected JSON logs by default")
+	assert.True(t, cfg.Logging.LogPayloads)
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("LOG_FORMAT", "xml")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "logging.level must be one of debug, info, warn or error, got \"verbose\"")
+	assert.ErrorContains(t, err, "logging.format must be json or text, got \"xml\"")
+}
+
+func TestLoadIdempotency(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.True(t, cfg.Features.Idempotency, "Expected idempotency keys to be honoured by default")
+	assert.Equal(t, 24*time.Hour, cfg.Idempotency.Window)
+
+	t.Setenv("IDEMPOTENCY_WINDOW", "-1h")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "idempotency.window must be a positive duration, got -1h0m0s")
+
+	t.Setenv("CORE_IDEMPOTENCY_ENABLED", "false")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.NoError(t, err, "Expected the window to be ignored when idempotency is disabled")
+}
+
+func TestLoadWatch(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, 10000, cfg.Watch.BufferSize)
+
+	t.Setenv("WATCH_BUFFER_SIZE", "0")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "watch.bufferSize must be at least 1, got 0")
+}
+
+func TestLoadWebhooks(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.False(t, cfg.Features.Webhooks, "Expected webhooks to be disabled by default")
+	assert.Equal(t, 10, cfg.Webhooks.MaxAttempts)
+
+	t.Setenv("CORE_WEBHOOKS_ENABLED", "true")
+	t.Setenv("WEBHOOK_INITIAL_BACKOFF", "2h")
+	_, err = Load(writeConfig(t, validYAML))
+	assert.ErrorContains(t, err, "webhooks.endpointsFile is required (set it in the config file or WEBHOOK_ENDPOINTS_FILE)")
+	assert.ErrorContains(t, err, "webhooks.initialBackoff 2h0m0s must not exceed webhooks.maxBackoff 1h0m0s")
+
+	t.Setenv("WEBHOOK_ENDPOINTS_FILE", "/etc/core/webhooks.yaml")
+	t.Setenv("WEBHOOK_INITIAL_BACKOFF", "")
+	cfg, err = Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.Equal(t, "/etc/core/webhooks.yaml", cfg.Webhooks.EndpointsFile)
+}
+
+func TestLoadAudit(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.True(t, cfg.Features.Audit, "Expected writes to be audited by default")
+
+	t.Setenv("CORE_AUDIT_ENABLED", "false")
+	cfg, err = Load(writeConfig(t, validYAML))
+	assert.NoError(t, err)
+	assert.False(t, cfg.Features.Audit)
+}
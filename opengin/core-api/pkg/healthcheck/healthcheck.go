@@ -0,0 +1,149 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package healthcheck implements the gRPC health service on top of periodic connectivity probes.
+//
+// Every dependency is reported as its own service, e.g. "neo4j". The ReadinessService, and any other
+// service passed to New, reports NOT_SERVING while a required dependency is down, so an orchestrator
+// can stop routing requests to the server without restarting it. The empty service name reports
+// liveness and stays SERVING until Shutdown.
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/logging"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ReadinessService is the health service name that reports whether every required dependency is up
+const ReadinessService = "readiness"
+
+// Probe checks a dependency and returns an error when it cannot be reached
+type Probe func(ctx context.Context) error
+
+type dependency struct {
+	name     string
+	required bool
+	probe    Probe
+	up       bool
+	checked  bool
+}
+
+// Checker runs the probes and publishes their results through a gRPC health server
+type Checker struct {
+	server            *health.Server
+	interval          time.Duration
+	timeout           time.Duration
+	readinessServices []string
+
+	mu           sync.Mutex
+	dependencies []*dependency
+}
+
+// New creates a checker that probes every interval, giving each probe at most timeout.
+// The services report readiness in addition to ReadinessService.
+func New(interval, timeout time.Duration, services ...string) *Checker {
+	c := &Checker{
+		server:            health.NewServer(),
+		interval:          interval,
+		timeout:           timeout,
+		readinessServices: append([]string{ReadinessService}, services...),
+	}
+	// Not ready until the dependencies have been checked
+	for _, service := range c.readinessServices {
+		c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return c
+}
+
+// Add registers a dependency. A dependency that is not required is reported on its own
+// but does not affect readiness.
+func (c *Checker) Add(name string, required bool, probe Probe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dependencies = append(c.dependencies, &dependency{name: name, required: required, probe: probe})
+	c.server.SetServingStatus(name, healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+}
+
+// Server returns the health server to register with the gRPC server
+func (c *Checker) Server() *health.Server {
+	return c.server
+}
+
+// Check runs every probe once, concurrently, and updates the statuses
+func (c *Checker) Check(ctx context.Context) {
+	c.mu.Lock()
+	dependencies := append([]*dependency(nil), c.dependencies...)
+	c.mu.Unlock()
+
+	results := make([]error, len(dependencies))
+	var wg sync.WaitGroup
+	for i, dep := range dependencies {
+		wg.Add(1)
+		go func(i int, probe Probe) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			results[i] = probe(probeCtx)
+		}(i, dep.probe)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		// The probes failed because the checker is stopping, not because the dependencies are down
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ready := true
+	for i, dep := range dependencies {
+		up := results[i] == nil
+		if !dep.checked || dep.up != up {
+			if up {
+				logging.FromContext(ctx).Info("Dependency is up", "dependency", dep.name)
+			} else {
+				logging.FromContext(ctx).Warn("Dependency is down", "dependency", dep.name, "error", results[i])
+			}
+		}
+		dep.up, dep.checked = up, true
+		c.server.SetServingStatus(dep.name, servingStatus(up))
+		if dep.required && !up {
+			ready = false
+		}
+	}
+	for _, service := range c.readinessServices {
+		c.server.SetServingStatus(service, servingStatus(ready))
+	}
+}
+
+// Run checks the dependencies every interval until the context is cancelled
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(ctx)
+		}
+	}
+}
+
+// Shutdown reports every service as NOT_SERVING and ignores later checks,
+// so that clients move away while the server drains
+func (c *Checker) Shutdown() {
+	c.server.Shutdown()
+}
+
+func servingStatus(up bool) healthpb.HealthCheckResponse_ServingStatus {
+	if up {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
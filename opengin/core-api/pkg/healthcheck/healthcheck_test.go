@@ -0,0 +1,69 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func servingStatusOf(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	assert.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestReadinessFollowsRequiredDependencies(t *testing.T) {
+	ctx := context.Background()
+	c := New(time.Minute, time.Second, "core.COREService")
+
+	var mongoErr error
+	c.Add("neo4j", true, func(ctx context.Context) error { return nil })
+	c.Add("mongodb", true, func(ctx context.Context) error { return mongoErr })
+	c.Add("cache", false, func(ctx context.Context) error { return errors.New("connection refused") })
+
+	// Not ready before the first check
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, ReadinessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, servingStatusOf(t, c, "neo4j"))
+
+	// An optional dependency being down does not affect readiness
+	c.Check(ctx)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(t, c, ReadinessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(t, c, "core.COREService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(t, c, "mongodb"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, "cache"))
+
+	mongoErr = errors.New("server selection timeout")
+	c.Check(ctx)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, ReadinessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, "core.COREService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, "mongodb"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(t, c, "neo4j"))
+
+	// Liveness does not depend on the stores
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatusOf(t, c, ""))
+
+	c.Shutdown()
+	mongoErr = nil
+	c.Check(ctx)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, ReadinessService))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, ""))
+}
+
+func TestProbeTimeout(t *testing.T) {
+	c := New(time.Minute, 10*time.Millisecond)
+	c.Add("postgres", true, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	c.Check(context.Background())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, "postgres"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatusOf(t, c, ReadinessService))
+}
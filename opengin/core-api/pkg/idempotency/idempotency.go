@@ -0,0 +1,192 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package idempotency makes retried calls safe.
+//
+// A caller sends an idempotency key in the idempotency-key metadata of a call. The first call
+// with a key runs and its response is stored for the configured window. A retry with the same key
+// and the same request gets the stored response back instead of running again, while a retry made
+// while the first call is still running fails with Aborted. Reusing a key for a different request
+// is an InvalidArgument error. Keys are scoped to the method and the authenticated caller.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+	"lk/datafoundation/core-api/pkg/auth"
+	"lk/datafoundation/core-api/pkg/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Metadata keys of the idempotency key and of the response header marking a stored response
+const (
+	KeyHeader      = "idempotency-key"
+	ReplayedHeader = "idempotency-replayed"
+)
+
+// maxKeyLength bounds the keys callers may send
+const maxKeyLength = 255
+
+// lease is how long a call holds its key. A retry after the lease runs again, for when
+// the server stopped before the first call finished.
+const lease = 5 * time.Minute
+
+// Record is a call made with an idempotency key
+type Record struct {
+	Key         string    // Hash of the method, the caller and the key
+	Fingerprint string    // Hash of the request
+	Response    []byte    // The response as a marshalled Any, once the call succeeded
+	Done        bool      // Whether the call succeeded
+	LockedUntil time.Time // End of the lease of a call that is running
+	ExpiresAt   time.Time // End of the window, after which the key can be reused
+}
+
+// Store keeps the records of the calls
+type Store interface {
+	// Begin stores the record of a new call. If the key is taken by an unexpired record it returns
+	// that record instead, unless it is the expired lease of a call with the same fingerprint,
+	// which the new call takes over.
+	Begin(ctx context.Context, record *Record) (*Record, error)
+	// Complete stores the response of a call that succeeded
+	Complete(ctx context.Context, key string, response []byte) error
+	// Abandon removes the record of a call that failed, so that it can be retried
+	Abandon(ctx context.Context, key string) error
+}
+
+// UnaryServerInterceptor makes the calls to the methods, given as full method names, idempotent
+// when they carry an idempotency key. Responses are kept for the window.
+func UnaryServerInterceptor(store Store, window time.Duration, methods ...string) grpc.UnaryServerInterceptor {
+	idempotent := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		idempotent[method] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := keyOf(ctx)
+		if key == "" || !idempotent[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(key) > maxKeyLength {
+			return nil, apperrors.InvalidArgumentf("idempotency key must not be longer than %d characters", maxKeyLength).WithField(KeyHeader)
+		}
+
+		fingerprint, err := fingerprintOf(req)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		record := &Record{
+			Key:         scopedKey(ctx, info.FullMethod, key),
+			Fingerprint: fingerprint,
+			LockedUntil: now.Add(lease),
+			ExpiresAt:   now.Add(window),
+		}
+
+		existing, err := store.Begin(ctx, record)
+		if err != nil {
+			return nil, fmt.Errorf("error storing idempotency key: %w", err)
+		}
+		if existing != nil {
+			return replay(ctx, existing, fingerprint)
+		}
+
+		resp, err := handler(ctx, req)
+		// Store the outcome even if the caller went away, the retry is then answered from it
+		storeCtx := context.WithoutCancel(ctx)
+		if err != nil {
+			if abandonErr := store.Abandon(storeCtx, record.Key); abandonErr != nil {
+				logging.FromContext(ctx).Warn("Failed to release idempotency key", "error", abandonErr)
+			}
+			return resp, err
+		}
+		if err := complete(storeCtx, store, record.Key, resp); err != nil {
+			// The call succeeded, a retry will wait for the lease to expire and run again
+			logging.FromContext(ctx).Warn("Failed to store response of idempotent call", "error", err)
+		}
+		return resp, nil
+	}
+}
+
+// replay answers a retried call from the record of the first one
+func replay(ctx context.Context, existing *Record, fingerprint string) (interface{}, error) {
+	if existing.Fingerprint != fingerprint {
+		return nil, apperrors.InvalidArgumentf("idempotency key was already used for a different request").WithField(KeyHeader)
+	}
+	if !existing.Done {
+		return nil, apperrors.Abortedf("a call with the same idempotency key is in progress, retry later")
+	}
+
+	var response anypb.Any
+	if err := proto.Unmarshal(existing.Response, &response); err != nil {
+		return nil, fmt.Errorf("error reading stored response: %w", err)
+	}
+	resp, err := response.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("error reading stored response: %w", err)
+	}
+	logging.FromContext(ctx).Info("Replaying response of idempotent call")
+	grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true"))
+	return resp, nil
+}
+
+// complete stores the response of a call that succeeded
+func complete(ctx context.Context, store Store, key string, resp interface{}) error {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a protobuf message", resp)
+	}
+	response, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return store.Complete(ctx, key, data)
+}
+
+// keyOf returns the idempotency key of the call, if any
+func keyOf(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(KeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// scopedKey hashes the key with the method and the caller, so that callers cannot see each other's responses
+func scopedKey(ctx context.Context, method, key string) string {
+	subject := ""
+	if id, ok := auth.FromContext(ctx); ok {
+		subject = id.Subject
+	}
+	sum := sha256.Sum256([]byte(method + "\x00" + subject + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprintOf hashes the request
+func fingerprintOf(req interface{}) (string, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("request %T is not a protobuf message", req)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("error fingerprinting request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
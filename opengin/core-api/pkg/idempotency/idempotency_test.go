@@ -0,0 +1,157 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// memoryStore is a Store in memory
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]*Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]*Record{}}
+}
+
+func (s *memoryStore) Begin(ctx context.Context, record *Record) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if existing, ok := s.records[record.Key]; ok && existing.ExpiresAt.After(now) &&
+		(existing.Done || existing.Fingerprint != record.Fingerprint || existing.LockedUntil.After(now)) {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	s.records[record.Key] = &copied
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key].Done = true
+	s.records[key].Response = response
+	return nil
+}
+
+func (s *memoryStore) Abandon(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// serverTransportStream records the headers set by the interceptor
+type serverTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *serverTransportStream) Method() string { return "" }
+func (s *serverTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// call invokes the interceptor with the key, returning the response, the headers and the error
+func call(interceptor grpc.UnaryServerInterceptor, key string, req proto.Message, handler grpc.UnaryHandler) (interface{}, metadata.MD, error) {
+	stream := &serverTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(KeyHeader, key))
+	resp, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/core.COREService/CreateEntity"}, handler)
+	return resp, stream.header, err
+}
+
+func TestRetryIsAnsweredWithStoredResponse(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newMemoryStore(), time.Hour, "/core.COREService/CreateEntity")
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return structpb.NewStringValue("created"), nil
+	}
+
+	first, header, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), handler)
+	assert.NoError(t, err)
+	assert.Empty(t, header.Get(ReplayedHeader))
+
+	second, header, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls, "Expected the retry not to run the handler again")
+	assert.True(t, proto.Equal(first.(proto.Message), second.(proto.Message)))
+	assert.Equal(t, []string{"true"}, header.Get(ReplayedHeader))
+}
+
+func TestKeyReusedForDifferentRequest(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newMemoryStore(), time.Hour, "/core.COREService/CreateEntity")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	}
+
+	_, _, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), handler)
+	assert.NoError(t, err)
+	_, _, err = call(interceptor, "key-1", structpb.NewStringValue("entity-2"), handler)
+	assert.True(t, apperrors.Is(err, apperrors.InvalidArgument))
+}
+
+func TestRetryWhileInProgress(t *testing.T) {
+	store := newMemoryStore()
+	interceptor := UnaryServerInterceptor(store, time.Hour, "/core.COREService/CreateEntity")
+
+	var retryErr error
+	_, _, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, _, retryErr = call(interceptor, "key-1", structpb.NewStringValue("entity-1"), func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("Expected the retry not to run while the first call is in progress")
+			return nil, nil
+		})
+		return req, nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, apperrors.Is(retryErr, apperrors.Aborted))
+}
+
+func TestFailedCallCanBeRetried(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newMemoryStore(), time.Hour, "/core.COREService/CreateEntity")
+
+	_, _, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("neo4j unavailable")
+	})
+	assert.EqualError(t, err, "neo4j unavailable")
+
+	resp, _, err := call(interceptor, "key-1", structpb.NewStringValue("entity-1"), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	assert.NoError(t, err, "Expected the key of a failed call to be released")
+	assert.NotNil(t, resp)
+}
+
+func TestCallsWithoutKeyOrToOtherMethods(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newMemoryStore(), time.Hour, "/core.COREService/UpdateEntity")
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return req, nil
+	}
+
+	call(interceptor, "key-1", structpb.NewStringValue("entity-1"), handler)
+	call(interceptor, "key-1", structpb.NewStringValue("entity-1"), handler)
+	_, err := interceptor(context.Background(), structpb.NewStringValue("entity-1"), &grpc.UnaryServerInfo{FullMethod: "/core.COREService/UpdateEntity"}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls, "Expected only the calls with a key to the given methods to be replayed")
+}
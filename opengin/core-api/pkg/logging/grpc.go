@@ -0,0 +1,105 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key of the request id, sent back in the response headers
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds the request ids taken from callers, longer ones are replaced
+const maxRequestIDLength = 128
+
+// UnaryServerInterceptor stores a logger tagged with the request id of the call in its context
+// and logs the outcome of the call
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := withRequest(ctx, info.FullMethod)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequest(ss.Context(), info.FullMethod)
+		ss.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// withRequest tags the logger of the context with the request id, the method and the trace id
+func withRequest(ctx context.Context, method string) (context.Context, string) {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = uuid.NewString()
+	}
+
+	args := []any{slog.String("request_id", requestID), slog.String("method", method)}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		args = append(args, slog.String("trace_id", span.TraceID().String()))
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return With(ctx, args...), requestID
+}
+
+type requestIDKey struct{}
+
+// RequestID returns the request id of the call, or an empty string outside of a call
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// logCall logs the status code and duration of a call. Failed calls are warnings and
+// the probes of the health service are only logged at debug level.
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	level := slog.LevelInfo
+	switch {
+	case err != nil:
+		level = slog.LevelWarn
+	case strings.HasPrefix(method, "/grpc.health.v1.Health/"):
+		level = slog.LevelDebug
+	}
+	args := []any{slog.String("code", status.Code(err).String()), slog.Duration("duration", time.Since(start))}
+	if err != nil {
+		args = append(args, slog.Any("error", err))
+	}
+	FromContext(ctx).Log(ctx, level, "Call finished", args...)
+}
+
+// serverStream replaces the context of a stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
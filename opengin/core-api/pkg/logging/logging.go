@@ -0,0 +1,109 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logging sets up the structured logger of the core API.
+//
+// The logger is carried in the context: the gRPC interceptors store a logger tagged with the
+// request id of the call, taken from the x-request-id metadata or generated, and the trace id,
+// so that every line logged while serving a call can be found with its request id.
+// Attribute values and other data of the callers are logged through Payload, which redacts
+// them unless Config.LogPayloads is set.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// Formats of Config.Format
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config configures the logger
+type Config struct {
+	Level       string `yaml:"level" env:"LOG_LEVEL"`          // debug, info, warn or error
+	Format      string `yaml:"format" env:"LOG_FORMAT"`        // json or text
+	LogPayloads bool   `yaml:"logPayloads" env:"LOG_PAYLOADS"` // Log attribute values instead of redacting them
+}
+
+// logPayloads is Config.LogPayloads of the installed logger
+var logPayloads atomic.Bool
+
+// Setup installs the logger writing to w as the default logger, which the log package then writes to
+func Setup(cfg Config, w io.Writer) (*slog.Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+	logPayloads.Store(cfg.LogPayloads)
+	return logger, nil
+}
+
+// ParseLevel parses debug, info, warn or error, in any case. An empty level is info.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := l.UnmarshalText([]byte(strings.ToLower(level))); err != nil {
+		return l, fmt.Errorf("unknown log level %q", level)
+	}
+	return l, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the logger
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger of the context, or the default logger if it carries none
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a context whose logger adds the attributes to every line
+func With(ctx context.Context, args ...any) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
+
+// Payload logs data of the callers, such as an attribute value or a row of a table,
+// as "[redacted]" unless payloads are logged
+func Payload(key string, value any) slog.Attr {
+	return slog.Any(key, payload{value})
+}
+
+type payload struct {
+	value any
+}
+
+func (p payload) LogValue() slog.Value {
+	if !logPayloads.Load() {
+		return slog.StringValue("[redacted]")
+	}
+	return slog.StringValue(fmt.Sprintf("%+v", p.value))
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// setup installs a logger writing JSON to the returned buffer, restoring the default logger afterwards
+func setup(t *testing.T, cfg Config) *bytes.Buffer {
+	previous := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		logPayloads.Store(false)
+	})
+
+	var buf bytes.Buffer
+	_, err := Setup(cfg, &buf)
+	assert.NoError(t, err)
+	return &buf
+}
+
+// lines decodes the JSON lines that were logged
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestSetupFiltersLevels(t *testing.T) {
+	buf := setup(t, Config{Level: "WARN", Format: FormatJSON})
+
+	slog.Info("Not logged")
+	slog.Warn("Logged", "entity_id", "entity-1")
+
+	entries := lines(t, buf)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Logged", entries[0]["msg"])
+	assert.Equal(t, "entity-1", entries[0]["entity_id"])
+}
+
+func TestSetupRejectsUnknownLevelAndFormat(t *testing.T) {
+	_, err := Setup(Config{Level: "verbose"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "unknown log level \"verbose\"")
+
+	_, err = Setup(Config{Format: "xml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "unknown log format \"xml\"")
+}
+
+func TestPayloadIsRedactedByDefault(t *testing.T) {
+	buf := setup(t, Config{Level: "debug"})
+	slog.Debug("Value", Payload("value", map[string]string{"salary": "100"}))
+	assert.Equal(t, "[redacted]", lines(t, buf)[0]["value"])
+
+	buf = setup(t, Config{Level: "debug", LogPayloads: true})
+	slog.Debug("Value", Payload("value", map[string]string{"salary": "100"}))
+	assert.Equal(t, "map[salary:100]", lines(t, buf)[0]["value"])
+}
+
+func TestUnaryServerInterceptorTagsRequest(t *testing.T) {
+	buf := setup(t, Config{})
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.COREService/ReadEntity"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "req-42"))
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		FromContext(ctx).Info("Reading entity")
+		assert.Equal(t, "req-42", RequestID(ctx))
+		return nil, status.Error(codes.NotFound, "entity not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	entries := lines(t, buf)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "req-42", entry["request_id"], "Expected every line of the call to carry its request id")
+		assert.Equal(t, "/core.COREService/ReadEntity", entry["method"])
+	}
+	assert.Equal(t, "Call finished", entries[1]["msg"])
+	assert.Equal(t, "WARN", entries[1]["level"])
+	assert.Equal(t, "NotFound", entries[1]["code"])
+}
+
+func TestUnaryServerInterceptorGeneratesRequestID(t *testing.T) {
+	buf := setup(t, Config{})
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.COREService/ReadEntity"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, strings.Repeat("x", maxRequestIDLength+1)))
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	assert.NoError(t, err)
+
+	requestID, _ := lines(t, buf)[0]["request_id"].(string)
+	assert.Len(t, requestID, 36, "Expected an overlong request id to be replaced by a UUID")
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics collects the Prometheus metrics of the core API and serves them over HTTP.
+//
+// The metrics cover the RPCs, every operation on the backing stores, the attribute resolvers,
+// the connection pools of the stores and the webhook deliveries:
+//
+//	core_rpc_duration_seconds{method, code}
+//	core_store_operations_total{store, operation, result}
+//	core_store_operation_duration_seconds{store, operation}
+//	core_attribute_resolve_duration_seconds{storage_type, operation, result}
+//	core_store_pool_connections{store, state}
+//	core_webhook_deliveries_total{endpoint, result}
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Names of the backing stores, used as the store label
+const (
+	Neo4j    = "neo4j"
+	MongoDB  = "mongodb"
+	Postgres = "postgres"
+)
+
+// Registry holds the metrics of the core API, along with the Go runtime and process metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "core_rpc_duration_seconds",
+		Help:    "Duration of the gRPC calls by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	storeOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_store_operations_total",
+		Help: "Operations on the backing stores: Neo4j queries, MongoDB commands and PostgreSQL statements.",
+	}, []string{"store", "operation", "result"})
+
+	storeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "core_store_operation_duration_seconds",
+		Help:    "Duration of the operations on the backing stores.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"store", "operation"})
+
+	attributeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "core_attribute_resolve_duration_seconds",
+		Help:    "Duration of resolving an attribute by storage type and operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"storage_type", "operation", "result"})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "core_webhook_deliveries_total",
+		Help: "Attempts to deliver events to the webhook endpoints: delivered, retried or failed once dead-lettered.",
+	}, []string{"endpoint", "result"})
+
+	pools = &poolCollector{
+		desc: prometheus.NewDesc("core_store_pool_connections",
+			"Connections of the connection pools of the backing stores: in_use, idle and the configured max.",
+			[]string{"store", "state"}, nil),
+		stats: map[string]func() PoolStats{},
+	}
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcDuration, storeOperations, storeDuration, attributeDuration, webhookDeliveries, pools,
+	)
+}
+
+// ObserveStore records an operation on a store that started at start and failed if err is not nil
+func ObserveStore(store, operation string, start time.Time, err error) {
+	storeOperations.WithLabelValues(store, operation, result(err)).Inc()
+	storeDuration.WithLabelValues(store, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveAttribute records the resolution of an attribute that started at start
+func ObserveAttribute(storageType, operation string, start time.Time, err error) {
+	attributeDuration.WithLabelValues(storageType, operation, result(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveWebhookDelivery records an attempt to deliver an event to an endpoint
+func ObserveWebhookDelivery(endpoint, result string) {
+	webhookDeliveries.WithLabelValues(endpoint, result).Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// PoolStats are the connections of a connection pool. Negative values are unknown and not reported.
+type PoolStats struct {
+	InUse int
+	Idle  int
+	Max   int
+}
+
+// poolCollector reads the pool statistics of the stores when the metrics are scraped
+type poolCollector struct {
+	desc  *prometheus.Desc
+	mu    sync.Mutex
+	stats map[string]func() PoolStats
+}
+
+// RegisterPool reports the connection pool of a store. A later call for the same store replaces it.
+func RegisterPool(store string, stats func() PoolStats) {
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	pools.stats[store] = stats
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for store, stats := range c.stats {
+		s := stats()
+		for _, state := range []struct {
+			name  string
+			value int
+		}{{"in_use", s.InUse}, {"idle", s.Idle}, {"max", s.Max}} {
+			if state.value >= 0 {
+				ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(state.value), store, state.name)
+			}
+		}
+	}
+}
+
+// UnaryServerInterceptor records the duration and status code of unary calls
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		rpcDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the duration and status code of streaming calls
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		rpcDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
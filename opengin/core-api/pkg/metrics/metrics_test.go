@@ -0,0 +1,68 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestObserveStore(t *testing.T) {
+	before := testutil.ToFloat64(storeOperations.WithLabelValues(Neo4j, "ReadGraphEntity", "ok"))
+	beforeErrors := testutil.ToFloat64(storeOperations.WithLabelValues(Neo4j, "ReadGraphEntity", "error"))
+
+	ObserveStore(Neo4j, "ReadGraphEntity", time.Now(), nil)
+	ObserveStore(Neo4j, "ReadGraphEntity", time.Now(), nil)
+	ObserveStore(Neo4j, "ReadGraphEntity", time.Now(), errors.New("connection reset"))
+
+	assert.Equal(t, before+2, testutil.ToFloat64(storeOperations.WithLabelValues(Neo4j, "ReadGraphEntity", "ok")))
+	assert.Equal(t, beforeErrors+1, testutil.ToFloat64(storeOperations.WithLabelValues(Neo4j, "ReadGraphEntity", "error")))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.COREService/ReadEntity"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "entity not found")
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err), "Expected the error to be passed on")
+
+	assert.Contains(t, scrape(t), `core_rpc_duration_seconds_count{code="NotFound",method="/core.COREService/ReadEntity"} 1`)
+}
+
+func TestPoolCollector(t *testing.T) {
+	RegisterPool("test", func() PoolStats { return PoolStats{InUse: 3, Idle: -1, Max: 10} })
+	defer func() {
+		pools.mu.Lock()
+		delete(pools.stats, "test")
+		pools.mu.Unlock()
+	}()
+
+	body := scrape(t)
+	assert.Contains(t, body, `core_store_pool_connections{state="in_use",store="test"} 3`)
+	assert.Contains(t, body, `core_store_pool_connections{state="max",store="test"} 10`)
+	assert.NotContains(t, body, `core_store_pool_connections{state="idle",store="test"}`, "Expected unknown values not to be reported")
+}
+
+// scrape returns the metrics as served by Handler
+func scrape(t *testing.T) string {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), "go_goroutines"), "Expected the Go runtime metrics")
+	return string(body)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"lk/datafoundation/core-api/pkg/metrics"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Headers of a delivery
+const (
+	SignatureHeader = "X-Core-Signature" // "t=<unix seconds>,v1=<hex HMAC-SHA256>", see Sign
+	EventIDHeader   = "X-Core-Event-Id"
+	EventTypeHeader = "X-Core-Event-Type"
+)
+
+// Sign returns the signature header of a delivery: the HMAC-SHA256 with the secret of the endpoint of
+// the timestamp in unix seconds, a dot and the body. Receivers compute the same and reject old
+// timestamps, so that a captured delivery cannot be sent to them again later.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// delivery is the body POSTed to the endpoints
+type delivery struct {
+	ID    string          `json:"id"` // Id of the event, the same at every endpoint and on every attempt
+	Type  string          `json:"type"`
+	Event json.RawMessage `json:"event"`
+}
+
+// Dispatcher delivers the pending entries of the outbox to the endpoints
+type Dispatcher struct {
+	store     Store
+	endpoints map[string]*Endpoint
+	cfg       Config
+	client    *http.Client
+}
+
+// NewDispatcher creates a dispatcher for the endpoints
+func NewDispatcher(store Store, endpoints []*Endpoint, cfg Config) *Dispatcher {
+	byName := make(map[string]*Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+	return &Dispatcher{
+		store:     store,
+		endpoints: byName,
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// Run delivers the entries with the configured number of workers until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < max(d.cfg.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// work delivers due entries one after the other, and polls for new ones once none is due
+func (d *Dispatcher) work(ctx context.Context) {
+	for {
+		found, err := d.DeliverNext(ctx)
+		if err != nil {
+			slog.Error("Error dispatching webhook delivery", "error", err)
+		}
+		if found && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.cfg.PollInterval):
+		}
+	}
+}
+
+// DeliverNext makes an attempt to deliver the entry that has been due the longest and stores the outcome.
+// It reports whether an entry was due.
+func (d *Dispatcher) DeliverNext(ctx context.Context) (bool, error) {
+	// The lease outlasts the attempt, so that only an entry of a stopped dispatcher is claimed again
+	entry, err := d.store.Claim(ctx, time.Now(), d.cfg.Timeout+time.Minute)
+	if err != nil {
+		return false, fmt.Errorf("error claiming outbox entry: %w", err)
+	}
+	if entry == nil {
+		return false, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "Dispatcher.DeliverNext", attribute.String("core.webhook.endpoint", entry.Endpoint))
+	defer span.End()
+
+	endpoint, ok := d.endpoints[entry.Endpoint]
+	if ok {
+		err = d.post(ctx, endpoint, entry)
+	} else {
+		err = fmt.Errorf("endpoint %s is no longer configured", entry.Endpoint)
+	}
+	now := time.Now().UTC()
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// Stopping, the attempt does not count and the entry is due again on the next start
+		entry.NextAttempt = now
+	case err == nil:
+		entry.Attempts++
+		entry.Status = StatusDelivered
+		entry.DeliveredAt = now
+		entry.LastError = ""
+		metrics.ObserveWebhookDelivery(entry.Endpoint, "delivered")
+		slog.Debug("Delivered webhook", "endpoint", entry.Endpoint, "event_id", entry.EventID, "event_type", entry.EventType, "attempts", entry.Attempts)
+	case !ok || entry.Attempts+1 >= d.cfg.MaxAttempts:
+		entry.Attempts++
+		entry.Status = StatusFailed
+		entry.LastError = err.Error()
+		metrics.ObserveWebhookDelivery(entry.Endpoint, "failed")
+		slog.Error("Webhook delivery failed for good, dead-lettered", "endpoint", entry.Endpoint, "event_id", entry.EventID, "event_type", entry.EventType, "attempts", entry.Attempts, "error", err)
+	default:
+		entry.Attempts++
+		entry.NextAttempt = now.Add(d.backoff(entry.Attempts))
+		entry.LastError = err.Error()
+		metrics.ObserveWebhookDelivery(entry.Endpoint, "retried")
+		slog.Warn("Webhook delivery failed, retrying", "endpoint", entry.Endpoint, "event_id", entry.EventID, "attempts", entry.Attempts, "next_attempt", entry.NextAttempt, "error", err)
+	}
+
+	if err := d.store.Save(context.WithoutCancel(ctx), entry); err != nil {
+		return true, fmt.Errorf("error saving outbox entry %s: %w", entry.ID, err)
+	}
+	return true, nil
+}
+
+// post sends the entry to the endpoint and fails unless the endpoint answers with a 2xx status
+func (d *Dispatcher) post(ctx context.Context, endpoint *Endpoint, entry *Entry) error {
+	body, err := json.Marshal(delivery{ID: entry.EventID, Type: entry.EventType, Event: entry.Event})
+	if err != nil {
+		return fmt.Errorf("error marshalling delivery: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, entry.EventID)
+	req.Header.Set(EventTypeHeader, entry.EventType)
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return nil
+}
+
+// backoff returns the wait after the given number of failed attempts: InitialBackoff doubled after every
+// attempt but the first, up to MaxBackoff, give or take a fifth so that failed entries spread out
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.InitialBackoff
+	for i := 1; i < attempts && wait < d.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, d.cfg.MaxBackoff)
+	return time.Duration(float64(wait) * (0.8 + 0.4*rand.Float64()))
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package outbox delivers the changes of entities to webhooks.
+//
+// CreateEntity, UpdateEntity and DeleteEntity record their events in an outbox collection as a
+// step of the write itself, one entry for every webhook endpoint that takes the event, so that an
+// event is recorded exactly when the change is. A Dispatcher in the background POSTs the entries to
+// the endpoints as signed JSON, see Sign, and retries failed deliveries with exponential backoff.
+// An entry still failing after the last attempt is dead-lettered; the admin RPCs list the
+// dead-lettered entries and replay them once the endpoint is fixed.
+//
+// Delivery is at least once: a receiver seeing the same event id twice should ignore the repeat.
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/watch"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// Config configures the delivery of the events to webhooks. The endpoints file is read once, on startup.
+type Config struct {
+	EndpointsFile  string        `yaml:"endpointsFile" env:"WEBHOOK_ENDPOINTS_FILE"`   // YAML file listing the webhooks, see EndpointsFile
+	Workers        int           `yaml:"workers" env:"WEBHOOK_WORKERS"`                // Number of deliveries made at the same time
+	Timeout        time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`                // Of a single delivery
+	MaxAttempts    int           `yaml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS"`       // After which a delivery is dead-lettered
+	InitialBackoff time.Duration `yaml:"initialBackoff" env:"WEBHOOK_INITIAL_BACKOFF"` // Wait after the first failed attempt, doubled after every further one
+	MaxBackoff     time.Duration `yaml:"maxBackoff" env:"WEBHOOK_MAX_BACKOFF"`         // Longest wait between two attempts
+	PollInterval   time.Duration `yaml:"pollInterval" env:"WEBHOOK_POLL_INTERVAL"`     // How often an idle dispatcher looks for new entries
+	Retention      time.Duration `yaml:"retention" env:"WEBHOOK_RETENTION"`            // How long delivered entries are kept
+}
+
+// EndpointsFile is the format of the endpoints file. The secrets signing the deliveries are read
+// from their own files, so that the endpoints file can be shared.
+type EndpointsFile struct {
+	Endpoints []struct {
+		Name       string   `yaml:"name"`
+		URL        string   `yaml:"url"`
+		SecretFile string   `yaml:"secretFile"`
+		EventTypes []string `yaml:"eventTypes"` // Types of the events to deliver, every type if empty
+	} `yaml:"endpoints"`
+}
+
+// Endpoint is a webhook receiving events
+type Endpoint struct {
+	Name       string
+	URL        string
+	Secret     []byte
+	EventTypes []string
+}
+
+// Takes reports whether the endpoint receives events of the type
+func (e *Endpoint) Takes(eventType string) bool {
+	return len(e.EventTypes) == 0 || slices.Contains(e.EventTypes, eventType)
+}
+
+// eventTypes are the types an endpoint can ask for
+var eventTypes = []string{
+	watch.EntityCreated, watch.EntityUpdated, watch.EntityDeleted,
+	watch.RelationshipCreated, watch.RelationshipUpdated, watch.RelationshipDeleted,
+}
+
+// LoadEndpoints reads the endpoints file and the secrets of the endpoints
+func LoadEndpoints(path string) ([]*Endpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading webhook endpoints file: %w", err)
+	}
+	var file EndpointsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing webhook endpoints file %s: %w", path, err)
+	}
+	if len(file.Endpoints) == 0 {
+		return nil, fmt.Errorf("webhook endpoints file %s lists no endpoints", path)
+	}
+
+	endpoints := make([]*Endpoint, 0, len(file.Endpoints))
+	seen := make(map[string]bool)
+	for i, e := range file.Endpoints {
+		if e.Name == "" {
+			return nil, fmt.Errorf("webhook endpoint %d in %s has no name", i+1, path)
+		}
+		if seen[e.Name] {
+			return nil, fmt.Errorf("webhook endpoint %s is listed twice in %s", e.Name, path)
+		}
+		seen[e.Name] = true
+
+		if u, err := url.Parse(e.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook endpoint %s has an invalid url %q, expected http:// or https://", e.Name, e.URL)
+		}
+		for _, eventType := range e.EventTypes {
+			if !slices.Contains(eventTypes, eventType) {
+				return nil, fmt.Errorf("webhook endpoint %s has an unknown event type %q", e.Name, eventType)
+			}
+		}
+		if e.SecretFile == "" {
+			return nil, fmt.Errorf("webhook endpoint %s has no secretFile", e.Name)
+		}
+		secret, err := os.ReadFile(e.SecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret of webhook endpoint %s: %w", e.Name, err)
+		}
+		secret = bytes.TrimSpace(secret)
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("secret of webhook endpoint %s in %s must be at least 32 bytes", e.Name, e.SecretFile)
+		}
+
+		endpoints = append(endpoints, &Endpoint{Name: e.Name, URL: e.URL, Secret: secret, EventTypes: e.EventTypes})
+	}
+	return endpoints, nil
+}
+
+// Statuses of the entries
+const (
+	StatusHeld      = "held"      // Recorded by a write that has not finished yet
+	StatusPending   = "pending"   // Waiting for its next attempt
+	StatusDelivered = "delivered" // Accepted by the endpoint
+	StatusFailed    = "failed"    // Dead-lettered after the last attempt
+)
+
+// Entry is the delivery of an event to an endpoint
+type Entry struct {
+	ID          string
+	EventID     string // Shared by the entries of the same event at different endpoints
+	Endpoint    string // Name of the endpoint
+	EventType   string
+	Event       []byte // The event as protojson
+	Status      string
+	Attempts    int
+	NextAttempt time.Time // When a pending entry is due
+	LastError   string    // Why the last attempt failed
+	CreatedAt   time.Time
+	DeliveredAt time.Time
+}
+
+// Store keeps the entries of the outbox
+type Store interface {
+	// Add stores new entries
+	Add(ctx context.Context, entries []*Entry) error
+	// Release makes the held entries of the events pending
+	Release(ctx context.Context, eventIDs []string) error
+	// Discard removes the entries of the events that were not delivered yet
+	Discard(ctx context.Context, eventIDs []string) error
+	// Claim returns the pending entry that has been due the longest, or nil if none is due.
+	// The entry is postponed by the lease, so that no other dispatcher claims it meanwhile.
+	Claim(ctx context.Context, now time.Time, lease time.Duration) (*Entry, error)
+	// Save stores the status, attempts, next attempt, last error and delivery time of the entry
+	Save(ctx context.Context, entry *Entry) error
+	// ListFailed returns up to limit dead-lettered entries of the endpoint, or of every endpoint
+	// if it is empty, the most recent first
+	ListFailed(ctx context.Context, endpoint string, limit int) ([]*Entry, error)
+	// Replay makes the dead-lettered entries with the ids, or all of them if ids is empty, of the
+	// endpoint, or of every endpoint if it is empty, pending again and returns how many it replayed
+	Replay(ctx context.Context, ids []string, endpoint string) (int64, error)
+}
+
+// Outbox records the events of the writes for the endpoints. A nil Outbox records nothing,
+// for when webhooks are disabled.
+type Outbox struct {
+	store     Store
+	endpoints []*Endpoint
+}
+
+// New creates the outbox of the endpoints
+func New(store Store, endpoints []*Endpoint) *Outbox {
+	return &Outbox{store: store, endpoints: endpoints}
+}
+
+// Record adds entries for the events that can be delivered right away, returning the ids of the events
+func (o *Outbox) Record(ctx context.Context, events ...*pb.EntityEvent) ([]string, error) {
+	return o.add(ctx, StatusPending, events)
+}
+
+// Hold adds entries for the events of a write that has not finished yet, returning the ids of the events.
+// The entries are delivered once the write finishes and Release is called; Discard them if it fails.
+func (o *Outbox) Hold(ctx context.Context, events ...*pb.EntityEvent) ([]string, error) {
+	return o.add(ctx, StatusHeld, events)
+}
+
+// Release hands the held entries of the events to the dispatcher
+func (o *Outbox) Release(ctx context.Context, eventIDs []string) error {
+	if o == nil || len(eventIDs) == 0 {
+		return nil
+	}
+	return o.store.Release(ctx, eventIDs)
+}
+
+// Discard removes the entries of the events that were not delivered yet, for a write that was undone
+func (o *Outbox) Discard(ctx context.Context, eventIDs []string) error {
+	if o == nil || len(eventIDs) == 0 {
+		return nil
+	}
+	return o.store.Discard(ctx, eventIDs)
+}
+
+// ListFailed returns the dead-lettered entries, see Store.ListFailed
+func (o *Outbox) ListFailed(ctx context.Context, endpoint string, limit int) ([]*Entry, error) {
+	return o.store.ListFailed(ctx, endpoint, limit)
+}
+
+// Replay delivers dead-lettered entries again, see Store.Replay
+func (o *Outbox) Replay(ctx context.Context, ids []string, endpoint string) (int64, error) {
+	return o.store.Replay(ctx, ids, endpoint)
+}
+
+func (o *Outbox) add(ctx context.Context, status string, events []*pb.EntityEvent) ([]string, error) {
+	if o == nil || len(events) == 0 {
+		return nil, nil
+	}
+	now := time.Now().UTC()
+
+	var entries []*Entry
+	eventIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		if event.Time == "" {
+			event.Time = now.Format(time.RFC3339Nano)
+		}
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling %s event of entity %s: %w", event.Type, event.EntityId, err)
+		}
+		eventID := uuid.New().String()
+		eventIDs = append(eventIDs, eventID)
+		for _, endpoint := range o.endpoints {
+			if !endpoint.Takes(event.Type) {
+				continue
+			}
+			entries = append(entries, &Entry{
+				ID:          uuid.New().String(),
+				EventID:     eventID,
+				Endpoint:    endpoint.Name,
+				EventType:   event.Type,
+				Event:       data,
+				Status:      status,
+				NextAttempt: now,
+				CreatedAt:   now,
+			})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if err := o.store.Add(ctx, entries); err != nil {
+		return nil, fmt.Errorf("error recording events in the outbox: %w", err)
+	}
+	return eventIDs, nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/watch"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is a Store in memory
+type memoryStore struct {
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+func (s *memoryStore) Add(ctx context.Context, entries []*Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, eventIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if entry.Status == StatusHeld && slices.Contains(eventIDs, entry.EventID) {
+			entry.Status = StatusPending
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Discard(ctx context.Context, eventIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = slices.DeleteFunc(s.entries, func(entry *Entry) bool {
+		return entry.Status != StatusDelivered && slices.Contains(eventIDs, entry.EventID)
+	})
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, now time.Time, lease time.Duration) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due *Entry
+	for _, entry := range s.entries {
+		if entry.Status == StatusPending && !entry.NextAttempt.After(now) && (due == nil || entry.NextAttempt.Before(due.NextAttempt)) {
+			due = entry
+		}
+	}
+	if due == nil {
+		return nil, nil
+	}
+	due.NextAttempt = now.Add(lease)
+	copied := *due
+	return &copied, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.entries {
+		if e.ID == entry.ID {
+			copied := *entry
+			s.entries[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) ListFailed(ctx context.Context, endpoint string, limit int) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var failed []*Entry
+	for _, entry := range s.entries {
+		if entry.Status == StatusFailed && (endpoint == "" || entry.Endpoint == endpoint) {
+			failed = append(failed, entry)
+		}
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i].CreatedAt.After(failed[j].CreatedAt) })
+	return failed[:min(limit, len(failed))], nil
+}
+
+func (s *memoryStore) Replay(ctx context.Context, ids []string, endpoint string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var replayed int64
+	for _, entry := range s.entries {
+		if entry.Status == StatusFailed && (len(ids) == 0 || slices.Contains(ids, entry.ID)) && (endpoint == "" || entry.Endpoint == endpoint) {
+			entry.Status = StatusPending
+			entry.Attempts = 0
+			entry.NextAttempt = time.Now()
+			replayed++
+		}
+	}
+	return replayed, nil
+}
+
+func (s *memoryStore) statuses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var statuses []string
+	for _, entry := range s.entries {
+		statuses = append(statuses, entry.Endpoint+":"+entry.Status)
+	}
+	return statuses
+}
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+func event(eventType string) *pb.EntityEvent {
+	return watch.EntityEvent(eventType, &pb.Entity{Id: "e1", Kind: &pb.Kind{Major: "Person", Minor: "Minister"}, Version: 1})
+}
+
+func TestRecordAddsEntryPerEndpoint(t *testing.T) {
+	store := &memoryStore{}
+	o := New(store, []*Endpoint{
+		{Name: "all", URL: "http://all", Secret: secret},
+		{Name: "deletions", URL: "http://deletions", Secret: secret, EventTypes: []string{watch.EntityDeleted}},
+	})
+
+	created, err := o.Record(context.Background(), event(watch.EntityCreated))
+	assert.NoError(t, err)
+	assert.Len(t, created, 1)
+	deleted, err := o.Hold(context.Background(), event(watch.EntityDeleted))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"all:pending", "all:held", "deletions:held"}, store.statuses())
+
+	assert.NoError(t, o.Release(context.Background(), deleted))
+	assert.Equal(t, []string{"all:pending", "all:pending", "deletions:pending"}, store.statuses())
+	assert.NoError(t, o.Discard(context.Background(), created))
+	assert.Equal(t, []string{"all:pending", "deletions:pending"}, store.statuses())
+
+	var disabled *Outbox
+	ids, err := disabled.Record(context.Background(), event(watch.EntityCreated))
+	assert.NoError(t, err)
+	assert.Empty(t, ids, "Expected a nil outbox to record nothing")
+	assert.NoError(t, disabled.Discard(context.Background(), created))
+}
+
+func TestDeliverNextSignsDelivery(t *testing.T) {
+	var received http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	store := &memoryStore{}
+	endpoints := []*Endpoint{{Name: "hook", URL: server.URL, Secret: secret}}
+	eventIDs, err := New(store, endpoints).Record(context.Background(), event(watch.EntityCreated))
+	require.NoError(t, err)
+
+	dispatcher := NewDispatcher(store, endpoints, Config{Timeout: time.Second, MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute})
+	found, err := dispatcher.DeliverNext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"hook:delivered"}, store.statuses())
+
+	assert.Equal(t, eventIDs[0], received.Get(EventIDHeader))
+	assert.Equal(t, watch.EntityCreated, received.Get(EventTypeHeader))
+	signature := received.Get(SignatureHeader)
+	timestamp, _ := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+	assert.Equal(t, Sign(secret, time.Unix(timestamp, 0), body), signature, "Expected the body to be signed with the secret")
+
+	var sent struct {
+		ID    string `json:"id"`
+		Event struct {
+			EntityID string `json:"entityId"`
+		} `json:"event"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &sent))
+	assert.Equal(t, eventIDs[0], sent.ID)
+	assert.Equal(t, "e1", sent.Event.EntityID)
+
+	found, err = dispatcher.DeliverNext(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, found, "Expected a delivered entry not to be sent again")
+}
+
+func TestFailedDeliveriesAreRetriedThenDeadLettered(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	store := &memoryStore{}
+	endpoints := []*Endpoint{{Name: "hook", URL: server.URL, Secret: secret}}
+	_, err := New(store, endpoints).Record(context.Background(), event(watch.EntityUpdated))
+	require.NoError(t, err)
+
+	// Without backoff, so that every attempt is due right away
+	dispatcher := NewDispatcher(store, endpoints, Config{Timeout: time.Second, MaxAttempts: 3})
+	for attempt := 1; attempt <= 3; attempt++ {
+		found, err := dispatcher.DeliverNext(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, found, "Expected attempt %d", attempt)
+	}
+	found, _ := dispatcher.DeliverNext(context.Background())
+	assert.False(t, found, "Expected no attempt after the last one")
+
+	failed, err := store.ListFailed(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, 3, failed[0].Attempts)
+	assert.Contains(t, failed[0].LastError, "503")
+
+	healthy.Store(true)
+	replayed, err := New(store, endpoints).Replay(context.Background(), nil, "hook")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), replayed)
+	found, err = dispatcher.DeliverNext(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"hook:delivered"}, store.statuses())
+}
+
+func TestBackoff(t *testing.T) {
+	dispatcher := NewDispatcher(nil, nil, Config{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second})
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 50: 10 * time.Second} {
+		wait := dispatcher.backoff(attempts)
+		assert.GreaterOrEqual(t, wait, want*8/10, "attempts %d", attempts)
+		assert.LessOrEqual(t, wait, want*12/10, "attempts %d", attempts)
+	}
+}
+
+func TestLoadEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretFile, append(secret, '\n'), 0o600))
+	write := func(content string) string {
+		path := filepath.Join(dir, "endpoints.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	endpoints, err := LoadEndpoints(write(`
+endpoints:
+  - name: search
+    url: https://search.example.org/hooks/core
+    secretFile: ` + secretFile + `
+    eventTypes: [ENTITY_CREATED, ENTITY_DELETED]
+`))
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, secret, endpoints[0].Secret)
+	assert.True(t, endpoints[0].Takes(watch.EntityCreated))
+	assert.False(t, endpoints[0].Takes(watch.EntityUpdated))
+
+	for name, content := range map[string]string{
+		"no endpoints":       "endpoints: []",
+		"invalid url":        "endpoints: [{name: a, url: 'search:8080', secretFile: " + secretFile + "}]",
+		"unknown event type": "endpoints: [{name: a, url: 'http://a', secretFile: " + secretFile + ", eventTypes: [CREATED]}]",
+		"no secret":          "endpoints: [{name: a, url: 'http://a'}]",
+		"duplicate name":     "endpoints: [{name: a, url: 'http://a', secretFile: " + secretFile + "}, {name: a, url: 'http://b', secretFile: " + secretFile + "}]",
+	} {
+		_, err := LoadEndpoints(write(content))
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package saga
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"lk/datafoundation/core-api/pkg/logging"
+	"lk/datafoundation/core-api/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Compensation undoes the effect of a step that already completed
+type Compensation func(ctx context.Context) error
+
+// step is a completed step together with the action that undoes it
+type step struct {
+	name string
+	undo Compensation
+}
+
+// StepOutcome is the result of compensating a single step
+type StepOutcome struct {
+	Step  string
+	Error error
+}
+
+// Saga coordinates a sequence of writes that span several stores which cannot share a transaction.
+// Every completed step records a compensation and when a later step fails the compensations
+// run in reverse order, so the stores are brought back to the state they had before the saga started.
+type Saga struct {
+	name  string
+	steps []step
+}
+
+// New creates a saga, the name is only used to identify it in logs and errors
+func New(name string) *Saga {
+	return &Saga{name: name}
+}
+
+// Run executes a step and records its compensation when it succeeds.
+// A failed step is expected to leave nothing behind, so its compensation is not recorded.
+// The undo may be nil for steps that do not write anything.
+func (s *Saga) Run(ctx context.Context, name string, do func(ctx context.Context) error, undo Compensation) error {
+	if err := do(ctx); err != nil {
+		return err
+	}
+	s.Record(name, undo)
+	return nil
+}
+
+// Record adds a compensation for a step that was executed outside of Run
+func (s *Saga) Record(name string, undo Compensation) {
+	if undo == nil {
+		return
+	}
+	s.steps = append(s.steps, step{name: name, undo: undo})
+}
+
+// Steps returns the names of the steps that would be compensated, in the order they completed
+func (s *Saga) Steps() []string {
+	names := make([]string, len(s.steps))
+	for i, st := range s.steps {
+		names[i] = st.name
+	}
+	return names
+}
+
+// Compensate runs the recorded compensations in reverse order and reports the outcome of each.
+// A failing compensation does not stop the remaining ones. The saga is empty afterwards.
+// Compensations run even if ctx was cancelled, a cancelled request is a common reason to roll back.
+func (s *Saga) Compensate(ctx context.Context) []StepOutcome {
+	ctx = context.WithoutCancel(ctx)
+	ctx, span := tracing.Start(ctx, "saga.Compensate", attribute.String("core.saga.name", s.name))
+	defer span.End()
+
+	outcomes := make([]StepOutcome, 0, len(s.steps))
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		st := s.steps[i]
+		stepCtx, stepSpan := tracing.Start(ctx, "saga.undo "+st.name)
+		err := st.undo(stepCtx)
+		tracing.End(stepSpan, err)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to undo step", "saga", s.name, "step", st.name, "error", err)
+		} else {
+			logging.FromContext(ctx).Info("Undid step", "saga", s.name, "step", st.name)
+		}
+		outcomes = append(outcomes, StepOutcome{Step: st.name, Error: err})
+	}
+	s.steps = nil
+
+	return outcomes
+}
+
+// Abort compensates every completed step and returns an error that wraps cause
+// and describes the outcome of the rollback
+func (s *Saga) Abort(ctx context.Context, cause error) error {
+	return &Error{Saga: s.name, Cause: cause, Rollback: s.Compensate(ctx)}
+}
+
+// Error is returned by Abort. It carries the error that made the saga fail and the rollback outcome.
+type Error struct {
+	Saga     string
+	Cause    error
+	Rollback []StepOutcome
+}
+
+// RolledBack reports whether every compensation succeeded
+func (e *Error) RolledBack() bool {
+	for _, outcome := range e.Rollback {
+		if outcome.Error != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Error) Error() string {
+	if len(e.Rollback) == 0 {
+		return fmt.Sprintf("%s failed: %v (nothing to roll back)", e.Saga, e.Cause)
+	}
+	if e.RolledBack() {
+		return fmt.Sprintf("%s failed: %v (rolled back %d steps)", e.Saga, e.Cause, len(e.Rollback))
+	}
+
+	var failed []string
+	for _, outcome := range e.Rollback {
+		if outcome.Error != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", outcome.Step, outcome.Error))
+		}
+	}
+	return fmt.Sprintf("%s failed: %v (rollback incomplete, %d of %d steps could not be undone: %s)",
+		e.Saga, e.Cause, len(failed), len(e.Rollback), strings.Join(failed, "; "))
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSagaCompensatesInReverseOrder(t *testing.T) {
+	ctx := context.Background()
+	s := New("CreateEntity")
+
+	var undone []string
+	for _, name := range []string{"entity", "relationship", "metadata"} {
+		name := name
+		err := s.Run(ctx, name, func(ctx context.Context) error { return nil }, func(ctx context.Context) error {
+			undone = append(undone, name)
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	cause := errors.New("attribute failed")
+	err := s.Abort(ctx, cause)
+
+	assert.Equal(t, []string{"metadata", "relationship", "entity"}, undone)
+	assert.ErrorIs(t, err, cause)
+	assert.Contains(t, err.Error(), "rolled back 3 steps")
+
+	var sagaErr *Error
+	assert.True(t, errors.As(err, &sagaErr))
+	assert.True(t, sagaErr.RolledBack())
+	assert.Empty(t, s.Steps())
+}
+
+func TestSagaDoesNotRecordFailedStep(t *testing.T) {
+	ctx := context.Background()
+	s := New("CreateEntity")
+
+	undoCalled := false
+	cause := errors.New("neo4j unavailable")
+	err := s.Run(ctx, "entity", func(ctx context.Context) error { return cause }, func(ctx context.Context) error {
+		undoCalled = true
+		return nil
+	})
+	assert.Equal(t, cause, err)
+	assert.Empty(t, s.Steps())
+
+	err = s.Abort(ctx, err)
+	assert.False(t, undoCalled)
+	assert.Contains(t, err.Error(), "nothing to roll back")
+}
+
+func TestSagaContinuesAfterFailedCompensation(t *testing.T) {
+	ctx := context.Background()
+	s := New("UpdateEntity")
+
+	entityUndone := false
+	s.Record("entity", func(ctx context.Context) error {
+		entityUndone = true
+		return nil
+	})
+	s.Record("metadata", func(ctx context.Context) error {
+		return errors.New("mongo unavailable")
+	})
+	s.Record("no-op", nil)
+	assert.Equal(t, []string{"entity", "metadata"}, s.Steps())
+
+	err := s.Abort(ctx, errors.New("attribute failed"))
+	assert.True(t, entityUndone)
+
+	var sagaErr *Error
+	assert.True(t, errors.As(err, &sagaErr))
+	assert.False(t, sagaErr.RolledBack())
+	assert.Len(t, sagaErr.Rollback, 2)
+	assert.Contains(t, err.Error(), "rollback incomplete, 1 of 2 steps could not be undone: metadata: mongo unavailable")
+}
+
+func TestSagaCompensatesAfterCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := New("CreateEntity")
+
+	var undoErr error
+	s.Record("entity", func(ctx context.Context) error {
+		undoErr = ctx.Err()
+		return nil
+	})
+
+	cancel()
+	s.Compensate(ctx)
+	assert.NoError(t, undoErr)
+}
@@ -19,6 +19,7 @@ package schema
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	"google.golang.org/protobuf/types/known/anypb"
@@ -327,7 +328,7 @@ func (sg *SchemaGenerator) GenerateSchema(anyValue *anypb.Any) (*SchemaInfo, err
 				TypeInfo:    &typeinference.TypeInfo{Type: typeinference.BoolType},
 			}, nil
 		default:
-			fmt.Printf("Schema generator hit default case with message type: %T, message: %+v\n", message, message)
+			slog.Warn("Schema generator hit default case", "message_type", fmt.Sprintf("%T", message))
 			return nil, fmt.Errorf("expected struct value or supported wrapper type, got %T", message)
 		}
 	}
@@ -6,7 +6,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -402,28 +402,26 @@ func GenerateSchema(value *anypb.Any) (*SchemaInfo, error) {
 // logSchemaInfo logs schema information in a readable format
 func LogSchemaInfo(schemaInfo *SchemaInfo) {
 	if schemaInfo == nil {
-		log.Printf("Schema is nil")
+		slog.Debug("Schema is nil")
 		return
 	}
 
 	// Log the schema information
-	log.Printf("Schema: StorageType=%v, TypeInfo=%v",
-		schemaInfo.StorageType,
-		schemaInfo.TypeInfo)
+	slog.Debug("Schema", "storage_type", schemaInfo.StorageType, "type_info", schemaInfo.TypeInfo)
 
 	// Convert schema to JSON for logging
 	schemaJSON, err := SchemaInfoToJSON(schemaInfo)
 	if err != nil {
-		log.Printf("Failed to convert schema to JSON: %v", err)
+		slog.Error("Failed to convert schema to JSON", "error", err)
 		return
 	}
 
 	// Marshal to pretty JSON for better readability
 	prettyJSON, err := json.MarshalIndent(schemaJSON, "", "  ")
 	if err != nil {
-		log.Printf("Failed to marshal schema to JSON: %v", err)
+		slog.Error("Failed to marshal schema to JSON", "error", err)
 		return
 	}
 
-	log.Printf("Schema JSON:\n%s", string(prettyJSON))
+	slog.Debug("Schema JSON", "schema", string(prettyJSON))
 }
@@ -0,0 +1,156 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing sets up OpenTelemetry tracing for the core API.
+//
+// The server joins the traces of its callers through the W3C trace-context in the gRPC metadata,
+// and every layer adds its own spans: the RPC, the engine, each repository method and each query
+// sent to a store. Spans carry the entity id, attribute name and storage type they are about.
+// Traces are exported to stdout or to an OTLP collector, or not at all, see Config.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters of Config.Exporter
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// instrumentationName names the tracer of the core API
+const instrumentationName = "lk/datafoundation/core-api"
+
+// Config configures the export of traces. The environment variables are those of the OpenTelemetry SDKs.
+type Config struct {
+	Exporter    string  `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`        // none, stdout or otlp
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"` // host:port or URL of the OTLP gRPC collector
+	Insecure    bool    `yaml:"insecure" env:"OTEL_EXPORTER_OTLP_INSECURE"` // Connect to the collector without TLS
+	ServiceName string  `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`        // service.name of the traces
+	SampleRatio float64 `yaml:"sampleRatio" env:"OTEL_TRACES_SAMPLER_ARG"`  // Share of the new traces that are sampled, from 0 to 1
+}
+
+// Setup installs the tracer provider and the W3C trace-context propagator. The returned function
+// flushes the spans not exported yet and must be called before the process exits.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	// Propagate the caller's trace even when not exporting, so that the trace is not broken here
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{}
+		switch {
+		case strings.HasPrefix(cfg.Endpoint, "http://"), strings.HasPrefix(cfg.Endpoint, "https://"):
+			options = append(options, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		case cfg.Endpoint != "":
+			options = append(options, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("Exporting traces", "service", cfg.ServiceName, "exporter", cfg.Exporter)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in the context
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartClient starts a span for an operation sent to a store, e.g. a Cypher query,
+// named after the store and the operation like "neo4j ReadGraphEntity"
+func StartClient(ctx context.Context, store, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append(attributes, semconv.DBSystemKey.String(store), semconv.DBOperationName(operation))
+	return otel.Tracer(instrumentationName).Start(ctx, store+" "+operation, trace.WithAttributes(attributes...), trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// End records the error, if any, on the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Attributes of the spans
+const (
+	EntityIDKey       = attribute.Key("core.entity.id")
+	RelationshipIDKey = attribute.Key("core.relationship.id")
+	AttributeNameKey  = attribute.Key("core.attribute.name")
+	StorageTypeKey    = attribute.Key("core.attribute.storage_type")
+	OperationKey      = attribute.Key("core.operation")
+	TableNameKey      = attribute.Key("core.table.name")
+)
+
+// EntityID is the id of the entity a span is about
+func EntityID(id string) attribute.KeyValue {
+	return EntityIDKey.String(id)
+}
+
+// RelationshipID is the id of the relationship a span is about
+func RelationshipID(id string) attribute.KeyValue {
+	return RelationshipIDKey.String(id)
+}
+
+// AttributeName is the name of the attribute a span is about
+func AttributeName(name string) attribute.KeyValue {
+	return AttributeNameKey.String(name)
+}
+
+// StorageType is the storage type of the attribute a span is about, e.g. "tabular"
+func StorageType(storageType string) attribute.KeyValue {
+	return StorageTypeKey.String(storageType)
+}
+
+// Operation is the create, read, update or delete operation of an attribute resolver
+func Operation(operation string) attribute.KeyValue {
+	return OperationKey.String(operation)
+}
+
+// TableName is the PostgreSQL table a span is about
+func TableName(name string) attribute.KeyValue {
+	return TableNameKey.String(name)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a tracer provider that keeps the ended spans, restoring the previous one afterwards
+func record(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSpansNestAndRecordErrors(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := Start(context.Background(), "EntityAttributeProcessor.ProcessEntityAttributes", EntityID("entity-1"))
+	_, child := StartClient(ctx, "neo4j", "ReadGraphEntity")
+	End(child, errors.New("connection reset"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	client, server := spans[0], spans[1]
+	assert.Equal(t, "neo4j ReadGraphEntity", client.Name())
+	assert.Equal(t, trace.SpanKindClient, client.SpanKind())
+	assert.Equal(t, server.SpanContext().SpanID(), client.Parent().SpanID(), "Expected the store span to be a child")
+	assert.Contains(t, client.Attributes(), attribute.String("db.system", "neo4j"))
+	assert.Equal(t, codes.Error, client.Status().Code)
+	assert.Equal(t, "connection reset", client.Status().Description)
+	assert.Contains(t, server.Attributes(), EntityID("entity-1"))
+	assert.Equal(t, codes.Unset, server.Status().Code)
+}
+
+func TestSetupPropagatesCallerTrace(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	carrier := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", trace.SpanContextFromContext(ctx).TraceID().String(),
+		"Expected the trace-context of the caller to be extracted even when not exporting")
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "jaeger"})
+	assert.ErrorContains(t, err, "unknown trace exporter \"jaeger\"")
+}
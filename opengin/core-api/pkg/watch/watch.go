@@ -0,0 +1,204 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package watch streams the changes of entities to the clients of WatchEntities.
+//
+// The server publishes an event for every change it writes to a Hub, which keeps the latest events
+// in memory. A watcher reads the events following the sequence it has seen last, so that a client
+// reconnecting with the sequence of its last event misses nothing, as long as the events were not
+// discarded in the meantime. Sequences start from the time the server started, so that they keep
+// increasing across restarts and a client resuming from before a restart is told it missed events.
+package watch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+)
+
+// Types of the events
+const (
+	EntityCreated       = "ENTITY_CREATED"
+	EntityUpdated       = "ENTITY_UPDATED"
+	EntityDeleted       = "ENTITY_DELETED"
+	RelationshipCreated = "RELATIONSHIP_CREATED"
+	RelationshipUpdated = "RELATIONSHIP_UPDATED"
+	RelationshipDeleted = "RELATIONSHIP_DELETED"
+)
+
+// Hub keeps the latest events and hands them to the watchers
+type Hub struct {
+	mu      sync.Mutex
+	events  []*pb.EntityEvent // Ring buffer of the kept events
+	first   int               // Index of the oldest kept event
+	count   int               // Number of kept events
+	next    uint64            // Sequence of the next event
+	changed chan struct{}     // Closed when events are published or the hub is closed
+	closed  bool
+}
+
+// NewHub creates a hub keeping the latest size events
+func NewHub(size int) *Hub {
+	return &Hub{
+		events:  make([]*pb.EntityEvent, size),
+		next:    uint64(time.Now().UnixMicro()),
+		changed: make(chan struct{}),
+	}
+}
+
+// Publish assigns the next sequences to the events and hands them to the watchers.
+// The oldest events are discarded once the hub is full.
+func (h *Hub) Publish(events ...*pb.EntityEvent) {
+	if len(events) == 0 {
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	for _, event := range events {
+		event.Sequence = h.next
+		h.next++
+		if event.Time == "" {
+			event.Time = now
+		}
+
+		if h.count < len(h.events) {
+			h.events[(h.first+h.count)%len(h.events)] = event
+			h.count++
+		} else {
+			h.events[h.first] = event
+			h.first = (h.first + 1) % len(h.events)
+		}
+	}
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+// Latest returns the sequence of the last published event
+func (h *Hub) Latest() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.next - 1
+}
+
+// Next waits for the events following the sequence after and returns them in order. It fails with
+// FailedPrecondition if some of them were discarded already, and with Unavailable once the hub is closed.
+func (h *Hub) Next(ctx context.Context, after uint64) ([]*pb.EntityEvent, error) {
+	for {
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			return nil, apperrors.New(apperrors.Unavailable, "the server is shutting down, resume watching after sequence %d", after)
+		}
+		oldest := h.next - uint64(h.count)
+		if after+1 < oldest {
+			h.mu.Unlock()
+			return nil, apperrors.FailedPreconditionf("events after sequence %d are no longer kept, read the entities again and watch from the latest sequence", after).WithField("afterSequence")
+		}
+		if after+1 < h.next {
+			start := int(after + 1 - oldest)
+			events := make([]*pb.EntityEvent, 0, h.count-start)
+			for i := start; i < h.count; i++ {
+				events = append(events, h.events[(h.first+i)%len(h.events)])
+			}
+			h.mu.Unlock()
+			return events, nil
+		}
+		if after >= h.next {
+			h.mu.Unlock()
+			return nil, apperrors.InvalidArgumentf("sequence %d has not been reached yet", after).WithField("afterSequence")
+		}
+		changed := h.changed
+		h.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close ends the watches, so that the server can stop without waiting for them
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.closed {
+		h.closed = true
+		close(h.changed)
+	}
+}
+
+// Filter selects the events a watcher asked for
+type Filter struct {
+	kinds             []*pb.Kind
+	entityIDs         map[string]bool
+	relationshipNames map[string]bool
+}
+
+// NewFilter creates the filter of a WatchEntities request
+func NewFilter(req *pb.WatchEntitiesRequest) *Filter {
+	return &Filter{
+		kinds:             req.Kinds,
+		entityIDs:         set(req.EntityIds),
+		relationshipNames: set(req.RelationshipNames),
+	}
+}
+
+// Match reports whether the event passes every filter
+func (f *Filter) Match(event *pb.EntityEvent) bool {
+	if len(f.kinds) > 0 && !f.matchKind(event.Kind) {
+		return false
+	}
+	if len(f.entityIDs) > 0 && !f.entityIDs[event.EntityId] && !f.entityIDs[event.GetRelationship().GetRelatedEntityId()] {
+		return false
+	}
+	if len(f.relationshipNames) > 0 && (event.Relationship == nil || !f.relationshipNames[event.Relationship.Name]) {
+		return false
+	}
+	return true
+}
+
+func (f *Filter) matchKind(kind *pb.Kind) bool {
+	for _, k := range f.kinds {
+		if k.GetMajor() == kind.GetMajor() && (k.GetMinor() == "" || k.GetMinor() == kind.GetMinor()) {
+			return true
+		}
+	}
+	return false
+}
+
+func set(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(values))
+	for _, value := range values {
+		m[value] = true
+	}
+	return m
+}
+
+// EntityEvent creates the event of a change of the entity
+func EntityEvent(eventType string, entity *pb.Entity) *pb.EntityEvent {
+	return &pb.EntityEvent{
+		Type:     eventType,
+		EntityId: entity.Id,
+		Kind:     entity.Kind,
+		Version:  entity.Version,
+	}
+}
+
+// RelationshipEvent creates the event of a change of a relationship of the entity
+func RelationshipEvent(eventType string, entity *pb.Entity, relationship *pb.Relationship) *pb.EntityEvent {
+	event := EntityEvent(eventType, entity)
+	event.Relationship = relationship
+	return event
+}
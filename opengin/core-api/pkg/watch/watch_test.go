@@ -0,0 +1,117 @@
+// Copyright 2025 Lanka Data Foundation
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "lk/datafoundation/core-api/lk/datafoundation/core-api"
+	"lk/datafoundation/core-api/pkg/apperrors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func entity(id, major, minor string) *pb.Entity {
+	return &pb.Entity{Id: id, Kind: &pb.Kind{Major: major, Minor: minor}, Version: 1}
+}
+
+func TestNextReturnsEventsInOrder(t *testing.T) {
+	hub := NewHub(10)
+	start := hub.Latest()
+
+	hub.Publish(EntityEvent(EntityCreated, entity("e1", "Person", "Minister")), EntityEvent(EntityCreated, entity("e2", "Person", "Minister")))
+	hub.Publish(EntityEvent(EntityUpdated, entity("e1", "Person", "Minister")))
+
+	events, err := hub.Next(context.Background(), start)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	for i, event := range events {
+		assert.Equal(t, start+uint64(i)+1, event.Sequence)
+		assert.NotEmpty(t, event.Time)
+	}
+	assert.Equal(t, EntityUpdated, events[2].Type)
+
+	events, err = hub.Next(context.Background(), events[1].Sequence)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1, "Expected a resumed watch to get only the later events")
+}
+
+func TestNextWaitsForEvents(t *testing.T) {
+	hub := NewHub(10)
+	start := hub.Latest()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		hub.Publish(EntityEvent(EntityDeleted, entity("e1", "Person", "Minister")))
+	}()
+
+	events, err := hub.Next(context.Background(), start)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = hub.Next(ctx, events[0].Sequence)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNextFailsForDiscardedEvents(t *testing.T) {
+	hub := NewHub(2)
+	start := hub.Latest()
+	for i := 0; i < 3; i++ {
+		hub.Publish(EntityEvent(EntityUpdated, entity("e1", "Person", "Minister")))
+	}
+
+	_, err := hub.Next(context.Background(), start)
+	assert.True(t, apperrors.Is(err, apperrors.FailedPrecondition), "Expected FailedPrecondition once the first event was discarded, got %v", err)
+
+	events, err := hub.Next(context.Background(), start+1)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2, "Expected the events that are still kept")
+
+	// A sequence from before a restart is older than anything the new hub has
+	_, err = NewHub(2).Next(context.Background(), start)
+	assert.True(t, apperrors.Is(err, apperrors.FailedPrecondition))
+}
+
+func TestCloseEndsWatches(t *testing.T) {
+	hub := NewHub(10)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		hub.Close()
+	}()
+
+	_, err := hub.Next(context.Background(), hub.Latest())
+	assert.True(t, apperrors.Is(err, apperrors.Unavailable), "Expected Unavailable after Close, got %v", err)
+}
+
+func TestFilter(t *testing.T) {
+	minister := entity("e1", "Person", "Minister")
+	department := entity("e2", "Organisation", "Department")
+	created := EntityEvent(EntityCreated, minister)
+	related := RelationshipEvent(RelationshipCreated, department, &pb.Relationship{Id: "r1", Name: "HAS_MINISTER", RelatedEntityId: "e1", Direction: "OUTGOING"})
+
+	tests := []struct {
+		name     string
+		req      *pb.WatchEntitiesRequest
+		created  bool
+		relation bool
+	}{
+		{"empty filter", &pb.WatchEntitiesRequest{}, true, true},
+		{"major kind", &pb.WatchEntitiesRequest{Kinds: []*pb.Kind{{Major: "Person"}}}, true, false},
+		{"minor kind", &pb.WatchEntitiesRequest{Kinds: []*pb.Kind{{Major: "Person", Minor: "Secretary"}}}, false, false},
+		{"entity at either end", &pb.WatchEntitiesRequest{EntityIds: []string{"e1"}}, true, true},
+		{"relationship name", &pb.WatchEntitiesRequest{RelationshipNames: []string{"HAS_MINISTER"}}, false, true},
+		{"combined", &pb.WatchEntitiesRequest{Kinds: []*pb.Kind{{Major: "Organisation"}}, RelationshipNames: []string{"HAS_SECRETARY"}}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := NewFilter(tt.req)
+			assert.Equal(t, tt.created, filter.Match(created))
+			assert.Equal(t, tt.relation, filter.Match(related))
+		})
+	}
+}
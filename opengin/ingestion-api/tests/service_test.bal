@@ -185,7 +185,7 @@ function testMetadataHandling() returns error? {
     test:assertEquals(actualValues["key2"], expectedValue2, "Metadata value for key2 doesn't match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity deleted");
     
     return;
@@ -357,7 +357,7 @@ function testMetadataUpdating() returns error? {
     io:println("Updated metadata verified");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity deleted");
     
     return;
@@ -495,7 +495,7 @@ function testEntityReading() returns error? {
     // test:assertTrue(nonExistentResponse is error, "Expected error for non-existent entity ID");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity deleted");
     
     return;
@@ -563,7 +563,7 @@ function testCreateMinimalGraphEntity() returns error? {
     test:assertEquals(readEntityResponse.relationships.length(), 0, "Relationships should be empty");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test minimal entity deleted");
     
     return;
@@ -648,7 +648,7 @@ function testCreateMinimalGraphEntityViaRest() returns error? {
     test:assertEquals(readEntityResponse.relationships.length(), 0, "Relationships should be empty");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test minimal JSON entity deleted");
     
     return;
@@ -801,8 +801,8 @@ function testEntityWithRelationship() returns error? {
     test:assertEquals(relationship.id, relationshipId, "Relationship ID doesn't match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: sourceEntityId});
-    Empty _ = check ep->DeleteEntity({id: targetEntityId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: sourceEntityId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: targetEntityId});
     io:println("Test entities with relationship deleted");
     
     return;
@@ -899,8 +899,8 @@ function testEntityWithSimpleOnlyNodesGraphAttributes() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with graph attributes deleted");
     
     return;
@@ -1005,7 +1005,7 @@ function testEntityWithSimpleGraphAttributes() returns error? {
     
     // Clean up
 
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with graph attributes deleted");
     
     return;
@@ -1140,7 +1140,7 @@ function testEntityWithMultiGraphAttributes() returns error? {
     
     // Clean up
     
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with graph attributes deleted");
     
     return;
@@ -1211,7 +1211,7 @@ function testEntityWithSimpleListAttributes() returns error? {
     io:println("Entity created with ID: " + createEntityResponse.id);
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with list attributes deleted");
     
     return;
@@ -1309,7 +1309,7 @@ function testEntityWithMixedTypeListAttributes() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with mixed type list attributes deleted");
     
     return;
@@ -1400,7 +1400,7 @@ function testEntityWithEmptyListAttributes() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with empty list attributes deleted");
     
     return;
@@ -1495,7 +1495,7 @@ function testEntityWithMapAttributes() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with map attributes deleted");
     
     return;
@@ -1624,7 +1624,7 @@ function testEntityWithNestedMapAttributes() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with nested map attributes deleted");
     
     return;
@@ -1720,7 +1720,7 @@ function testEntityWithEmptyMapValues() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with empty map values deleted");
     
     return;
@@ -1858,7 +1858,7 @@ function testEntityWithNestedMapValues() returns error? {
     test:assertEquals(readEntityResponse.id, testId, "Entity ID should match");
     
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with nested map values deleted");
     
     return;
@@ -1995,7 +1995,7 @@ function testEntityWithTabularAttributes() returns error? {
     verifyTabularData(dataJson, tabularData);
 
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with tabular attributes deleted");
 
     return;
@@ -2209,7 +2209,7 @@ function testEntityWithTabularAttributesMultiRels() returns error? {
     verifyTabularData(employeeDataJson, employeeData);
 
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with tabular attributes deleted");
 
     return;
@@ -2371,7 +2371,7 @@ function testEntityWithTabularAttributesUpdate() returns error? {
     verifyTabularData(dataJson, tabularData);
 
     // Clean up
-    Empty _ = check ep->DeleteEntity({id: testId});
+    DeleteEntityResponse _ = check ep->DeleteEntity({id: testId});
     io:println("Test entity with tabular attributes deleted");
 
     return;
@@ -414,25 +414,34 @@ service /v1 on ep0 {
 
         ReadEntityRequest request = {
             entity: entityFilter,
-            output: [] // Return all fields by default
+            output: [], // Return all fields by default
+            pageSize: 1000 // The search returns every match, read in the largest pages
         };
 
-        // Call the ReadEntities method
-        EntityList|error entityList = ep->ReadEntities(request);
-        if entityList is error {
-            io:println(string `Error reading entities: ${entityList.message()}`);
-            
-            return <http:BadRequest> {
-                body: {
-                    "error": "Invalid search criteria",
-                    "details": entityList.message()
-                }
-            };
+        // Call the ReadEntities method, following the pages up to the last one
+        Entity[] entities = [];
+        while true {
+            EntityList|error entityList = ep->ReadEntities(request);
+            if entityList is error {
+                io:println(string `Error reading entities: ${entityList.message()}`);
+                
+                return <http:BadRequest> {
+                    body: {
+                        "error": "Invalid search criteria",
+                        "details": entityList.message()
+                    }
+                };
+            }
+            entities.push(...entityList.entities);
+            if entityList.nextPageToken == "" {
+                break;
+            }
+            request.pageToken = entityList.nextPageToken;
         }
 
         // Map the result to the expected response format
         record {string id?; record {string major?; string minor?;} kind?; string name?; string created?; string terminated?;}[] response = [];
-        foreach var entity in entityList.entities {
+        foreach var entity in entities {
             response.push({
                 id: entity.id,
                 kind: {major: entity.kind.major, minor: entity.kind.minor},
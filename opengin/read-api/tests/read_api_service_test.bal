@@ -264,8 +264,8 @@ function testEntityAttributeRetrieval() returns error? {
     verifyTabularData(actualValueJson, expectedValueJson);
     
     // Clean up
-    EntityId deleteRequest = {id: testId};
-    Empty _ = check ep->DeleteEntity(deleteRequest);
+    DeleteEntityRequest deleteRequest = {id: testId};
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteRequest);
     io:println("Test entity deleted");
     
     return;
@@ -381,8 +381,8 @@ function testEntityMetadataRetrieval() returns error? {
     test:assertEquals(actualValues["model"], "Sensor X1", "Metadata value for model doesn't match");
     
     // Clean up
-    EntityId deleteEntityRequest = {id: testId};
-    Empty _ = check ep->DeleteEntity(deleteEntityRequest);
+    DeleteEntityRequest deleteEntityRequest = {id: testId};
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteEntityRequest);
     io:println("Test entity deleted");
     
     return;
@@ -515,14 +515,14 @@ function testEntityRelationshipsRetrieval() returns error? {
     test:assertEquals(respNone.relationships.length(), 0, "Should return no relationships for non-existent name");
 
     // Clean up - delete is not yet working
-    EntityId deleteParent = {id: entityId};
-    EntityId deleteChild1 = {id: relatedId1};
-    EntityId deleteChild2 = {id: relatedId2};
-    EntityId deleteChild3 = {id: relatedId3};
-    Empty _ = check ep->DeleteEntity(deleteParent);
-    Empty _ = check ep->DeleteEntity(deleteChild1);
-    Empty _ = check ep->DeleteEntity(deleteChild2);
-    Empty _ = check ep->DeleteEntity(deleteChild3);
+    DeleteEntityRequest deleteParent = {id: entityId};
+    DeleteEntityRequest deleteChild1 = {id: relatedId1};
+    DeleteEntityRequest deleteChild2 = {id: relatedId2};
+    DeleteEntityRequest deleteChild3 = {id: relatedId3};
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteParent);
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteChild1);
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteChild2);
+    DeleteEntityResponse _ = check ep->DeleteEntity(deleteChild3);
     io:println("Test entities deleted");
     return;
 }
@@ -725,8 +725,8 @@ function testEntitySearch() returns error? {
     
     // Clean up
     foreach string id in testIds {
-        EntityId deleteRequest = {id: id};
-        Empty _ = check coreClient->DeleteEntity(deleteRequest);
+        DeleteEntityRequest deleteRequest = {id: id};
+        DeleteEntityResponse _ = check coreClient->DeleteEntity(deleteRequest);
     }
     io:println("Test entities deleted");
     
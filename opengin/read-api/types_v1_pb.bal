@@ -5,7 +5,7 @@ import ballerina/grpc;
 import ballerina/protobuf;
 import ballerina/protobuf.types.'any;
 
-public const string TYPES_V1_DESC = "0A0E74797065735F76312E70726F746F1204636F72651A19676F6F676C652F70726F746F6275662F616E792E70726F746F22320A044B696E6412140A056D616A6F7218012001280952056D616A6F7212140A056D696E6F7218022001280952056D696E6F7222740A0E54696D65426173656456616C7565121C0A09737461727454696D651801200128095209737461727454696D6512180A07656E6454696D651802200128095207656E6454696D65122A0A0576616C756518032001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C756522B2010A0C52656C6174696F6E73686970120E0A0269641801200128095202696412280A0F72656C61746564456E746974794964180220012809520F72656C61746564456E74697479496412120A046E616D6518032001280952046E616D65121C0A09737461727454696D651804200128095209737461727454696D6512180A07656E6454696D651805200128095207656E6454696D65121C0A09646972656374696F6E1806200128095209646972656374696F6E22DB040A06456E74697479120E0A02696418012001280952026964121E0A046B696E6418022001280B320A2E636F72652E4B696E6452046B696E6412180A0763726561746564180320012809520763726561746564121E0A0A7465726D696E61746564180420012809520A7465726D696E6174656412280A046E616D6518052001280B32142E636F72652E54696D65426173656456616C756552046E616D6512360A086D6574616461746118062003280B321A2E636F72652E456E746974792E4D65746164617461456E74727952086D65746164617461123C0A0A6174747269627574657318072003280B321C2E636F72652E456E746974792E41747472696275746573456E747279520A6174747269627574657312450A0D72656C6174696F6E736869707318082003280B321F2E636F72652E456E746974792E52656C6174696F6E7368697073456E747279520D72656C6174696F6E73686970731A510A0D4D65746164617461456E74727912100A036B657918012001280952036B6579122A0A0576616C756518022001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75653A0238011A570A0F41747472696275746573456E74727912100A036B657918012001280952036B6579122E0A0576616C756518022001280B32182E636F72652E54696D65426173656456616C75654C697374520576616C75653A0238011A540A1252656C6174696F6E7368697073456E74727912100A036B657918012001280952036B657912280A0576616C756518022001280B32122E636F72652E52656C6174696F6E73686970520576616C75653A02380122420A1254696D65426173656456616C75654C697374122C0A0676616C75657318012003280B32142E636F72652E54696D65426173656456616C7565520676616C756573226D0A1152656164456E746974795265717565737412240A06656E7469747918012001280B320C2E636F72652E456E746974795206656E7469747912160A066F757470757418022003280952066F7574707574121A0A08616374697665417418032001280952086163746976654174221A0A08456E746974794964120E0A02696418012001280952026964224B0A13557064617465456E7469747952657175657374120E0A0269641801200128095202696412240A06656E7469747918022001280B320C2E636F72652E456E746974795206656E7469747922070A05456D70747922360A0A456E746974794C69737412280A08656E74697469657318012003280B320C2E636F72652E456E746974795208656E746974696573328F020A0B434F524553657276696365122A0A0C437265617465456E74697479120C2E636F72652E456E746974791A0C2E636F72652E456E7469747912330A0A52656164456E7469747912172E636F72652E52656164456E74697479526571756573741A0C2E636F72652E456E7469747912390A0C52656164456E74697469657312172E636F72652E52656164456E74697479526571756573741A102E636F72652E456E746974794C69737412370A0C557064617465456E7469747912192E636F72652E557064617465456E74697479526571756573741A0C2E636F72652E456E74697479122B0A0C44656C657465456E74697479120E2E636F72652E456E7469747949641A0B2E636F72652E456D707479421C5A1A6C6B2F64617461666F756E646174696F6E2F636F72652D617069620670726F746F33";
+public const string TYPES_V1_DESC = "0A0E74797065735F76312E70726F746F1204636F72651A19676F6F676C652F70726F746F6275662F616E792E70726F746F22320A044B696E6412140A056D616A6F7218012001280952056D616A6F7212140A056D696E6F7218022001280952056D696E6F7222740A0E54696D65426173656456616C7565121C0A09737461727454696D651801200128095209737461727454696D6512180A07656E6454696D651802200128095207656E6454696D65122A0A0576616C756518032001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75652282030A0C52656C6174696F6E73686970120E0A0269641801200128095202696412280A0F72656C61746564456E746974794964180220012809520F72656C61746564456E74697479496412120A046E616D6518032001280952046E616D65121C0A09737461727454696D651804200128095209737461727454696D6512180A07656E6454696D651805200128095207656E6454696D65121C0A09646972656374696F6E1806200128095209646972656374696F6E12420A0A70726F7065727469657318072003280B32222E636F72652E52656C6174696F6E736869702E50726F70657274696573456E747279520A70726F7065727469657312350A0876657273696F6E7318082003280B32192E636F72652E52656C6174696F6E7368697056657273696F6E520876657273696F6E731A530A0F50726F70657274696573456E74727912100A036B657918012001280952036B6579122A0A0576616C756518022001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75653A02380122B1020A1352656C6174696F6E7368697056657273696F6E121C0A09737461727454696D651801200128095209737461727454696D6512180A07656E6454696D651802200128095207656E6454696D6512490A0A70726F7065727469657318032003280B32292E636F72652E52656C6174696F6E7368697056657273696F6E2E50726F70657274696573456E747279520A70726F70657274696573121E0A0A7265636F726465644174180420012809520A7265636F72646564417412220A0C737570657273656465644174180520012809520C7375706572736564656441741A530A0F50726F70657274696573456E74727912100A036B657918012001280952036B6579122A0A0576616C756518022001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75653A02380122F5040A06456E74697479120E0A02696418012001280952026964121E0A046B696E6418022001280B320A2E636F72652E4B696E6452046B696E6412180A0763726561746564180320012809520763726561746564121E0A0A7465726D696E61746564180420012809520A7465726D696E6174656412280A046E616D6518052001280B32142E636F72652E54696D65426173656456616C756552046E616D6512360A086D6574616461746118062003280B321A2E636F72652E456E746974792E4D65746164617461456E74727952086D65746164617461123C0A0A6174747269627574657318072003280B321C2E636F72652E456E746974792E41747472696275746573456E747279520A6174747269627574657312450A0D72656C6174696F6E736869707318082003280B321F2E636F72652E456E746974792E52656C6174696F6E7368697073456E747279520D72656C6174696F6E736869707312180A0776657273696F6E180920012803520776657273696F6E1A510A0D4D65746164617461456E74727912100A036B657918012001280952036B6579122A0A0576616C756518022001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75653A0238011A570A0F41747472696275746573456E74727912100A036B657918012001280952036B6579122E0A0576616C756518022001280B32182E636F72652E54696D65426173656456616C75654C697374520576616C75653A0238011A540A1252656C6174696F6E7368697073456E74727912100A036B657918012001280952036B657912280A0576616C756518022001280B32122E636F72652E52656C6174696F6E73686970520576616C75653A02380122420A1254696D65426173656456616C75654C697374122C0A0676616C75657318012003280B32142E636F72652E54696D65426173656456616C7565520676616C75657322EF010A1152656164456E746974795265717565737412240A06656E7469747918012001280B320C2E636F72652E456E746974795206656E7469747912160A066F757470757418022003280952066F7574707574121A0A08616374697665417418032001280952086163746976654174121A0A087061676553697A6518042001280552087061676553697A65121C0A0970616765546F6B656E180520012809520970616765546F6B656E12180A076F72646572427918062001280952076F726465724279122C0A11696E636C756465546F74616C436F756E741807200128085211696E636C756465546F74616C436F756E74221A0A08456E746974794964120E0A0269641801200128095202696422570A1344656C657465456E7469747952657175657374120E0A0269641801200128095202696412180A076361736361646518022001280852076361736361646512160A0664727952756E180320012808520664727952756E22CE010A1444656C657465456E74697479526573706F6E7365120E0A0269641801200128095202696412160A0664727952756E180220012808520664727952756E12280A0F72656C6174696F6E73686970496473180320032809520F72656C6174696F6E73686970496473121E0A0A61747472696275746573180420032809520A6174747269627574657312280A0F6174747269627574655461626C6573180520032809520F6174747269627574655461626C6573121A0A086D6574616461746118062001280852086D65746164617461226F0A174261746368437265617465456E74697479526573756C7412140A05696E6465781801200128055205696E646578120E0A0269641802200128095202696412180A077375636365737318032001280852077375636365737312140A056572726F7218042001280952056572726F722288010A1B4261746368437265617465456E746974696573526573706F6E736512370A07726573756C747318012003280B321D2E636F72652E4261746368437265617465456E74697479526573756C745207726573756C747312180A076372656174656418022001280552076372656174656412160A066661696C656418032001280552066661696C656422750A13557064617465456E7469747952657175657374120E0A0269641801200128095202696412240A06656E7469747918022001280B320C2E636F72652E456E746974795206656E7469747912280A0F657870656374656456657273696F6E180320012803520F657870656374656456657273696F6E22070A05456D707479227C0A0A456E746974794C69737412280A08656E74697469657318012003280B320C2E636F72652E456E746974795208656E74697469657312240A0D6E65787450616765546F6B656E180220012809520D6E65787450616765546F6B656E121E0A0A746F74616C436F756E74180320012803520A746F74616C436F756E7422AA010A145761746368456E7469746965735265717565737412200A056B696E647318012003280B320A2E636F72652E4B696E6452056B696E6473121C0A09656E746974794964731802200328095209656E74697479496473122C0A1172656C6174696F6E736869704E616D6573180320032809521172656C6174696F6E736869704E616D657312240A0D616674657253657175656E6365180420012804520D616674657253657175656E636522DF010A0B456E746974794576656E74121A0A0873657175656E6365180120012804520873657175656E636512120A0474797065180220012809520474797065121A0A08656E7469747949641803200128095208656E746974794964121E0A046B696E6418042001280B320A2E636F72652E4B696E6452046B696E6412180A0776657273696F6E180520012803520776657273696F6E12360A0C72656C6174696F6E7368697018062001280B32122E636F72652E52656C6174696F6E73686970520C72656C6174696F6E7368697012120A0474696D65180720012809520474696D6522560A224C6973744661696C6564576562686F6F6B44656C6976657269657352657175657374121A0A08656E64706F696E741801200128095208656E64706F696E7412140A056C696D697418022001280552056C696D697422D8010A0F576562686F6F6B44656C6976657279120E0A0269641801200128095202696412180A076576656E74496418022001280952076576656E744964121A0A08656E64706F696E741803200128095208656E64706F696E7412270A056576656E7418042001280B32112E636F72652E456E746974794576656E7452056576656E74121A0A08617474656D7074731805200128055208617474656D707473121C0A096C6173744572726F7218062001280952096C6173744572726F72121C0A096372656174656441741807200128095209637265617465644174224C0A13576562686F6F6B44656C69766572794C69737412350A0A64656C6976657269657318012003280B32152E636F72652E576562686F6F6B44656C6976657279520A64656C69766572696573224E0A1E5265706C6179576562686F6F6B44656C697665726965735265717565737412100A036964731801200328095203696473121A0A08656E64706F696E741802200128095208656E64706F696E74223D0A1F5265706C6179576562686F6F6B44656C69766572696573526573706F6E7365121A0A087265706C6179656418012001280352087265706C6179656422B9010A135265616441756469744C6F6752657175657374121A0A08656E7469747949641801200128095208656E74697479496412140A056163746F7218022001280952056163746F72121C0A09737461727454696D651803200128095209737461727454696D6512180A07656E6454696D651804200128095207656E6454696D65121A0A087061676553697A6518052001280552087061676553697A65121C0A0970616765546F6B656E180620012809520970616765546F6B656E22A1020A0B41756469745265636F7264120E0A0269641801200128095202696412120A0474696D65180220012809520474696D6512140A056163746F7218032001280952056163746F72121E0A0A617574684D6574686F64180420012809520A617574684D6574686F6412160A066D6574686F6418052001280952066D6574686F64121C0A097265717565737449641806200128095209726571756573744964121A0A08656E7469747949641807200128095208656E746974794964122B0A076368616E67657318082003280B32112E636F72652E41756469744368616E676552076368616E67657312390A0A6174747269627574657318092003280B32192E636F72652E41756469744174747269627574655772697465520A6174747269627574657322510A0B41756469744368616E676512140A056669656C6418012001280952056669656C6412160A066265666F726518022001280952066265666F726512140A05616674657218032001280952056166746572225B0A134175646974417474726962757465577269746512120A046E616D6518012001280952046E616D6512160A0676616C756573180220012805520676616C75657312180A0764656C65746564180320012808520764656C65746564225D0A0841756469744C6F67122B0A077265636F72647318012003280B32112E636F72652E41756469745265636F726452077265636F72647312240A0D6E65787450616765546F6B656E180220012809520D6E65787450616765546F6B656E22620A1852656164456E74697479486973746F727952657175657374120E0A02696418012001280952026964121C0A09737461727454696D651802200128095209737461727454696D6512180A07656E6454696D651803200128095207656E6454696D6522DF010A104D657461646174615265766973696F6E121C0A09737461727454696D651801200128095209737461727454696D6512180A07656E6454696D651802200128095207656E6454696D6512400A086D6574616461746118032003280B32242E636F72652E4D657461646174615265766973696F6E2E4D65746164617461456E74727952086D657461646174611A510A0D4D65746164617461456E74727912100A036B657918012001280952036B6579122A0A0576616C756518022001280B32142E676F6F676C652E70726F746F6275662E416E79520576616C75653A02380122B1030A0D456E74697479486973746F7279120E0A02696418012001280952026964121E0A046B696E6418022001280B320A2E636F72652E4B696E6452046B696E6412180A0763726561746564180320012809520763726561746564121E0A0A7465726D696E61746564180420012809520A7465726D696E61746564122A0A056E616D657318052003280B32142E636F72652E54696D65426173656456616C756552056E616D657312320A086D6574616461746118062003280B32162E636F72652E4D657461646174615265766973696F6E52086D6574616461746112380A0D72656C6174696F6E736869707318072003280B32122E636F72652E52656C6174696F6E73686970520D72656C6174696F6E736869707312430A0A6174747269627574657318082003280B32232E636F72652E456E74697479486973746F72792E41747472696275746573456E747279520A617474726962757465731A570A0F41747472696275746573456E74727912100A036B657918012001280952036B6579122E0A0576616C756518022001280B32182E636F72652E54696D65426173656456616C75654C697374520576616C75653A02380122570A1144696666456E7469747952657175657374120E0A02696418012001280952026964121A0A0866726F6D54696D65180220012809520866726F6D54696D6512160A06746F54696D651803200128095206746F54696D65223A0A0A4E616D654368616E676512160A066265666F726518012001280952066265666F726512140A056166746572180220012809520561667465722290010A0E4D657461646174614368616E676512100A036B657918012001280952036B657912120A0474797065180220012809520474797065122C0A066265666F726518032001280B32142E676F6F676C652E70726F746F6275662E416E7952066265666F7265122A0A05616674657218042001280B32142E676F6F676C652E70726F746F6275662E416E7952056166746572227E0A1252656C6174696F6E736869704368616E676512120A0474797065180120012809520474797065122A0A066265666F726518022001280B32122E636F72652E52656C6174696F6E7368697052066265666F726512280A05616674657218032001280B32122E636F72652E52656C6174696F6E736869705205616674657222A5010A0F4174747269627574654368616E676512120A046E616D6518012001280952046E616D6512120A047479706518022001280952047479706512320A096164646564526F777318032001280B32142E676F6F676C652E70726F746F6275662E416E7952096164646564526F777312360A0B72656D6F766564526F777318042001280B32142E676F6F676C652E70726F746F6275662E416E79520B72656D6F766564526F7773229F020A0A456E7469747944696666120E0A02696418012001280952026964121A0A0866726F6D54696D65180220012809520866726F6D54696D6512160A06746F54696D651803200128095206746F54696D6512240A046E616D6518042001280B32102E636F72652E4E616D654368616E676552046E616D6512300A086D6574616461746118052003280B32142E636F72652E4D657461646174614368616E676552086D65746164617461123E0A0D72656C6174696F6E736869707318062003280B32182E636F72652E52656C6174696F6E736869704368616E6765520D72656C6174696F6E736869707312350A0A6174747269627574657318072003280B32152E636F72652E4174747269627574654368616E6765520A6174747269627574657322E3010A0F547261766572736552657175657374120E0A02696418012001280952026964122C0A1172656C6174696F6E736869704E616D6573180220032809521172656C6174696F6E736869704E616D6573121C0A09646972656374696F6E1803200128095209646972656374696F6E121A0A086D696E446570746818042001280552086D696E4465707468121A0A086D6178446570746818052001280552086D61784465707468121A0A0861637469766541741806200128095208616374697665417412200A056B696E647318072003280B320A2E636F72652E4B696E6452056B696E647322650A0947726170684E6F6465120E0A02696418012001280952026964121E0A046B696E6418022001280B320A2E636F72652E4B696E6452046B696E6412120A046E616D6518032001280952046E616D6512140A05646570746818042001280552056465707468229F010A09477261706845646765120E0A0269641801200128095202696412120A046E616D6518022001280952046E616D65121A0A08736F7572636549641803200128095208736F757263654964121A0A08746172676574496418042001280952087461726765744964121C0A09737461727454696D651805200128095209737461727454696D6512180A07656E6454696D651806200128095207656E6454696D6522730A05477261706812250A056E6F64657318012003280B320F2E636F72652E47726170684E6F646552056E6F64657312250A05656467657318022003280B320F2E636F72652E47726170684564676552056564676573121C0A097472756E636174656418032001280852097472756E636174656422D4010A1046696E6450617468735265717565737412160A0666726F6D4964180120012809520666726F6D496412120A04746F49641802200128095204746F4964122C0A1172656C6174696F6E736869704E616D6573180320032809521172656C6174696F6E736869704E616D6573121A0A08616374697665417418042001280952086163746976654174121C0A096D61784C656E67746818052001280552096D61784C656E67746812100A03616C6C1806200128085203616C6C121A0A086D6178506174687318072001280552086D61785061746873224E0A0450617468121C0A09656E746974794964731801200328095209656E7469747949647312280A0F72656C6174696F6E73686970496473180220032809520F72656C6174696F6E73686970496473222C0A08506174684C69737412200A05706174687318012003280B320A2E636F72652E506174685205706174687322D8010A145265616448696572617263687952657175657374120E0A02696418012001280952026964122C0A1172656C6174696F6E736869704E616D6573180220032809521172656C6174696F6E736869704E616D6573121C0A09646972656374696F6E1803200128095209646972656374696F6E121A0A08616374697665417418042001280952086163746976654174121A0A086D6178446570746818052001280552086D61784465707468122C0A11696E636C7564654368696C64436F756E741806200128085211696E636C7564654368696C64436F756E7422CC010A0D4869657261726368794E6F6465120E0A02696418012001280952026964121E0A046B696E6418022001280B320A2E636F72652E4B696E6452046B696E6412120A046E616D6518032001280952046E616D6512260A0E72656C6174696F6E736869704964180420012809520E72656C6174696F6E736869704964121E0A0A6368696C64436F756E74180520012805520A6368696C64436F756E74122F0A086368696C6472656E18062003280B32132E636F72652E4869657261726368794E6F646552086368696C6472656E22520A0948696572617263687912270A04726F6F7418012001280B32132E636F72652E4869657261726368794E6F64655204726F6F74121C0A097472756E636174656418022001280852097472756E636174656432E2070A0B434F524553657276696365122A0A0C437265617465456E74697479120C2E636F72652E456E746974791A0C2E636F72652E456E7469747912330A0A52656164456E7469747912172E636F72652E52656164456E74697479526571756573741A0C2E636F72652E456E7469747912390A0C52656164456E74697469657312172E636F72652E52656164456E74697479526571756573741A102E636F72652E456E746974794C69737412370A0C557064617465456E7469747912192E636F72652E557064617465456E74697479526571756573741A0C2E636F72652E456E7469747912450A0C44656C657465456E7469747912192E636F72652E44656C657465456E74697479526571756573741A1A2E636F72652E44656C657465456E74697479526573706F6E736512480A134261746368437265617465456E746974696573120C2E636F72652E456E746974791A212E636F72652E4261746368437265617465456E746974696573526573706F6E7365280112400A0D5761746368456E746974696573121A2E636F72652E5761746368456E746974696573526571756573741A112E636F72652E456E746974794576656E74300112620A1B4C6973744661696C6564576562686F6F6B44656C6976657269657312282E636F72652E4C6973744661696C6564576562686F6F6B44656C69766572696573526571756573741A192E636F72652E576562686F6F6B44656C69766572794C69737412660A175265706C6179576562686F6F6B44656C6976657269657312242E636F72652E5265706C6179576562686F6F6B44656C69766572696573526571756573741A252E636F72652E5265706C6179576562686F6F6B44656C69766572696573526573706F6E736512390A0C5265616441756469744C6F6712192E636F72652E5265616441756469744C6F67526571756573741A0E2E636F72652E41756469744C6F6712480A1152656164456E74697479486973746F7279121E2E636F72652E52656164456E74697479486973746F7279526571756573741A132E636F72652E456E74697479486973746F727912370A0A44696666456E7469747912172E636F72652E44696666456E74697479526571756573741A102E636F72652E456E7469747944696666122E0A08547261766572736512152E636F72652E5472617665727365526571756573741A0B2E636F72652E477261706812330A0946696E64506174687312162E636F72652E46696E645061746873526571756573741A0E2E636F72652E506174684C697374123C0A0D52656164486965726172636879121A2E636F72652E52656164486965726172636879526571756573741A0F2E636F72652E486965726172636879421C5A1A6C6B2F64617461666F756E646174696F6E2F636F72652D617069620670726F746F33";
 
 public isolated client class COREServiceClient {
     *grpc:AbstractClientEndpoint;
@@ -129,10 +129,10 @@ public isolated client class COREServiceClient {
         return {content: <Entity>result, headers: respHeaders};
     }
 
-    isolated remote function DeleteEntity(EntityId|ContextEntityId req) returns Empty|grpc:Error {
+    isolated remote function DeleteEntity(DeleteEntityRequest|ContextDeleteEntityRequest req) returns DeleteEntityResponse|grpc:Error {
         map<string|string[]> headers = {};
-        EntityId message;
-        if req is ContextEntityId {
+        DeleteEntityRequest message;
+        if req is ContextDeleteEntityRequest {
             message = req.content;
             headers = req.headers;
         } else {
@@ -140,13 +140,13 @@ public isolated client class COREServiceClient {
         }
         var payload = check self.grpcClient->executeSimpleRPC("core.COREService/DeleteEntity", message, headers);
         [anydata, map<string|string[]>] [result, _] = payload;
-        return <Empty>result;
+        return <DeleteEntityResponse>result;
     }
 
-    isolated remote function DeleteEntityContext(EntityId|ContextEntityId req) returns ContextEmpty|grpc:Error {
+    isolated remote function DeleteEntityContext(DeleteEntityRequest|ContextDeleteEntityRequest req) returns ContextDeleteEntityResponse|grpc:Error {
         map<string|string[]> headers = {};
-        EntityId message;
-        if req is ContextEntityId {
+        DeleteEntityRequest message;
+        if req is ContextDeleteEntityRequest {
             message = req.content;
             headers = req.headers;
         } else {
@@ -154,22 +154,349 @@ public isolated client class COREServiceClient {
         }
         var payload = check self.grpcClient->executeSimpleRPC("core.COREService/DeleteEntity", message, headers);
         [anydata, map<string|string[]>] [result, respHeaders] = payload;
-        return {content: <Empty>result, headers: respHeaders};
+        return {content: <DeleteEntityResponse>result, headers: respHeaders};
+    }
+
+    isolated remote function WatchEntities(WatchEntitiesRequest|ContextWatchEntitiesRequest req) returns EntityEvent|grpc:Error {
+        map<string|string[]> headers = {};
+        WatchEntitiesRequest message;
+        if req is ContextWatchEntitiesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeServerStreaming("core.COREService/WatchEntities", message, headers);
+        [stream<anydata, grpc:Error?>, map<string|string[]>] [result, _] = payload;
+        EntityEventStream outputStream = new EntityEventStream(result);
+        return new stream<EntityEvent, grpc:Error?>(outputStream);
+    }
+
+    isolated remote function WatchEntitiesContext(WatchEntitiesRequest|ContextWatchEntitiesRequest req) returns ContextEntityEvent|grpc:Error {
+        map<string|string[]> headers = {};
+        WatchEntitiesRequest message;
+        if req is ContextWatchEntitiesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeServerStreaming("core.COREService/WatchEntities", message, headers);
+        [stream<anydata, grpc:Error?>, map<string|string[]>] [result, respHeaders] = payload;
+        EntityEventStream outputStream = new EntityEventStream(result);
+        return {content: new stream<EntityEvent, grpc:Error?>(outputStream), headers: respHeaders};
+    }
+
+    isolated remote function ListFailedWebhookDeliveries(ListFailedWebhookDeliveriesRequest|ContextListFailedWebhookDeliveriesRequest req) returns WebhookDeliveryList|grpc:Error {
+        map<string|string[]> headers = {};
+        ListFailedWebhookDeliveriesRequest message;
+        if req is ContextListFailedWebhookDeliveriesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ListFailedWebhookDeliveries", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <WebhookDeliveryList>result;
+    }
+
+    isolated remote function ListFailedWebhookDeliveriesContext(ListFailedWebhookDeliveriesRequest|ContextListFailedWebhookDeliveriesRequest req) returns ContextWebhookDeliveryList|grpc:Error {
+        map<string|string[]> headers = {};
+        ListFailedWebhookDeliveriesRequest message;
+        if req is ContextListFailedWebhookDeliveriesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ListFailedWebhookDeliveries", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <WebhookDeliveryList>result, headers: respHeaders};
+    }
+
+    isolated remote function ReplayWebhookDeliveries(ReplayWebhookDeliveriesRequest|ContextReplayWebhookDeliveriesRequest req) returns ReplayWebhookDeliveriesResponse|grpc:Error {
+        map<string|string[]> headers = {};
+        ReplayWebhookDeliveriesRequest message;
+        if req is ContextReplayWebhookDeliveriesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReplayWebhookDeliveries", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <ReplayWebhookDeliveriesResponse>result;
+    }
+
+    isolated remote function ReplayWebhookDeliveriesContext(ReplayWebhookDeliveriesRequest|ContextReplayWebhookDeliveriesRequest req) returns ContextReplayWebhookDeliveriesResponse|grpc:Error {
+        map<string|string[]> headers = {};
+        ReplayWebhookDeliveriesRequest message;
+        if req is ContextReplayWebhookDeliveriesRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReplayWebhookDeliveries", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <ReplayWebhookDeliveriesResponse>result, headers: respHeaders};
+    }
+
+    isolated remote function ReadAuditLog(ReadAuditLogRequest|ContextReadAuditLogRequest req) returns AuditLog|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadAuditLogRequest message;
+        if req is ContextReadAuditLogRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadAuditLog", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <AuditLog>result;
+    }
+
+    isolated remote function ReadAuditLogContext(ReadAuditLogRequest|ContextReadAuditLogRequest req) returns ContextAuditLog|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadAuditLogRequest message;
+        if req is ContextReadAuditLogRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadAuditLog", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <AuditLog>result, headers: respHeaders};
+    }
+
+    isolated remote function ReadEntityHistory(ReadEntityHistoryRequest|ContextReadEntityHistoryRequest req) returns EntityHistory|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadEntityHistoryRequest message;
+        if req is ContextReadEntityHistoryRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadEntityHistory", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <EntityHistory>result;
+    }
+
+    isolated remote function ReadEntityHistoryContext(ReadEntityHistoryRequest|ContextReadEntityHistoryRequest req) returns ContextEntityHistory|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadEntityHistoryRequest message;
+        if req is ContextReadEntityHistoryRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadEntityHistory", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <EntityHistory>result, headers: respHeaders};
+    }
+
+    isolated remote function DiffEntity(DiffEntityRequest|ContextDiffEntityRequest req) returns EntityDiff|grpc:Error {
+        map<string|string[]> headers = {};
+        DiffEntityRequest message;
+        if req is ContextDiffEntityRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/DiffEntity", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <EntityDiff>result;
+    }
+
+    isolated remote function DiffEntityContext(DiffEntityRequest|ContextDiffEntityRequest req) returns ContextEntityDiff|grpc:Error {
+        map<string|string[]> headers = {};
+        DiffEntityRequest message;
+        if req is ContextDiffEntityRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/DiffEntity", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <EntityDiff>result, headers: respHeaders};
+    }
+
+    isolated remote function Traverse(TraverseRequest|ContextTraverseRequest req) returns Graph|grpc:Error {
+        map<string|string[]> headers = {};
+        TraverseRequest message;
+        if req is ContextTraverseRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/Traverse", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <Graph>result;
+    }
+
+    isolated remote function TraverseContext(TraverseRequest|ContextTraverseRequest req) returns ContextGraph|grpc:Error {
+        map<string|string[]> headers = {};
+        TraverseRequest message;
+        if req is ContextTraverseRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/Traverse", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <Graph>result, headers: respHeaders};
+    }
+
+    isolated remote function FindPaths(FindPathsRequest|ContextFindPathsRequest req) returns PathList|grpc:Error {
+        map<string|string[]> headers = {};
+        FindPathsRequest message;
+        if req is ContextFindPathsRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/FindPaths", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <PathList>result;
+    }
+
+    isolated remote function FindPathsContext(FindPathsRequest|ContextFindPathsRequest req) returns ContextPathList|grpc:Error {
+        map<string|string[]> headers = {};
+        FindPathsRequest message;
+        if req is ContextFindPathsRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/FindPaths", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <PathList>result, headers: respHeaders};
+    }
+
+    isolated remote function ReadHierarchy(ReadHierarchyRequest|ContextReadHierarchyRequest req) returns Hierarchy|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadHierarchyRequest message;
+        if req is ContextReadHierarchyRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadHierarchy", message, headers);
+        [anydata, map<string|string[]>] [result, _] = payload;
+        return <Hierarchy>result;
+    }
+
+    isolated remote function ReadHierarchyContext(ReadHierarchyRequest|ContextReadHierarchyRequest req) returns ContextHierarchy|grpc:Error {
+        map<string|string[]> headers = {};
+        ReadHierarchyRequest message;
+        if req is ContextReadHierarchyRequest {
+            message = req.content;
+            headers = req.headers;
+        } else {
+            message = req;
+        }
+        var payload = check self.grpcClient->executeSimpleRPC("core.COREService/ReadHierarchy", message, headers);
+        [anydata, map<string|string[]>] [result, respHeaders] = payload;
+        return {content: <Hierarchy>result, headers: respHeaders};
+    }
+
+    isolated remote function BatchCreateEntities() returns BatchCreateEntitiesStreamingClient|grpc:Error {
+        grpc:StreamingClient sClient = check self.grpcClient->executeClientStreaming("core.COREService/BatchCreateEntities");
+        return new BatchCreateEntitiesStreamingClient(sClient);
     }
 }
 
-public type ContextEntityId record {|
-    EntityId content;
-    map<string|string[]> headers;
-|};
+public isolated client class BatchCreateEntitiesStreamingClient {
+    private final grpc:StreamingClient sClient;
+
+    isolated function init(grpc:StreamingClient sClient) {
+        self.sClient = sClient;
+    }
+
+    isolated remote function sendEntity(Entity message) returns grpc:Error? {
+        return self.sClient->send(message);
+    }
+
+    isolated remote function sendContextEntity(ContextEntity message) returns grpc:Error? {
+        return self.sClient->send(message);
+    }
+
+    isolated remote function receiveBatchCreateEntitiesResponse() returns BatchCreateEntitiesResponse|grpc:Error? {
+        var response = check self.sClient->receive();
+        if response is () {
+            return response;
+        } else {
+            [anydata, map<string|string[]>] [payload, _] = response;
+            return <BatchCreateEntitiesResponse>payload;
+        }
+    }
+
+    isolated remote function receiveContextBatchCreateEntitiesResponse() returns ContextBatchCreateEntitiesResponse|grpc:Error? {
+        var response = check self.sClient->receive();
+        if response is () {
+            return response;
+        } else {
+            [anydata, map<string|string[]>] [payload, headers] = response;
+            return {content: <BatchCreateEntitiesResponse>payload, headers: headers};
+        }
+    }
+
+    isolated remote function sendError(grpc:Error response) returns grpc:Error? {
+        return self.sClient->sendError(response);
+    }
+
+    isolated remote function complete() returns grpc:Error? {
+        return self.sClient->complete();
+    }
+}
+
+public class EntityEventStream {
+    private stream<anydata, grpc:Error?> anydataStream;
+
+    public isolated function init(stream<anydata, grpc:Error?> anydataStream) {
+        self.anydataStream = anydataStream;
+    }
+
+    public isolated function next() returns record {|EntityEvent value;|}|grpc:Error? {
+        var streamValue = self.anydataStream.next();
+        if streamValue is () {
+            return streamValue;
+        } else if streamValue is grpc:Error {
+            return streamValue;
+        } else {
+            record {|EntityEvent value;|} nextRecord = {value: <EntityEvent>streamValue.value};
+            return nextRecord;
+        }
+    }
+
+    public isolated function close() returns grpc:Error? {
+        return self.anydataStream.close();
+    }
+}
 
 public type ContextEntity record {|
     Entity content;
     map<string|string[]> headers;
 |};
 
-public type ContextEmpty record {|
-    Empty content;
+public type ContextReadEntityRequest record {|
+    ReadEntityRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextEntityList record {|
+    EntityList content;
     map<string|string[]> headers;
 |};
 
@@ -178,19 +505,143 @@ public type ContextUpdateEntityRequest record {|
     map<string|string[]> headers;
 |};
 
-public type ContextEntityList record {|
-    EntityList content;
+public type ContextDeleteEntityRequest record {|
+    DeleteEntityRequest content;
     map<string|string[]> headers;
 |};
 
-public type ContextReadEntityRequest record {|
-    ReadEntityRequest content;
+public type ContextDeleteEntityResponse record {|
+    DeleteEntityResponse content;
+    map<string|string[]> headers;
+|};
+
+public type ContextWatchEntitiesRequest record {|
+    WatchEntitiesRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextEntityEventStream record {|
+    stream<EntityEvent, error?> content;
+    map<string|string[]> headers;
+|};
+
+public type ContextListFailedWebhookDeliveriesRequest record {|
+    ListFailedWebhookDeliveriesRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextWebhookDeliveryList record {|
+    WebhookDeliveryList content;
+    map<string|string[]> headers;
+|};
+
+public type ContextReplayWebhookDeliveriesRequest record {|
+    ReplayWebhookDeliveriesRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextReplayWebhookDeliveriesResponse record {|
+    ReplayWebhookDeliveriesResponse content;
+    map<string|string[]> headers;
+|};
+
+public type ContextReadAuditLogRequest record {|
+    ReadAuditLogRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextAuditLog record {|
+    AuditLog content;
+    map<string|string[]> headers;
+|};
+
+public type ContextReadEntityHistoryRequest record {|
+    ReadEntityHistoryRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextEntityHistory record {|
+    EntityHistory content;
+    map<string|string[]> headers;
+|};
+
+public type ContextDiffEntityRequest record {|
+    DiffEntityRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextEntityDiff record {|
+    EntityDiff content;
+    map<string|string[]> headers;
+|};
+
+public type ContextTraverseRequest record {|
+    TraverseRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextGraph record {|
+    Graph content;
+    map<string|string[]> headers;
+|};
+
+public type ContextFindPathsRequest record {|
+    FindPathsRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextPathList record {|
+    PathList content;
+    map<string|string[]> headers;
+|};
+
+public type ContextReadHierarchyRequest record {|
+    ReadHierarchyRequest content;
+    map<string|string[]> headers;
+|};
+
+public type ContextHierarchy record {|
+    Hierarchy content;
+    map<string|string[]> headers;
+|};
+
+public type ContextBatchCreateEntitiesResponse record {|
+    BatchCreateEntitiesResponse content;
     map<string|string[]> headers;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type EntityId record {|
+public type Kind record {|
+    string major = "";
+    string minor = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type TimeBasedValue record {|
+    string startTime = "";
+    string endTime = "";
+    'any:Any value = {};
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type Relationship record {|
     string id = "";
+    string relatedEntityId = "";
+    string name = "";
+    string startTime = "";
+    string endTime = "";
+    string direction = "";
+    record {|string key; 'any:Any value;|}[] properties = [];
+    RelationshipVersion[] versions = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type RelationshipVersion record {|
+    string startTime = "";
+    string endTime = "";
+    record {|string key; 'any:Any value;|}[] properties = [];
+    string recordedAt = "";
+    string supersededAt = "";
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
@@ -203,54 +654,328 @@ public type Entity record {|
     record {|string key; 'any:Any value;|}[] metadata = [];
     record {|string key; TimeBasedValueList value;|}[] attributes = [];
     record {|string key; Relationship value;|}[] relationships = [];
+    int version = 0;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type TimeBasedValue record {|
-    string startTime = "";
-    string endTime = "";
-    'any:Any value = {};
+public type TimeBasedValueList record {|
+    TimeBasedValue[] values = [];
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type Empty record {|
+public type ReadEntityRequest record {|
+    Entity entity = {};
+    string[] output = [];
+    string activeAt = "";
+    int pageSize = 0;
+    string pageToken = "";
+    string orderBy = "";
+    boolean includeTotalCount = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type EntityId record {|
+    string id = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type DeleteEntityRequest record {|
+    string id = "";
+    boolean cascade = false;
+    boolean dryRun = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type DeleteEntityResponse record {|
+    string id = "";
+    boolean dryRun = false;
+    string[] relationshipIds = [];
+    string[] attributes = [];
+    string[] attributeTables = [];
+    boolean metadata = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type BatchCreateEntityResult record {|
+    int index = 0;
+    string id = "";
+    boolean success = false;
+    string error = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type BatchCreateEntitiesResponse record {|
+    BatchCreateEntityResult[] results = [];
+    int created = 0;
+    int failed = 0;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
 public type UpdateEntityRequest record {|
     string id = "";
     Entity entity = {};
+    int expectedVersion = 0;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type TimeBasedValueList record {|
-    TimeBasedValue[] values = [];
+public type Empty record {|
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type Kind record {|
-    string major = "";
-    string minor = "";
+public type EntityList record {|
+    Entity[] entities = [];
+    string nextPageToken = "";
+    int totalCount = 0;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type Relationship record {|
+public type WatchEntitiesRequest record {|
+    Kind[] kinds = [];
+    string[] entityIds = [];
+    string[] relationshipNames = [];
+    int afterSequence = 0;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type EntityEvent record {|
+    int sequence = 0;
+    string type = "";
+    string entityId = "";
+    Kind kind = {};
+    int version = 0;
+    Relationship relationship = {};
+    string time = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ListFailedWebhookDeliveriesRequest record {|
+    string endpoint = "";
+    int limit = 0;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type WebhookDelivery record {|
     string id = "";
-    string relatedEntityId = "";
+    string eventId = "";
+    string endpoint = "";
+    EntityEvent event = {};
+    int attempts = 0;
+    string lastError = "";
+    string createdAt = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type WebhookDeliveryList record {|
+    WebhookDelivery[] deliveries = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ReplayWebhookDeliveriesRequest record {|
+    string[] ids = [];
+    string endpoint = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ReplayWebhookDeliveriesResponse record {|
+    int replayed = 0;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ReadAuditLogRequest record {|
+    string entityId = "";
+    string actor = "";
+    string startTime = "";
+    string endTime = "";
+    int pageSize = 0;
+    string pageToken = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type AuditRecord record {|
+    string id = "";
+    string time = "";
+    string actor = "";
+    string authMethod = "";
+    string method = "";
+    string requestId = "";
+    string entityId = "";
+    AuditChange[] changes = [];
+    AuditAttributeWrite[] attributes = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type AuditChange record {|
+    string field = "";
+    string before = "";
+    string after = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type AuditAttributeWrite record {|
     string name = "";
+    int values = 0;
+    boolean deleted = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type AuditLog record {|
+    AuditRecord[] records = [];
+    string nextPageToken = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ReadEntityHistoryRequest record {|
+    string id = "";
+    string startTime = "";
+    string endTime = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type MetadataRevision record {|
     string startTime = "";
     string endTime = "";
+    record {|string key; 'any:Any value;|}[] metadata = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type EntityHistory record {|
+    string id = "";
+    Kind kind = {};
+    string created = "";
+    string terminated = "";
+    TimeBasedValue[] names = [];
+    MetadataRevision[] metadata = [];
+    Relationship[] relationships = [];
+    record {|string key; TimeBasedValueList value;|}[] attributes = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type DiffEntityRequest record {|
+    string id = "";
+    string fromTime = "";
+    string toTime = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type NameChange record {|
+    string before = "";
+    string after = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type MetadataChange record {|
+    string key = "";
+    string type = "";
+    'any:Any before = {};
+    'any:Any after = {};
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type RelationshipChange record {|
+    string type = "";
+    Relationship before = {};
+    Relationship after = {};
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type AttributeChange record {|
+    string name = "";
+    string type = "";
+    'any:Any addedRows = {};
+    'any:Any removedRows = {};
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type EntityDiff record {|
+    string id = "";
+    string fromTime = "";
+    string toTime = "";
+    NameChange name = {};
+    MetadataChange[] metadata = [];
+    RelationshipChange[] relationships = [];
+    AttributeChange[] attributes = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type TraverseRequest record {|
+    string id = "";
+    string[] relationshipNames = [];
     string direction = "";
+    int minDepth = 0;
+    int maxDepth = 0;
+    string activeAt = "";
+    Kind[] kinds = [];
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type EntityList record {|
-    Entity[] entities = [];
+public type GraphNode record {|
+    string id = "";
+    Kind kind = {};
+    string name = "";
+    int depth = 0;
 |};
 
 @protobuf:Descriptor {value: TYPES_V1_DESC}
-public type ReadEntityRequest record {|
-    Entity entity = {};
-    string[] output = [];
+public type GraphEdge record {|
+    string id = "";
+    string name = "";
+    string sourceId = "";
+    string targetId = "";
+    string startTime = "";
+    string endTime = "";
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type Graph record {|
+    GraphNode[] nodes = [];
+    GraphEdge[] edges = [];
+    boolean truncated = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type FindPathsRequest record {|
+    string fromId = "";
+    string toId = "";
+    string[] relationshipNames = [];
     string activeAt = "";
+    int maxLength = 0;
+    boolean all = false;
+    int maxPaths = 0;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type Path record {|
+    string[] entityIds = [];
+    string[] relationshipIds = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type PathList record {|
+    Path[] paths = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type ReadHierarchyRequest record {|
+    string id = "";
+    string[] relationshipNames = [];
+    string direction = "";
+    string activeAt = "";
+    int maxDepth = 0;
+    boolean includeChildCount = false;
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type HierarchyNode record {|
+    string id = "";
+    Kind kind = {};
+    string name = "";
+    string relationshipId = "";
+    int childCount = 0;
+    HierarchyNode[] children = [];
+|};
+
+@protobuf:Descriptor {value: TYPES_V1_DESC}
+public type Hierarchy record {|
+    HierarchyNode root = {};
+    boolean truncated = false;
 |};